@@ -20,6 +20,7 @@ type ServerConfig struct {
 	DB           DBConfig   `yaml:"db"`
 	GRPC         GRPCConfig `yaml:"grpc"`
 	Auth         AuthConfig `yaml:"auth"`
+	Jobs         JobsConfig `yaml:"jobs"`
 	FileLocation string     `yaml:"fileLocation" validate:"required"`
 }
 
@@ -54,6 +55,50 @@ type AuthConfig struct {
 	Secret string `yaml:"secret" validate:"required"`
 }
 
+// JobsConfig consists of fields for background jobs configuration
+type JobsConfig struct {
+	OrphanedUploads OrphanedUploadsConfig `yaml:"orphanedUploads"`
+	Versions        VersionsConfig        `yaml:"versions"`
+	Trash           TrashConfig           `yaml:"trash"`
+	Changes         ChangesConfig         `yaml:"changes"`
+	Sends           SendsConfig           `yaml:"sends"`
+	Emergency       EmergencyConfig       `yaml:"emergency"`
+}
+
+// OrphanedUploadsConfig consists of fields for orphaned uploads cleanup job configuration
+type OrphanedUploadsConfig struct {
+	Schedule string        `yaml:"schedule" validate:"required"`
+	TTL      time.Duration `yaml:"ttl" validate:"required"`
+}
+
+// VersionsConfig consists of fields for entity versions retention job configuration
+type VersionsConfig struct {
+	Schedule string `yaml:"schedule" validate:"required"`
+	Limit    int    `yaml:"limit" validate:"required,min=1"`
+}
+
+// TrashConfig consists of fields for trash purge job configuration
+type TrashConfig struct {
+	Schedule  string        `yaml:"schedule" validate:"required"`
+	Retention time.Duration `yaml:"retention" validate:"required"`
+}
+
+// ChangesConfig consists of fields for change log retention job configuration
+type ChangesConfig struct {
+	Schedule  string        `yaml:"schedule" validate:"required"`
+	Retention time.Duration `yaml:"retention" validate:"required"`
+}
+
+// SendsConfig consists of fields for expired sends purge job configuration
+type SendsConfig struct {
+	Schedule string `yaml:"schedule" validate:"required"`
+}
+
+// EmergencyConfig consists of fields for emergency access grant job configuration
+type EmergencyConfig struct {
+	Schedule string `yaml:"schedule" validate:"required"`
+}
+
 // MustLoad loads the ServerConfig from file
 func MustLoad() *ServerConfig {
 	path := configPath()
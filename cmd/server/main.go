@@ -36,7 +36,19 @@ func main() {
 	if err != nil {
 		log.Fatal(fmt.Errorf("error connecting to database: %w", err))
 	}
-	a := app.New(conf.GRPC.Port, pool, conf.Auth.Secret, conf.FileLocation)
+	jobs := app.JobsConfig{
+		OrphanedUploadsSchedule: conf.Jobs.OrphanedUploads.Schedule,
+		OrphanedUploadsTTL:      conf.Jobs.OrphanedUploads.TTL,
+		VersionsPruneSchedule:   conf.Jobs.Versions.Schedule,
+		VersionsLimit:           conf.Jobs.Versions.Limit,
+		TrashPurgeSchedule:      conf.Jobs.Trash.Schedule,
+		TrashRetention:          conf.Jobs.Trash.Retention,
+		ChangesPruneSchedule:    conf.Jobs.Changes.Schedule,
+		ChangesRetention:        conf.Jobs.Changes.Retention,
+		SendsPurgeSchedule:      conf.Jobs.Sends.Schedule,
+		EmergencyGrantSchedule:  conf.Jobs.Emergency.Schedule,
+	}
+	a := app.New(conf.GRPC.Port, pool, conf.Auth.Secret, conf.FileLocation, jobs)
 
 	go func() {
 		a.MustRun()
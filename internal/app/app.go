@@ -2,36 +2,105 @@
 package app
 
 import (
+	"context"
+	"time"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	grpcapp "github.com/vindosVP/go-pass/internal/app/grpc"
+	"github.com/vindosVP/go-pass/internal/changefeed"
+	"github.com/vindosVP/go-pass/internal/scheduler"
 	"github.com/vindosVP/go-pass/internal/services/auth"
 	"github.com/vindosVP/go-pass/internal/services/passkeeper"
 	"github.com/vindosVP/go-pass/internal/storage/postgres"
 )
 
-// App consist the grpc server
+// JobsConfig consists of the background jobs settings
+type JobsConfig struct {
+	OrphanedUploadsSchedule string
+	OrphanedUploadsTTL      time.Duration
+	VersionsPruneSchedule   string
+	VersionsLimit           int
+	TrashPurgeSchedule      string
+	TrashRetention          time.Duration
+	ChangesPruneSchedule    string
+	ChangesRetention        time.Duration
+	SendsPurgeSchedule      string
+	EmergencyGrantSchedule  string
+}
+
+// App consist the grpc server, the change and the access request feeds and the background jobs scheduler
 type App struct {
-	grpcServer *grpcapp.App
+	grpcServer  *grpcapp.App
+	feed        *changefeed.Feed
+	requestFeed *changefeed.Feed
+	scheduler   *scheduler.Scheduler
 }
 
 // MustRun runs the app
 func (a *App) MustRun() {
+	a.feed.Start()
+	a.requestFeed.Start()
+	a.scheduler.Start()
 	a.grpcServer.MustRun()
 }
 
-// Stop stops app
+// Stop stops app, the feeds are stopped first to end the watch streams
 func (a *App) Stop() {
+	a.feed.Stop()
+	a.requestFeed.Stop()
 	a.grpcServer.Stop()
+	a.scheduler.Stop()
 }
 
 // New creates the App instance
-func New(port int, pool *pgxpool.Pool, secret string, fl string) *App {
+func New(port int, pool *pgxpool.Pool, secret string, fl string, jobs JobsConfig) *App {
 	s := postgres.New(pool)
-	a := auth.New(s, secret)
-	k := passkeeper.New(s, s, s, s, fl)
-	grpcApp := grpcapp.New(port, secret, a, k)
+	a := auth.New(s, s, s, secret)
+	feed := changefeed.New(s)
+	requestFeed := changefeed.New(changefeed.ListenerFunc(s.ListenAccessRequests))
+	k := passkeeper.New(passkeeper.Deps{
+		Entities:   s,
+		Files:      s,
+		Versions:   s,
+		Trash:      s,
+		Folders:    s,
+		Tags:       s,
+		Changes:    s,
+		ChangeFeed: feed,
+		Conflicts:  s,
+		Shares:     s,
+		Orgs:       s,
+		Sends:      s,
+		Emergency:  s,
+		Access:     s,
+		AccessFeed: requestFeed,
+	}, fl)
+	sch := scheduler.New(s)
+	sch.MustAdd("orphaned-uploads", jobs.OrphanedUploadsSchedule, func(ctx context.Context) error {
+		return k.CleanupOrphanedUploads(ctx, jobs.OrphanedUploadsTTL)
+	})
+	sch.MustAdd("versions-prune", jobs.VersionsPruneSchedule, func(ctx context.Context) error {
+		return k.PruneVersions(ctx, jobs.VersionsLimit)
+	})
+	sch.MustAdd("trash-purge", jobs.TrashPurgeSchedule, func(ctx context.Context) error {
+		return k.PurgeTrash(ctx, jobs.TrashRetention)
+	})
+	sch.MustAdd("changes-prune", jobs.ChangesPruneSchedule, func(ctx context.Context) error {
+		return k.PruneChanges(ctx, jobs.ChangesRetention)
+	})
+	sch.MustAdd("sends-purge", jobs.SendsPurgeSchedule, func(ctx context.Context) error {
+		return k.PurgeSends(ctx)
+	})
+	sch.MustAdd("emergency-grant", jobs.EmergencyGrantSchedule, func(ctx context.Context) error {
+		return k.GrantEmergencyRequests(ctx)
+	})
+	grpcApp := grpcapp.New(port, secret, s, a, k, sch)
+
 	return &App{
-		grpcServer: grpcApp,
+		grpcServer:  grpcApp,
+		feed:        feed,
+		requestFeed: requestFeed,
+		scheduler:   sch,
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	authgrpc "github.com/vindosVP/go-pass/internal/grpc/auth"
+	healthgrpc "github.com/vindosVP/go-pass/internal/grpc/health"
 	passkeepergrpc "github.com/vindosVP/go-pass/internal/grpc/passkeeper"
 	"github.com/vindosVP/go-pass/internal/interceptors"
 	"github.com/vindosVP/go-pass/pkg/logger/sl"
@@ -51,7 +52,8 @@ func (a *App) Stop() {
 }
 
 // New creates a grpc app instance.
-func New(port int, secret string, auth authgrpc.Auth, keeper passkeepergrpc.Keeper) *App {
+func New(port int, secret string, devices interceptors.Devices, auth authgrpc.Auth, keeper passkeepergrpc.Keeper,
+	sch healthgrpc.Scheduler) *App {
 	loggingOpts := []logging.Option{
 		logging.WithLogOnEvents(
 			logging.PayloadReceived, logging.PayloadSent,
@@ -66,15 +68,16 @@ func New(port int, secret string, auth authgrpc.Auth, keeper passkeepergrpc.Keep
 	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
 		recovery.UnaryServerInterceptor(recoveryOpts...),
 		logging.UnaryServerInterceptor(InterceptorLogger(sl.Log), loggingOpts...),
-		interceptors.NewAuthInterceptor(secret).Unary(),
+		interceptors.NewAuthInterceptor(secret, devices).Unary(),
 	), grpc.ChainStreamInterceptor(
 		recovery.StreamServerInterceptor(recoveryOpts...),
 		logging.StreamServerInterceptor(InterceptorLogger(sl.Log), loggingOpts...),
-		interceptors.NewAuthInterceptor(secret).Stream()),
+		interceptors.NewAuthInterceptor(secret, devices).Stream()),
 	)
 
 	authgrpc.Register(grpcServer, auth)
 	passkeepergrpc.Register(grpcServer, keeper)
+	healthgrpc.Register(grpcServer, sch)
 
 	return &App{
 		grpcServer: grpcServer,
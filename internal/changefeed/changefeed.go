@@ -0,0 +1,147 @@
+// Package changefeed fans out the entity change notifications to the watchers.
+//
+// Every replica of the server listens to the postgres notifications of the changes made on all replicas,
+// the watchers are only signaled and read the changes from the change log themselves.
+package changefeed
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// Listener is a change notifications API
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Listener
+type Listener interface {
+	ListenChanges(ctx context.Context, listening func(), notify func(ownerID int)) error
+}
+
+// ListenerFunc adapts the function to the Listener, e.g. to listen to the notifications of another channel.
+type ListenerFunc func(ctx context.Context, listening func(), notify func(ownerID int)) error
+
+// ListenChanges calls f.
+func (f ListenerFunc) ListenChanges(ctx context.Context, listening func(), notify func(ownerID int)) error {
+	return f(ctx, listening, notify)
+}
+
+const reconnectDelay = time.Second
+
+// Feed signals the subscribers of the user when the user`s entities change.
+type Feed struct {
+	l Listener
+
+	mu   sync.Mutex
+	subs map[int]map[chan struct{}]struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new Feed instance
+func New(l Listener) *Feed {
+	return &Feed{l: l, subs: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Start starts listening to the change notifications, the listener is reconnected on failures.
+func (f *Feed) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		f.loop(ctx)
+	}()
+	sl.Log.Info("change feed started")
+}
+
+// Stop stops listening and closes all subscriptions.
+func (f *Feed) Stop() {
+	sl.Log.Info("stopping change feed")
+	if f.cancel != nil {
+		f.cancel()
+	}
+	f.wg.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ownerID, subs := range f.subs {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(f.subs, ownerID)
+	}
+}
+
+// Subscribe returns the channel signaled when the user`s entities change and the function to unsubscribe.
+// Signals are coalesced, the channel is closed when the feed stops.
+func (f *Feed) Subscribe(ownerID int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	f.mu.Lock()
+	if f.subs[ownerID] == nil {
+		f.subs[ownerID] = make(map[chan struct{}]struct{})
+	}
+	f.subs[ownerID][ch] = struct{}{}
+	f.mu.Unlock()
+
+	unsubscribe := func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subs[ownerID][ch]; !ok {
+			return
+		}
+		delete(f.subs[ownerID], ch)
+		if len(f.subs[ownerID]) == 0 {
+			delete(f.subs, ownerID)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Notify signals the subscribers of the user.
+func (f *Feed) Notify(ownerID int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs[ownerID] {
+		signal(ch)
+	}
+}
+
+// notifyAll signals all subscribers, the changes may have been missed while the listener was not connected.
+func (f *Feed) notifyAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, subs := range f.subs {
+		for ch := range subs {
+			signal(ch)
+		}
+	}
+}
+
+func (f *Feed) loop(ctx context.Context) {
+	for {
+		err := f.l.ListenChanges(ctx, f.notifyAll, f.Notify)
+		if ctx.Err() != nil {
+			return
+		}
+		sl.Log.Error("change notifications listener failed", sl.Err(err), slog.Duration("reconnect_in", reconnectDelay))
+
+		t := time.NewTimer(reconnectDelay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,68 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vindosVP/go-pass/internal/changefeed/mocks"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestFeed_Notify(t *testing.T) {
+
+	f := New(nil)
+	first, unsubscribe := f.Subscribe(1)
+	second, _ := f.Subscribe(1)
+	other, _ := f.Subscribe(2)
+
+	f.Notify(1)
+	f.Notify(1)
+	assert.Len(t, first, 1, "signals must be coalesced")
+	assert.Len(t, second, 1)
+	assert.Len(t, other, 0)
+
+	<-first
+	unsubscribe()
+	unsubscribe()
+	f.Notify(1)
+	assert.Len(t, first, 0)
+	assert.Len(t, second, 1)
+
+	f.notifyAll()
+	assert.Len(t, other, 1)
+}
+
+func TestFeed_Loop(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	l := mocks.NewListener(t)
+	f := New(l)
+	sub, _ := f.Subscribe(1)
+
+	l.On("ListenChanges", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection lost")).Once()
+	l.On("ListenChanges", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(func())()
+		args.Get(2).(func(int))(1)
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled).Once()
+
+	f.Start()
+	select {
+	case <-sub:
+	case <-time.After(3 * time.Second):
+		t.Fatal("subscriber is not signaled after the listener reconnected")
+	}
+
+	f.Stop()
+	for range sub {
+		// drains the signals sent before the feed stopped, the loop ends once the subscription is closed
+	}
+	_, ok := <-sub
+	assert.False(t, ok)
+}
@@ -6,14 +6,17 @@ import (
 	"errors"
 	"log/slog"
 	"net/mail"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/vindosVP/go-pass/internal/models"
 	authv1 "github.com/vindosVP/go-pass/internal/proto/auth"
 	"github.com/vindosVP/go-pass/internal/services/auth"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
 	"github.com/vindosVP/go-pass/pkg/logger/sl"
 )
 
@@ -22,7 +25,13 @@ import (
 //go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Auth
 type Auth interface {
 	CreateUser(ctx context.Context, email string, pass string) (*models.User, error)
-	Login(ctx context.Context, email string, pass string) (string, error)
+	Challenge(ctx context.Context) ([]byte, time.Time, error)
+	Login(ctx context.Context, email string, pass string, d *models.Device, p *models.DeviceProof) (string, *models.Device, error)
+	Devices(ctx context.Context, userID int) ([]*models.Device, error)
+	RemoveDevice(ctx context.Context, id int, userID int) error
+	SetKeys(ctx context.Context, k *models.UserKeys) (*models.UserKeys, error)
+	Keys(ctx context.Context, userID int) (*models.UserKeys, error)
+	PublicKey(ctx context.Context, email string) (*models.UserKeys, error)
 }
 
 type server struct {
@@ -35,6 +44,20 @@ func Register(gRPCServer *grpc.Server, auth Auth) {
 	authv1.RegisterAuthServer(gRPCServer, &server{auth: auth})
 }
 
+// Challenge returns a new login challenge
+func (s *server) Challenge(ctx context.Context, _ *authv1.ChallengeRequest) (*authv1.ChallengeResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling challenge")
+
+	nonce, expiresAt, err := s.auth.Challenge(ctx)
+	if err != nil {
+		lg.Error("failed to create challenge", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to create challenge")
+	}
+	return &authv1.ChallengeResponse{Nonce: nonce, ExpiresAt: timestamppb.New(expiresAt)}, nil
+}
+
 // Login logs in the user
 func (s *server) Login(ctx context.Context, in *authv1.LoginRequest) (*authv1.LoginResponse, error) {
 
@@ -46,18 +69,164 @@ func (s *server) Login(ctx context.Context, in *authv1.LoginRequest) (*authv1.Lo
 		lg.Info(msg)
 		return nil, status.Error(code, msg)
 	}
-	token, err := s.auth.Login(ctx, in.Email, in.Password)
+	d := &models.Device{ID: int(in.DeviceId), Name: in.DeviceName, PublicKey: in.DevicePublicKey}
+	p := &models.DeviceProof{Nonce: in.Nonce, Signature: in.Signature}
+	token, device, err := s.auth.Login(ctx, in.Email, in.Password, d, p)
 	if err != nil {
-		if errors.Is(err, auth.ErrInvalidCredentials) {
+		switch {
+		case errors.Is(err, auth.ErrInvalidCredentials):
 			lg.Info("invalid email or password")
 			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		case errors.Is(err, auth.ErrInvalidDevice):
+			lg.Info("invalid device", sl.Err(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, auth.ErrDeviceNotFound):
+			lg.Info("device not found")
+			return nil, status.Error(codes.NotFound, "device not found, log in as a new device")
+		case errors.Is(err, auth.ErrInvalidChallenge):
+			lg.Info("invalid challenge")
+			return nil, status.Error(codes.Unauthenticated, "challenge is expired or already used, request a new one")
+		case errors.Is(err, auth.ErrInvalidSignature):
+			lg.Info("invalid device signature")
+			return nil, status.Error(codes.Unauthenticated, "challenge is not signed by the device key")
 		}
 		lg.Error("failed to login", sl.Err(err))
 		return nil, status.Error(codes.Internal, "failed to login")
 	}
 
-	lg.Info("logged in")
-	return &authv1.LoginResponse{Token: token}, nil
+	lg.Info("logged in", slog.Int("device", device.ID))
+	return &authv1.LoginResponse{Token: token, DeviceId: int64(device.ID)}, nil
+}
+
+// ListDevices returns the user`s devices
+func (s *server) ListDevices(ctx context.Context, _ *authv1.ListDevicesRequest) (*authv1.ListDevicesResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list devices")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+	did, err := grpcmd.ExtractDeviceID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract device id: %v", err)
+	}
+
+	devices, err := s.auth.Devices(ctx, uid)
+	if err != nil {
+		lg.Error("failed to list devices", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to list devices")
+	}
+	resp := &authv1.ListDevicesResponse{Devices: make([]*authv1.Device, 0, len(devices))}
+	for _, d := range devices {
+		resp.Devices = append(resp.Devices, &authv1.Device{
+			Id:          int64(d.ID),
+			Name:        d.Name,
+			PublicKey:   d.PublicKey,
+			CreatedAt:   timestamppb.New(d.CreatedAt),
+			LastLoginAt: timestamppb.New(d.LastLoginAt),
+			Current:     d.ID == did,
+		})
+	}
+	return resp, nil
+}
+
+// RemoveDevice removes the user`s device
+func (s *server) RemoveDevice(ctx context.Context, in *authv1.RemoveDeviceRequest) (*authv1.RemoveDeviceResponse, error) {
+
+	lg := sl.Log.With(slog.Int64("device", in.Id))
+	lg.Info("handling remove device")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.auth.RemoveDevice(ctx, int(in.Id), uid); err != nil {
+		if errors.Is(err, auth.ErrDeviceNotFound) {
+			lg.Info("device not found")
+			return nil, status.Error(codes.NotFound, "device not found")
+		}
+		lg.Error("failed to remove device", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to remove device")
+	}
+
+	lg.Info("removed device")
+	return &authv1.RemoveDeviceResponse{}, nil
+}
+
+// SetKeys registers the user`s key pair
+func (s *server) SetKeys(ctx context.Context, in *authv1.SetKeysRequest) (*authv1.SetKeysResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling set keys")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	k, err := s.auth.SetKeys(ctx, &models.UserKeys{UserID: uid, PublicKey: in.PublicKey, WrappedPrivateKey: in.WrappedPrivateKey})
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidKeys):
+			lg.Info("invalid keys", sl.Err(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, auth.ErrPublicKeyMismatch):
+			lg.Info("public key mismatch")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		lg.Error("failed to set keys", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to set keys")
+	}
+
+	lg.Info("set keys")
+	return &authv1.SetKeysResponse{Keys: keysToGRPC(k)}, nil
+}
+
+// GetKeys returns the user`s key pair
+func (s *server) GetKeys(ctx context.Context, _ *authv1.GetKeysRequest) (*authv1.GetKeysResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling get keys")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	k, err := s.auth.Keys(ctx, uid)
+	if err != nil {
+		if errors.Is(err, auth.ErrKeysNotFound) {
+			lg.Info("keys not found")
+			return nil, status.Error(codes.NotFound, "keys not found")
+		}
+		lg.Error("failed to get keys", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to get keys")
+	}
+	return &authv1.GetKeysResponse{Keys: keysToGRPC(k)}, nil
+}
+
+// GetPublicKey looks up the user`s public key
+func (s *server) GetPublicKey(ctx context.Context, in *authv1.GetPublicKeyRequest) (*authv1.GetPublicKeyResponse, error) {
+
+	lg := sl.Log.With(slog.String("email", in.Email))
+	lg.Info("handling get public key")
+
+	if !isValidEmail(in.Email) {
+		return nil, status.Error(codes.InvalidArgument, "email is invalid")
+	}
+	k, err := s.auth.PublicKey(ctx, in.Email)
+	if err != nil {
+		if errors.Is(err, auth.ErrKeysNotFound) {
+			lg.Info("public key not found")
+			return nil, status.Error(codes.NotFound, "public key not found")
+		}
+		lg.Error("failed to get public key", sl.Err(err))
+		return nil, status.Error(codes.Internal, "failed to get public key")
+	}
+	return &authv1.GetPublicKeyResponse{Keys: keysToGRPC(k)}, nil
 }
 
 // Register registers a new user
@@ -85,6 +254,16 @@ func (s *server) Register(ctx context.Context, in *authv1.RegisterRequest) (*aut
 	return &authv1.RegisterResponse{UserId: int64(user.ID)}, nil
 }
 
+func keysToGRPC(k *models.UserKeys) *authv1.UserKeys {
+	return &authv1.UserKeys{
+		Email:             k.Email,
+		PublicKey:         k.PublicKey,
+		WrappedPrivateKey: k.WrappedPrivateKey,
+		CreatedAt:         timestamppb.New(k.CreatedAt),
+		UpdatedAt:         timestamppb.New(k.UpdatedAt),
+	}
+}
+
 func validate(email string, password string) (error, codes.Code, string) {
 	errValidation := errors.New("user validation error")
 	if email == "" {
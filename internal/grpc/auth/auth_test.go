@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/vindosVP/go-pass/internal/grpc/auth/mocks"
@@ -42,8 +44,10 @@ func TestServer_Login(t *testing.T) {
 		{
 			name: "ok",
 			in: &authv1.LoginRequest{
-				Email:    "test@example.com",
-				Password: "password",
+				Email:     "test@example.com",
+				Password:  "password",
+				Nonce:     []byte("nonce"),
+				Signature: []byte("signature"),
 			},
 			err: nil,
 			am: authMock{
@@ -101,6 +105,18 @@ func TestServer_Login(t *testing.T) {
 				err:    auth.ErrInvalidCredentials,
 			},
 		},
+		{
+			name: "invalid signature",
+			in: &authv1.LoginRequest{
+				Email:    "test@example.com",
+				Password: "password",
+			},
+			err: status.Error(codes.Unauthenticated, "challenge is not signed by the device key"),
+			am: authMock{
+				needed: true,
+				err:    auth.ErrInvalidSignature,
+			},
+		},
 		{
 			name: "unexpected error",
 			in: &authv1.LoginRequest{
@@ -125,11 +141,16 @@ func TestServer_Login(t *testing.T) {
 				if tt.am.err != nil {
 					token = ""
 				} else {
-					tok, err := jwt.NewToken(tt.am.user, secret)
+					tok, err := jwt.NewToken(tt.am.user, 3, secret)
 					require.NoError(t, err)
 					token = tok
 				}
-				a.On("Login", mock.Anything, tt.in.Email, tt.in.Password).Return(token, tt.am.err)
+				var d *models.Device
+				if tt.am.err == nil {
+					d = &models.Device{ID: 3}
+				}
+				proof := &models.DeviceProof{Nonce: tt.in.Nonce, Signature: tt.in.Signature}
+				a.On("Login", mock.Anything, tt.in.Email, tt.in.Password, mock.Anything, proof).Return(token, d, tt.am.err)
 			}
 			s := server{
 				auth: a,
@@ -138,14 +159,34 @@ func TestServer_Login(t *testing.T) {
 			assert.ErrorIs(t, err, tt.err)
 			if err == nil {
 				require.NotEqual(t, "", out.Token)
-				email, _, err := jwt.VerifyToken(out.Token, secret)
+				email, _, _, err := jwt.VerifyToken(out.Token, secret)
 				require.NoError(t, err)
 				assert.Equal(t, tt.in.Email, email)
+				assert.Equal(t, int64(3), out.DeviceId)
 			}
 		})
 	}
 }
 
+func TestServer_Challenge(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	expiresAt := time.Date(2024, time.January, 1, 0, 5, 0, 0, time.UTC)
+	a := mocks.NewAuth(t)
+	a.On("Challenge", mock.Anything).Return([]byte("nonce"), expiresAt, nil).Once()
+	a.On("Challenge", mock.Anything).Return(nil, time.Time{}, errors.New("unexpected")).Once()
+
+	s := server{auth: a}
+	out, err := s.Challenge(context.Background(), &authv1.ChallengeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("nonce"), out.Nonce)
+	assert.Equal(t, expiresAt, out.ExpiresAt.AsTime())
+
+	_, err = s.Challenge(context.Background(), &authv1.ChallengeRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
 func TestServer_Register(t *testing.T) {
 
 	type authMock struct {
@@ -266,3 +307,65 @@ func TestServer_Register(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_Devices(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1", "did", "3"))
+	a := mocks.NewAuth(t)
+	a.On("Devices", mock.Anything, 1).Return([]*models.Device{
+		{ID: 2, UserID: 1, Name: "phone"},
+		{ID: 3, UserID: 1, Name: "laptop"},
+	}, nil).Once()
+	a.On("RemoveDevice", mock.Anything, 2, 1).Return(nil).Once()
+	a.On("RemoveDevice", mock.Anything, 5, 1).Return(auth.ErrDeviceNotFound).Once()
+
+	s := server{auth: a}
+	resp, err := s.ListDevices(ctx, &authv1.ListDevicesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Devices, 2)
+	assert.False(t, resp.Devices[0].Current)
+	assert.True(t, resp.Devices[1].Current)
+	assert.Equal(t, "laptop", resp.Devices[1].Name)
+
+	_, err = s.RemoveDevice(ctx, &authv1.RemoveDeviceRequest{Id: 2})
+	require.NoError(t, err)
+
+	_, err = s.RemoveDevice(ctx, &authv1.RemoveDeviceRequest{Id: 5})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_Keys(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	pub := make([]byte, 32)
+	a := mocks.NewAuth(t)
+	a.On("SetKeys", mock.Anything, &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: []byte("wrapped")}).
+		Return(&models.UserKeys{UserID: 1, Email: "test@example.com", PublicKey: pub, WrappedPrivateKey: []byte("wrapped")}, nil).Once()
+	a.On("SetKeys", mock.Anything, mock.Anything).Return(nil, auth.ErrPublicKeyMismatch).Once()
+	a.On("Keys", mock.Anything, 1).Return(nil, auth.ErrKeysNotFound).Once()
+	a.On("PublicKey", mock.Anything, "friend@example.com").
+		Return(&models.UserKeys{UserID: 2, Email: "friend@example.com", PublicKey: pub}, nil).Once()
+
+	s := server{auth: a}
+	resp, err := s.SetKeys(ctx, &authv1.SetKeysRequest{PublicKey: pub, WrappedPrivateKey: []byte("wrapped")})
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", resp.Keys.Email)
+
+	_, err = s.SetKeys(ctx, &authv1.SetKeysRequest{PublicKey: pub, WrappedPrivateKey: []byte("other")})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = s.GetKeys(ctx, &authv1.GetKeysRequest{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	pk, err := s.GetPublicKey(ctx, &authv1.GetPublicKeyRequest{Email: "friend@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, pub, pk.Keys.PublicKey)
+	assert.Empty(t, pk.Keys.WrappedPrivateKey)
+
+	_, err = s.GetPublicKey(ctx, &authv1.GetPublicKeyRequest{Email: "friend"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	models "github.com/vindosVP/go-pass/internal/models"
@@ -14,6 +15,43 @@ type Auth struct {
 	mock.Mock
 }
 
+// Challenge provides a mock function with given fields: ctx
+func (_m *Auth) Challenge(ctx context.Context) ([]byte, time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Challenge")
+	}
+
+	var r0 []byte
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) time.Time); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateUser provides a mock function with given fields: ctx, email, pass
 func (_m *Auth) CreateUser(ctx context.Context, email string, pass string) (*models.User, error) {
 	ret := _m.Called(ctx, email, pass)
@@ -44,27 +82,174 @@ func (_m *Auth) CreateUser(ctx context.Context, email string, pass string) (*mod
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, email, pass
-func (_m *Auth) Login(ctx context.Context, email string, pass string) (string, error) {
-	ret := _m.Called(ctx, email, pass)
+// Devices provides a mock function with given fields: ctx, userID
+func (_m *Auth) Devices(ctx context.Context, userID int) ([]*models.Device, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Devices")
+	}
+
+	var r0 []*models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Device, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Device); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Keys provides a mock function with given fields: ctx, userID
+func (_m *Auth) Keys(ctx context.Context, userID int) (*models.UserKeys, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 *models.UserKeys
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.UserKeys, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.UserKeys); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserKeys)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, email, pass, d, p
+func (_m *Auth) Login(ctx context.Context, email string, pass string, d *models.Device, p *models.DeviceProof) (string, *models.Device, error) {
+	ret := _m.Called(ctx, email, pass, d, p)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
 	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, email, pass)
+	var r1 *models.Device
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Device, *models.DeviceProof) (string, *models.Device, error)); ok {
+		return rf(ctx, email, pass, d, p)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, email, pass)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Device, *models.DeviceProof) string); ok {
+		r0 = rf(ctx, email, pass, d, p)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, pass)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.Device, *models.DeviceProof) *models.Device); ok {
+		r1 = rf(ctx, email, pass, d, p)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *models.Device, *models.DeviceProof) error); ok {
+		r2 = rf(ctx, email, pass, d, p)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PublicKey provides a mock function with given fields: ctx, email
+func (_m *Auth) PublicKey(ctx context.Context, email string) (*models.UserKeys, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKey")
+	}
+
+	var r0 *models.UserKeys
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserKeys, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserKeys); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserKeys)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDevice provides a mock function with given fields: ctx, id, userID
+func (_m *Auth) RemoveDevice(ctx context.Context, id int, userID int) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetKeys provides a mock function with given fields: ctx, k
+func (_m *Auth) SetKeys(ctx context.Context, k *models.UserKeys) (*models.UserKeys, error) {
+	ret := _m.Called(ctx, k)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetKeys")
+	}
+
+	var r0 *models.UserKeys
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserKeys) (*models.UserKeys, error)); ok {
+		return rf(ctx, k)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserKeys) *models.UserKeys); ok {
+		r0 = rf(ctx, k)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserKeys)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserKeys) error); ok {
+		r1 = rf(ctx, k)
 	} else {
 		r1 = ret.Error(1)
 	}
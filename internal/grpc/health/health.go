@@ -0,0 +1,54 @@
+// Package healthgrpc consists the health grpc server
+package healthgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	healthv1 "github.com/vindosVP/go-pass/internal/proto/health"
+	"github.com/vindosVP/go-pass/internal/scheduler"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// Scheduler is a background jobs scheduler API interface
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Scheduler
+type Scheduler interface {
+	Status() []scheduler.Status
+}
+
+type server struct {
+	healthv1.UnimplementedHealthServer
+	sch Scheduler
+}
+
+// Register registers the health service.
+func Register(gRPCServer *grpc.Server, sch Scheduler) {
+	healthv1.RegisterHealthServer(gRPCServer, &server{sch: sch})
+}
+
+// JobsStatus returns the status of the background jobs
+func (s *server) JobsStatus(_ context.Context, _ *healthv1.JobsStatusRequest) (*healthv1.JobsStatusResponse, error) {
+
+	sl.Log.Info("handling jobs status")
+
+	res := &healthv1.JobsStatusResponse{}
+	for _, st := range s.sch.Status() {
+		j := &healthv1.Job{Name: st.Name, Schedule: st.Schedule, Running: st.Running}
+		if !st.NextRun.IsZero() {
+			j.NextRunAt = timestamppb.New(st.NextRun)
+		}
+		if st.LastRun != nil {
+			j.LastRun = &healthv1.JobRun{
+				ScheduledAt: timestamppb.New(st.LastRun.ScheduledAt),
+				StartedAt:   timestamppb.New(st.LastRun.StartedAt),
+				FinishedAt:  timestamppb.New(st.LastRun.FinishedAt),
+				Failed:      st.LastRun.Error != "",
+			}
+		}
+		res.Jobs = append(res.Jobs, j)
+	}
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package healthgrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/grpc/health/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	healthv1 "github.com/vindosVP/go-pass/internal/proto/health"
+	"github.com/vindosVP/go-pass/internal/scheduler"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_JobsStatus(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	next := time.Date(2024, time.January, 1, 1, 0, 0, 0, time.UTC)
+	run := &models.JobRun{
+		Name:        "trash-purge",
+		ScheduledAt: next.Add(-time.Hour),
+		StartedAt:   next.Add(-time.Hour),
+		FinishedAt:  next.Add(-time.Hour + time.Second),
+		Error:       "connection refused",
+	}
+	sch := mocks.NewScheduler(t)
+	sch.On("Status").Return([]scheduler.Status{
+		{Name: "trash-purge", Schedule: "@hourly", NextRun: next, LastRun: run},
+		{Name: "sends-purge", Schedule: "@daily", Running: true},
+	}).Once()
+
+	s := server{sch: sch}
+	out, err := s.JobsStatus(context.Background(), &healthv1.JobsStatusRequest{})
+	require.NoError(t, err)
+	require.Len(t, out.Jobs, 2)
+
+	j := out.Jobs[0]
+	assert.Equal(t, "trash-purge", j.Name)
+	assert.Equal(t, "@hourly", j.Schedule)
+	assert.Equal(t, next, j.NextRunAt.AsTime())
+	require.NotNil(t, j.LastRun)
+	assert.Equal(t, run.FinishedAt, j.LastRun.FinishedAt.AsTime())
+	assert.True(t, j.LastRun.Failed)
+
+	// the job has not run yet and its next run is not scheduled
+	j = out.Jobs[1]
+	assert.True(t, j.Running)
+	assert.Nil(t, j.NextRunAt)
+	assert.Nil(t, j.LastRun)
+}
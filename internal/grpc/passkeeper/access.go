@@ -0,0 +1,195 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// accessStatuses binds the keeper access request statuses to the grpc ones.
+var accessStatuses = map[models.AccessStatus]passkeeperv1.AccessStatus{
+	models.AccessPending:  passkeeperv1.AccessStatus_ACCESS_PENDING,
+	models.AccessApproved: passkeeperv1.AccessStatus_ACCESS_APPROVED,
+	models.AccessDenied:   passkeeperv1.AccessStatus_ACCESS_DENIED,
+}
+
+// accessActions binds the keeper audited access actions to the grpc ones.
+var accessActions = map[models.AccessAction]passkeeperv1.AccessAction{
+	models.AccessRequested: passkeeperv1.AccessAction_ACCESS_REQUEST,
+	models.AccessGranted:   passkeeperv1.AccessAction_ACCESS_APPROVE,
+	models.AccessRejected:  passkeeperv1.AccessAction_ACCESS_DENY,
+	models.AccessViewed:    passkeeperv1.AccessAction_ACCESS_VIEW,
+	models.AccessRequired:  passkeeperv1.AccessAction_ACCESS_REQUIRE,
+	models.AccessWaived:    passkeeperv1.AccessAction_ACCESS_WAIVE,
+}
+
+// SetApprovalRequired sets whether the reads of the entity have to be approved.
+func (s server) SetApprovalRequired(ctx context.Context, in *passkeeperv1.SetApprovalRequiredRequest) (*passkeeperv1.SetApprovalRequiredResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling set approval required request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.SetApprovalRequired(ctx, int(in.Id), uid, totype(in.Type), in.Required); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to set approval required")
+	}
+
+	lg.Info("set approval required", slog.Int64("id", in.Id), slog.Bool("required", in.Required))
+	return &passkeeperv1.SetApprovalRequiredResponse{}, nil
+}
+
+// RequestAccess requests the read access to the entity requiring approval.
+func (s server) RequestAccess(ctx context.Context, in *passkeeperv1.RequestAccessRequest) (*passkeeperv1.RequestAccessResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling request access request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	r := &models.AccessRequest{Type: totype(in.Type), EntityID: int(in.Id), RequesterID: uid, Reason: in.Reason}
+	res, err := s.k.RequestAccess(ctx, r)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to request access")
+	}
+
+	lg.Info("requested access", slog.Int("request", res.ID))
+	return &passkeeperv1.RequestAccessResponse{Request: accessRequestToGRPC(res)}, nil
+}
+
+// DecideAccessRequest approves or denies the pending access request.
+func (s server) DecideAccessRequest(ctx context.Context, in *passkeeperv1.DecideAccessRequestRequest) (*passkeeperv1.DecideAccessRequestResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling decide access request request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	res, err := s.k.DecideAccessRequest(ctx, int(in.Id), uid, in.Approve, in.Window.AsDuration())
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to decide access request")
+	}
+
+	lg.Info("decided access request", slog.Int64("id", in.Id), slog.String("status", string(res.Status)))
+	return &passkeeperv1.DecideAccessRequestResponse{Request: accessRequestToGRPC(res)}, nil
+}
+
+// ListAccessRequests returns the access requests the user approves or has made.
+func (s server) ListAccessRequests(ctx context.Context, _ *passkeeperv1.ListAccessRequestsRequest) (*passkeeperv1.ListAccessRequestsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list access requests request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	requests, err := s.k.AccessRequests(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list access requests")
+	}
+	return &passkeeperv1.ListAccessRequestsResponse{Requests: accessRequestsToGRPC(requests)}, nil
+}
+
+// WatchAccessRequests streams the access requests every time they change.
+func (s server) WatchAccessRequests(_ *passkeeperv1.WatchAccessRequestsRequest, str passkeeperv1.PassKeeper_WatchAccessRequestsServer) error {
+
+	lg := sl.Log
+	lg.Info("handling watch access requests request")
+
+	ctx := str.Context()
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	err = s.k.WatchAccessRequests(ctx, uid, func(requests []*models.AccessRequest) error {
+		return str.Send(&passkeeperv1.WatchAccessRequestsResponse{Requests: accessRequestsToGRPC(requests)})
+	})
+	switch {
+	case ctx.Err() != nil:
+		lg.Info("access requests watch canceled by client")
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, passkeeper.ErrWatchStopped):
+		lg.Info("access requests watch stopped")
+		return status.Errorf(codes.Unavailable, "server is shutting down, resume the watch")
+	case err != nil:
+		return errorStatus(lg, err, 0, "failed to watch access requests")
+	}
+	return nil
+}
+
+// ListAccessEvents returns the audit trail of the entity requiring approval.
+func (s server) ListAccessEvents(ctx context.Context, in *passkeeperv1.ListAccessEventsRequest) (*passkeeperv1.ListAccessEventsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list access events request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	events, err := s.k.AccessEvents(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to list access events")
+	}
+	resp := &passkeeperv1.ListAccessEventsResponse{Events: make([]*passkeeperv1.AccessEvent, 0, len(events))}
+	for _, ev := range events {
+		resp.Events = append(resp.Events, &passkeeperv1.AccessEvent{
+			Id:        int64(ev.ID),
+			RequestId: int64(ev.RequestID),
+			ActorId:   int64(ev.ActorID),
+			Action:    accessActions[ev.Action],
+			CreatedAt: timestamppb.New(ev.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func accessRequestsToGRPC(requests []*models.AccessRequest) []*passkeeperv1.AccessRequest {
+	res := make([]*passkeeperv1.AccessRequest, 0, len(requests))
+	for _, r := range requests {
+		res = append(res, accessRequestToGRPC(r))
+	}
+	return res
+}
+
+func accessRequestToGRPC(r *models.AccessRequest) *passkeeperv1.AccessRequest {
+	res := &passkeeperv1.AccessRequest{
+		Id:             int64(r.ID),
+		Type:           fromtype(r.Type),
+		EntityId:       int64(r.EntityID),
+		RequesterEmail: r.RequesterEmail,
+		Reason:         r.Reason,
+		Status:         accessStatuses[r.Status],
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+	if !r.ExpiresAt.IsZero() {
+		res.ExpiresAt = timestamppb.New(r.ExpiresAt)
+	}
+	if !r.DecidedAt.IsZero() {
+		res.DecidedAt = timestamppb.New(r.DecidedAt)
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_RequestAccess(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "2"))
+	k := mocks.NewKeeper(t)
+	k.On("RequestAccess", mock.Anything, &models.AccessRequest{Type: models.TypePassword, EntityID: 3, RequesterID: 2, Reason: "incident"}).
+		Return(&models.AccessRequest{ID: 7, Type: models.TypePassword, EntityID: 3, RequesterEmail: "friend@gmail.com",
+			Reason: "incident", Status: models.AccessPending}, nil).Once()
+	k.On("RequestAccess", mock.Anything, mock.Anything).Return(nil, passkeeper.ErrApprovalNotRequired).Once()
+
+	s := server{k: k}
+	resp, err := s.RequestAccess(ctx, &passkeeperv1.RequestAccessRequest{Id: 3, Type: passkeeperv1.Type_PASSWORD, Reason: "incident"})
+	require.NoError(t, err)
+	assert.Equal(t, passkeeperv1.AccessStatus_ACCESS_PENDING, resp.Request.Status)
+	assert.Equal(t, "friend@gmail.com", resp.Request.RequesterEmail)
+	assert.Nil(t, resp.Request.ExpiresAt)
+
+	_, err = s.RequestAccess(ctx, &passkeeperv1.RequestAccessRequest{Id: 3, Type: passkeeperv1.Type_PASSWORD})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_DecideAccessRequest(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	expires := time.Now().Add(time.Hour)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("DecideAccessRequest", mock.Anything, 7, 1, true, time.Hour).
+		Return(&models.AccessRequest{ID: 7, Status: models.AccessApproved, ExpiresAt: expires, DecidedAt: time.Now()}, nil).Once()
+	k.On("DecideAccessRequest", mock.Anything, 7, 1, true, time.Hour).Return(nil, passkeeper.ErrAccessRequestDecided).Once()
+	k.On("DecideAccessRequest", mock.Anything, 8, 1, false, time.Duration(0)).Return(nil, passkeeper.ErrAccessRequestNotFound).Once()
+
+	s := server{k: k}
+	resp, err := s.DecideAccessRequest(ctx, &passkeeperv1.DecideAccessRequestRequest{Id: 7, Approve: true, Window: durationpb.New(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, passkeeperv1.AccessStatus_ACCESS_APPROVED, resp.Request.Status)
+	assert.True(t, expires.Equal(resp.Request.ExpiresAt.AsTime()))
+
+	_, err = s.DecideAccessRequest(ctx, &passkeeperv1.DecideAccessRequestRequest{Id: 7, Approve: true, Window: durationpb.New(time.Hour)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.DecideAccessRequest(ctx, &passkeeperv1.DecideAccessRequestRequest{Id: 8})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_GetEntityApprovalRequired(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "2"))
+	k := mocks.NewKeeper(t)
+	k.On("Get", mock.Anything, 3, 2, models.TypePassword).Return(nil, passkeeper.ErrApprovalRequired).Once()
+
+	s := server{k: k}
+	_, err := s.GetEntity(ctx, &passkeeperv1.GetEntityRequest{Id: 3, Type: passkeeperv1.Type_PASSWORD})
+	st := status.Convert(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "APPROVAL_REQUIRED", info.Reason)
+}
+
+func TestServer_ListAccessEvents(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("AccessEvents", mock.Anything, 3, 1, models.TypePassword).Return([]*models.AccessEvent{
+		{ID: 1, RequestID: 7, ActorID: 2, Action: models.AccessRequested},
+		{ID: 2, RequestID: 7, ActorID: 1, Action: models.AccessGranted},
+		{ID: 3, RequestID: 7, ActorID: 2, Action: models.AccessViewed},
+	}, nil).Once()
+	k.On("AccessEvents", mock.Anything, 3, 1, models.TypeCard).Return(nil, passkeeper.ErrPermissionDenied).Once()
+
+	s := server{k: k}
+	resp, err := s.ListAccessEvents(ctx, &passkeeperv1.ListAccessEventsRequest{Id: 3, Type: passkeeperv1.Type_PASSWORD})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 3)
+	assert.Equal(t, passkeeperv1.AccessAction_ACCESS_APPROVE, resp.Events[1].Action)
+	assert.Equal(t, passkeeperv1.AccessAction_ACCESS_VIEW, resp.Events[2].Action)
+
+	_, err = s.ListAccessEvents(ctx, &passkeeperv1.ListAccessEventsRequest{Id: 3, Type: passkeeperv1.Type_CARD})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
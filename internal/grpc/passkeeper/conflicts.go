@@ -0,0 +1,118 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// resolutions binds the grpc conflict resolutions to the keeper ones.
+var resolutions = map[passkeeperv1.Resolution]models.Resolution{
+	passkeeperv1.Resolution_KEEP_SERVER: models.KeepServer,
+	passkeeperv1.Resolution_KEEP_CLIENT: models.KeepClient,
+	passkeeperv1.Resolution_MERGE:       models.Merge,
+}
+
+// ListConflicts returns the offline edits conflicting with the server changes.
+func (s server) ListConflicts(ctx context.Context, _ *passkeeperv1.ListConflictsRequest) (*passkeeperv1.ListConflictsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list conflicts request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	conflicts, err := s.k.Conflicts(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list conflicts")
+	}
+	resp := &passkeeperv1.ListConflictsResponse{Conflicts: make([]*passkeeperv1.Conflict, 0, len(conflicts))}
+	for _, c := range conflicts {
+		resp.Conflicts = append(resp.Conflicts, conflictToGRPC(c))
+	}
+	return resp, nil
+}
+
+// GetConflict returns the conflict.
+func (s server) GetConflict(ctx context.Context, in *passkeeperv1.GetConflictRequest) (*passkeeperv1.GetConflictResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling get conflict request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	c, err := s.k.Conflict(ctx, int(in.Id), uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to get conflict")
+	}
+	return &passkeeperv1.GetConflictResponse{Conflict: conflictToGRPC(c)}, nil
+}
+
+// ResolveConflict resolves the conflict by choosing a side or merging the fields.
+func (s server) ResolveConflict(ctx context.Context, in *passkeeperv1.ResolveConflictRequest) (*passkeeperv1.ResolveConflictResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling resolve conflict request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	r, ok := resolutions[in.Resolution]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown resolution %s", in.Resolution)
+	}
+	var merged *models.Entity
+	if in.Entity != nil {
+		merged = grpcToDTO(in.Entity, uid)
+	}
+	e, err := s.k.ResolveConflict(ctx, int(in.Id), uid, r, merged, updateFields(in.UpdateMask), int(in.ExpectedRevision))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to resolve conflict")
+	}
+
+	lg.Info("resolved conflict", slog.Int64("id", in.Id))
+	return &passkeeperv1.ResolveConflictResponse{Entity: dtoToGRPC(e)}, nil
+}
+
+func conflictToGRPC(c *models.Conflict) *passkeeperv1.Conflict {
+	res := &passkeeperv1.Conflict{
+		Id:                int64(c.ID),
+		Type:              fromtype(c.Type),
+		EntityId:          int64(c.EntityID),
+		BaseRevision:      int64(c.BaseRevision),
+		ServerRevision:    int64(c.ServerRevision),
+		Fields:            fieldNames(c.Fields),
+		ConflictingFields: fieldNames(c.ConflictingFields),
+		CreatedAt:         timestamppb.New(c.CreatedAt),
+	}
+	if c.Client != nil {
+		res.Client = dtoToGRPC(c.Client)
+	}
+	if c.Server != nil {
+		res.Server = dtoToGRPC(c.Server)
+	}
+	return res
+}
+
+func fieldNames(fields []models.EntityField) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, string(f))
+	}
+	return names
+}
@@ -0,0 +1,124 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_UpdateEntityFromBase(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	fields := []models.EntityField{models.FieldPassword}
+	k := mocks.NewKeeper(t)
+	k.On("UpdateFromBase", mock.Anything, mock.MatchedBy(func(e *models.Entity) bool {
+		return e.ID == 7 && e.Password == "merged" && e.Revision == 0
+	}), fields, 3).Return(nil).Once()
+	k.On("UpdateFromBase", mock.Anything, mock.MatchedBy(func(e *models.Entity) bool {
+		return e.Password == "conflicting"
+	}), fields, 3).Return(&passkeeper.ConflictError{Conflict: &models.Conflict{ID: 9, ConflictingFields: fields}}).Once()
+
+	s := server{k: k}
+	req := func(pass string, expected int64) *passkeeperv1.UpdateEntityRequest {
+		return &passkeeperv1.UpdateEntityRequest{
+			Id:               7,
+			Entity:           &passkeeperv1.Entity{Type: passkeeperv1.Type_PASSWORD, Password: pass},
+			UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+			ExpectedRevision: expected,
+			BaseRevision:     3,
+		}
+	}
+
+	_, err := s.UpdateEntity(ctx, req("merged", 0))
+	require.NoError(t, err)
+
+	_, err = s.UpdateEntity(ctx, req("conflicting", 0))
+	st := status.Convert(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "CONFLICT", info.Reason)
+	assert.Equal(t, map[string]string{"conflict_id": "9", "fields": "password"}, info.Metadata)
+
+	_, err = s.UpdateEntity(ctx, req("merged", 2))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListConflicts(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Conflicts", mock.Anything, 1).Return([]*models.Conflict{{
+		ID:                9,
+		Type:              models.TypeText,
+		EntityID:          7,
+		BaseRevision:      2,
+		ServerRevision:    4,
+		ConflictingFields: []models.EntityField{models.FieldText},
+		Client:            &models.Entity{ID: 7, Type: models.TypeText, Text: "client"},
+		CreatedAt:         time.Now(),
+	}}, nil).Once()
+
+	s := server{k: k}
+	resp, err := s.ListConflicts(ctx, &passkeeperv1.ListConflictsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Conflicts, 1)
+	c := resp.Conflicts[0]
+	assert.Equal(t, int64(9), c.Id)
+	assert.Equal(t, passkeeperv1.Type_TEXT, c.Type)
+	assert.Equal(t, []string{"text"}, c.ConflictingFields)
+	assert.Empty(t, c.Fields)
+	assert.Equal(t, "client", c.Client.Text)
+	assert.Nil(t, c.Server)
+}
+
+func TestServer_ResolveConflict(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("ResolveConflict", mock.Anything, 9, 1, models.Merge, mock.MatchedBy(func(e *models.Entity) bool {
+		return e.Text == "merged"
+	}), []models.EntityField{models.FieldText}, 4).
+		Return(&models.Entity{ID: 7, Type: models.TypeText, Text: "merged", Revision: 5}, nil).Once()
+	k.On("ResolveConflict", mock.Anything, 10, 1, models.KeepServer, (*models.Entity)(nil), []models.EntityField(nil), 0).
+		Return(nil, passkeeper.ErrConflictNotFound).Once()
+
+	s := server{k: k}
+	resp, err := s.ResolveConflict(ctx, &passkeeperv1.ResolveConflictRequest{
+		Id:               9,
+		Resolution:       passkeeperv1.Resolution_MERGE,
+		Entity:           &passkeeperv1.Entity{Type: passkeeperv1.Type_TEXT, Text: "merged"},
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"text"}},
+		ExpectedRevision: 4,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Entity.Revision)
+
+	_, err = s.ResolveConflict(ctx, &passkeeperv1.ResolveConflictRequest{Id: 10})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.ResolveConflict(ctx, &passkeeperv1.ResolveConflictRequest{Id: 9, Resolution: passkeeperv1.Resolution(42)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,173 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// emergencyStatuses binds the grpc emergency access statuses to the keeper ones.
+var emergencyStatuses = map[passkeeperv1.EmergencyStatus]models.EmergencyStatus{
+	passkeeperv1.EmergencyStatus_EMERGENCY_INVITED:   models.EmergencyInvited,
+	passkeeperv1.EmergencyStatus_EMERGENCY_ACCEPTED:  models.EmergencyAccepted,
+	passkeeperv1.EmergencyStatus_EMERGENCY_REQUESTED: models.EmergencyRequested,
+	passkeeperv1.EmergencyStatus_EMERGENCY_GRANTED:   models.EmergencyGranted,
+	passkeeperv1.EmergencyStatus_EMERGENCY_REVOKED:   models.EmergencyRevoked,
+}
+
+// emergencyActions binds the grpc emergency access actions to the keeper ones.
+var emergencyActions = map[passkeeperv1.EmergencyAction]models.EmergencyAction{
+	passkeeperv1.EmergencyAction_EMERGENCY_INVITE:  models.EmergencyInvite,
+	passkeeperv1.EmergencyAction_EMERGENCY_ACCEPT:  models.EmergencyAccept,
+	passkeeperv1.EmergencyAction_EMERGENCY_REQUEST: models.EmergencyRequest,
+	passkeeperv1.EmergencyAction_EMERGENCY_APPROVE: models.EmergencyApprove,
+	passkeeperv1.EmergencyAction_EMERGENCY_REJECT:  models.EmergencyReject,
+	passkeeperv1.EmergencyAction_EMERGENCY_GRANT:   models.EmergencyGrant,
+	passkeeperv1.EmergencyAction_EMERGENCY_REVOKE:  models.EmergencyRevoke,
+}
+
+// InviteEmergencyContact invites the user as the trusted contact.
+func (s server) InviteEmergencyContact(ctx context.Context, in *passkeeperv1.InviteEmergencyContactRequest) (*passkeeperv1.InviteEmergencyContactResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling invite emergency contact request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	c := &models.EmergencyContact{GrantorID: uid, GranteeEmail: in.Email, WaitPeriod: in.WaitPeriod.AsDuration()}
+	res, err := s.k.InviteEmergencyContact(ctx, c)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to invite emergency contact")
+	}
+
+	lg.Info("invited emergency contact", slog.Int("id", res.ID))
+	return &passkeeperv1.InviteEmergencyContactResponse{Contact: contactToGRPC(res)}, nil
+}
+
+// UpdateEmergencyAccess performs the action on the emergency access the user is a party of.
+func (s server) UpdateEmergencyAccess(ctx context.Context, in *passkeeperv1.UpdateEmergencyAccessRequest) (*passkeeperv1.UpdateEmergencyAccessResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling update emergency access request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	action, ok := emergencyActions[in.Action]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown emergency action %s", in.Action)
+	}
+	res, err := s.k.UpdateEmergencyAccess(ctx, int(in.Id), uid, action)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to update emergency access")
+	}
+
+	lg.Info("updated emergency access", slog.Int64("id", in.Id))
+	return &passkeeperv1.UpdateEmergencyAccessResponse{Contact: contactToGRPC(res)}, nil
+}
+
+// ListEmergencyContacts returns the emergency contacts the user is the grantor or the grantee of.
+func (s server) ListEmergencyContacts(ctx context.Context, _ *passkeeperv1.ListEmergencyContactsRequest) (*passkeeperv1.ListEmergencyContactsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list emergency contacts request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	contacts, err := s.k.EmergencyContacts(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list emergency contacts")
+	}
+	resp := &passkeeperv1.ListEmergencyContactsResponse{Contacts: make([]*passkeeperv1.EmergencyContact, 0, len(contacts))}
+	for _, c := range contacts {
+		resp.Contacts = append(resp.Contacts, contactToGRPC(c))
+	}
+	return resp, nil
+}
+
+// ListEmergencyEvents returns the audit trail of the emergency access.
+func (s server) ListEmergencyEvents(ctx context.Context, in *passkeeperv1.ListEmergencyEventsRequest) (*passkeeperv1.ListEmergencyEventsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list emergency events request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	events, err := s.k.EmergencyEvents(ctx, int(in.Id), uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to list emergency events")
+	}
+	resp := &passkeeperv1.ListEmergencyEventsResponse{Events: make([]*passkeeperv1.EmergencyEvent, 0, len(events))}
+	for _, ev := range events {
+		e := &passkeeperv1.EmergencyEvent{
+			Id:        int64(ev.ID),
+			ActorId:   int64(ev.ActorID),
+			Action:    fromEmergencyAction(ev.Action),
+			To:        fromEmergencyStatus(ev.To),
+			CreatedAt: timestamppb.New(ev.CreatedAt),
+		}
+		if ev.From != "" {
+			from := fromEmergencyStatus(ev.From)
+			e.From = &from
+		}
+		resp.Events = append(resp.Events, e)
+	}
+	return resp, nil
+}
+
+func contactToGRPC(c *models.EmergencyContact) *passkeeperv1.EmergencyContact {
+	res := &passkeeperv1.EmergencyContact{
+		Id:           int64(c.ID),
+		GrantorEmail: c.GrantorEmail,
+		GranteeEmail: c.GranteeEmail,
+		Status:       fromEmergencyStatus(c.Status),
+		WaitPeriod:   durationpb.New(c.WaitPeriod),
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+	}
+	if !c.RequestedAt.IsZero() {
+		res.RequestedAt = timestamppb.New(c.RequestedAt)
+		if c.Status == models.EmergencyRequested {
+			res.GrantsAt = timestamppb.New(c.RequestedAt.Add(c.WaitPeriod))
+		}
+	}
+	return res
+}
+
+func fromEmergencyStatus(st models.EmergencyStatus) passkeeperv1.EmergencyStatus {
+	for gs, ms := range emergencyStatuses {
+		if ms == st {
+			return gs
+		}
+	}
+	return passkeeperv1.EmergencyStatus_EMERGENCY_INVITED
+}
+
+func fromEmergencyAction(a models.EmergencyAction) passkeeperv1.EmergencyAction {
+	for ga, ma := range emergencyActions {
+		if ma == a {
+			return ga
+		}
+	}
+	return passkeeperv1.EmergencyAction_EMERGENCY_INVITE
+}
@@ -0,0 +1,104 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_InviteEmergencyContact(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("InviteEmergencyContact", mock.Anything,
+		&models.EmergencyContact{GrantorID: 1, GranteeEmail: "friend@gmail.com", WaitPeriod: time.Hour}).
+		Return(&models.EmergencyContact{ID: 3, GrantorEmail: "me@gmail.com", GranteeEmail: "friend@gmail.com",
+			Status: models.EmergencyInvited, WaitPeriod: time.Hour}, nil).Once()
+	k.On("InviteEmergencyContact", mock.Anything, mock.Anything).Return(nil, passkeeper.ErrInvalidWaitPeriod).Once()
+	k.On("InviteEmergencyContact", mock.Anything, mock.Anything).Return(nil, passkeeper.ErrEmergencyContactSelf).Once()
+
+	s := server{k: k}
+	resp, err := s.InviteEmergencyContact(ctx, &passkeeperv1.InviteEmergencyContactRequest{Email: "friend@gmail.com",
+		WaitPeriod: durationpb.New(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, passkeeperv1.EmergencyStatus_EMERGENCY_INVITED, resp.Contact.Status)
+	assert.Equal(t, time.Hour, resp.Contact.WaitPeriod.AsDuration())
+	assert.Nil(t, resp.Contact.GrantsAt)
+
+	_, err = s.InviteEmergencyContact(ctx, &passkeeperv1.InviteEmergencyContactRequest{Email: "friend@gmail.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.InviteEmergencyContact(ctx, &passkeeperv1.InviteEmergencyContactRequest{Email: "me@gmail.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_UpdateEmergencyAccess(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	requested := time.Now()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "2"))
+	k := mocks.NewKeeper(t)
+	k.On("UpdateEmergencyAccess", mock.Anything, 3, 2, models.EmergencyRequest).
+		Return(&models.EmergencyContact{ID: 3, Status: models.EmergencyRequested, WaitPeriod: time.Hour,
+			RequestedAt: requested}, nil).Once()
+	k.On("UpdateEmergencyAccess", mock.Anything, 3, 2, models.EmergencyRequest).
+		Return(nil, passkeeper.ErrEmergencyTransition).Once()
+	k.On("UpdateEmergencyAccess", mock.Anything, 3, 2, models.EmergencyApprove).
+		Return(nil, passkeeper.ErrPermissionDenied).Once()
+
+	s := server{k: k}
+	resp, err := s.UpdateEmergencyAccess(ctx, &passkeeperv1.UpdateEmergencyAccessRequest{Id: 3,
+		Action: passkeeperv1.EmergencyAction_EMERGENCY_REQUEST})
+	require.NoError(t, err)
+	assert.Equal(t, passkeeperv1.EmergencyStatus_EMERGENCY_REQUESTED, resp.Contact.Status)
+	assert.True(t, requested.Add(time.Hour).Equal(resp.Contact.GrantsAt.AsTime()))
+
+	_, err = s.UpdateEmergencyAccess(ctx, &passkeeperv1.UpdateEmergencyAccessRequest{Id: 3,
+		Action: passkeeperv1.EmergencyAction_EMERGENCY_REQUEST})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.UpdateEmergencyAccess(ctx, &passkeeperv1.UpdateEmergencyAccessRequest{Id: 3,
+		Action: passkeeperv1.EmergencyAction_EMERGENCY_APPROVE})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.UpdateEmergencyAccess(ctx, &passkeeperv1.UpdateEmergencyAccessRequest{Id: 3, Action: 42})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListEmergencyEvents(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("EmergencyEvents", mock.Anything, 3, 1).Return([]*models.EmergencyEvent{
+		{ID: 1, ContactID: 3, ActorID: 1, Action: models.EmergencyInvite, To: models.EmergencyInvited},
+		{ID: 2, ContactID: 3, Action: models.EmergencyGrant, From: models.EmergencyRequested, To: models.EmergencyGranted},
+	}, nil).Once()
+	k.On("EmergencyEvents", mock.Anything, 4, 1).Return(nil, passkeeper.ErrEmergencyContactNotFound).Once()
+
+	s := server{k: k}
+	resp, err := s.ListEmergencyEvents(ctx, &passkeeperv1.ListEmergencyEventsRequest{Id: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.Nil(t, resp.Events[0].From)
+	assert.Equal(t, passkeeperv1.EmergencyStatus_EMERGENCY_REQUESTED, resp.Events[1].GetFrom())
+	assert.Equal(t, int64(0), resp.Events[1].ActorId)
+
+	_, err = s.ListEmergencyEvents(ctx, &passkeeperv1.ListEmergencyEventsRequest{Id: 4})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,140 @@
+package passkeepergrpc
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const errorDomain = "passkeeper.go-pass"
+
+// badRequestFields binds the keeper validation errors to the invalid request fields.
+var badRequestFields = map[error]string{
+	passkeeper.ErrUnknownEntity:          "type",
+	passkeeper.ErrNotVersioned:           "type",
+	passkeeper.ErrUnableToUpdateFile:     "entity.type",
+	passkeeper.ErrUnableToSaveFile:       "entity.type",
+	passkeeper.ErrInvalidUpdateMask:      "update_mask",
+	passkeeper.ErrInvalidPageToken:       "page_token",
+	passkeeper.ErrInvalidSortField:       "sort_by",
+	passkeeper.ErrEmptyName:              "name",
+	passkeeper.ErrInvalidLabels:          "entity.labels",
+	passkeeper.ErrInvalidCustomFields:    "entity.custom_fields",
+	passkeeper.ErrEmptyQuery:             "query",
+	passkeeper.ErrInvalidCursor:          "cursor",
+	passkeeper.ErrFolderCycle:            "parent_id",
+	passkeeper.ErrInvalidBaseRevision:    "base_revision",
+	passkeeper.ErrInvalidResolution:      "resolution",
+	passkeeper.ErrInvalidPermission:      "permission",
+	passkeeper.ErrEmptyEmail:             "email",
+	passkeeper.ErrShareWithOwner:         "email",
+	passkeeper.ErrInvalidRole:            "role",
+	passkeeper.ErrInvalidVaultKey:        "keys",
+	passkeeper.ErrInvalidSend:            "send",
+	passkeeper.ErrEmergencyContactSelf:   "email",
+	passkeeper.ErrInvalidWaitPeriod:      "wait_period",
+	passkeeper.ErrInvalidEmergencyAction: "action",
+	passkeeper.ErrApprovalNotRequired:    "id",
+	passkeeper.ErrInvalidReason:          "reason",
+	passkeeper.ErrInvalidViewWindow:      "window",
+}
+
+// errorStatus logs the keeper error and converts it to the grpc status error with the error details.
+// The id is the requested entity id, 0 if the request is not about the single entity.
+// Unexpected errors are reported as Internal with the msg message.
+func errorStatus(lg *slog.Logger, err error, id int64, msg string) error {
+
+	for target, field := range badRequestFields {
+		if errors.Is(err, target) {
+			lg.Info(msg, sl.Err(err))
+			return withDetails(status.New(codes.InvalidArgument, err.Error()), &errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: err.Error()}},
+			})
+		}
+	}
+
+	var ce *passkeeper.ConflictError
+	if errors.As(err, &ce) {
+		lg.Info(msg, sl.Err(err))
+		return withDetails(status.New(codes.Aborted, err.Error()), &errdetails.ErrorInfo{
+			Reason: "CONFLICT",
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"conflict_id": strconv.Itoa(ce.Conflict.ID),
+				"fields":      strings.Join(fieldNames(ce.Conflict.ConflictingFields), ","),
+			},
+		})
+	}
+
+	var se *storage.Error
+	if !errors.As(err, &se) {
+		lg.Error(msg, sl.Err(err))
+		return status.Error(codes.Internal, msg)
+	}
+
+	lg.Info(msg, sl.Err(err))
+	name := ""
+	if id != 0 {
+		name = strconv.FormatInt(id, 10)
+	}
+	switch se.Kind {
+	case storage.KindNotFound:
+		return withDetails(status.New(codes.NotFound, se.Error()), &errdetails.ResourceInfo{
+			ResourceType: se.Resource,
+			ResourceName: name,
+			Description:  se.Error(),
+		})
+	case storage.KindConflict:
+		code, reason := codes.AlreadyExists, "CONFLICT"
+		switch {
+		case errors.Is(se, storage.ErrRevisionMismatch):
+			code, reason = codes.Aborted, "REVISION_MISMATCH"
+		case errors.Is(se, storage.ErrVaultKeyVersion):
+			code, reason = codes.Aborted, "KEY_VERSION_MISMATCH"
+		case errors.Is(se, storage.ErrVaultRekeyRequired):
+			code, reason = codes.FailedPrecondition, "REKEY_REQUIRED"
+		case errors.Is(se, storage.ErrEmergencyTransition):
+			code, reason = codes.FailedPrecondition, "INVALID_TRANSITION"
+		case errors.Is(se, storage.ErrAccessRequestDecided):
+			code, reason = codes.FailedPrecondition, "ALREADY_DECIDED"
+		}
+		return withDetails(status.New(code, se.Error()), &errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   errorDomain,
+			Metadata: map[string]string{"resource": se.Resource, "id": name},
+		})
+	case storage.KindForbidden:
+		reason := "FORBIDDEN"
+		if errors.Is(se, storage.ErrApprovalRequired) {
+			reason = "APPROVAL_REQUIRED"
+		}
+		return withDetails(status.New(codes.PermissionDenied, se.Error()), &errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   errorDomain,
+			Metadata: map[string]string{"resource": se.Resource, "id": name},
+		})
+	case storage.KindQuota:
+		return withDetails(status.New(codes.ResourceExhausted, se.Error()), &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{Subject: se.Resource, Description: se.Error()}},
+		})
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+// withDetails attaches the details to the status, the status is returned as is if details can not be attached.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if ds, err := st.WithDetails(details...); err == nil {
+		return ds.Err()
+	}
+	return st.Err()
+}
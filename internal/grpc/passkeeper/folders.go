@@ -0,0 +1,195 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// CreateFolder creates the folder.
+func (s server) CreateFolder(ctx context.Context, in *passkeeperv1.CreateFolderRequest) (*passkeeperv1.CreateFolderResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling create folder request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	f, err := s.k.CreateFolder(ctx, &models.Folder{OwnerID: uid, ParentID: int(in.ParentId), Name: in.Name})
+	if err != nil {
+		return nil, errorStatus(lg, err, in.ParentId, "failed to create folder")
+	}
+
+	lg.Info("created folder", slog.Int("id", f.ID))
+	return &passkeeperv1.CreateFolderResponse{Folder: folderToGRPC(f)}, nil
+}
+
+// UpdateFolder renames and moves the folder.
+func (s server) UpdateFolder(ctx context.Context, in *passkeeperv1.UpdateFolderRequest) (*passkeeperv1.UpdateFolderResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling update folder request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	f, err := s.k.UpdateFolder(ctx, &models.Folder{ID: int(in.Id), OwnerID: uid, ParentID: int(in.ParentId), Name: in.Name})
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to update folder")
+	}
+
+	lg.Info("updated folder", slog.Int("id", f.ID))
+	return &passkeeperv1.UpdateFolderResponse{Folder: folderToGRPC(f)}, nil
+}
+
+// DeleteFolder deletes the folder keeping its entities.
+func (s server) DeleteFolder(ctx context.Context, in *passkeeperv1.DeleteFolderRequest) (*passkeeperv1.DeleteFolderResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling delete folder request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.DeleteFolder(ctx, int(in.Id), uid); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to delete folder")
+	}
+
+	lg.Info("deleted folder", slog.Int("id", int(in.Id)))
+	return &passkeeperv1.DeleteFolderResponse{}, nil
+}
+
+// ListFolders returns all folders.
+func (s server) ListFolders(ctx context.Context, _ *passkeeperv1.ListFoldersRequest) (*passkeeperv1.ListFoldersResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list folders request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	folders, err := s.k.Folders(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list folders")
+	}
+	resp := &passkeeperv1.ListFoldersResponse{Folders: make([]*passkeeperv1.Folder, 0, len(folders))}
+	for _, f := range folders {
+		resp.Folders = append(resp.Folders, folderToGRPC(f))
+	}
+	return resp, nil
+}
+
+// CreateTag creates the tag.
+func (s server) CreateTag(ctx context.Context, in *passkeeperv1.CreateTagRequest) (*passkeeperv1.CreateTagResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling create tag request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	t, err := s.k.CreateTag(ctx, &models.Tag{OwnerID: uid, Name: in.Name})
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to create tag")
+	}
+
+	lg.Info("created tag", slog.Int("id", t.ID))
+	return &passkeeperv1.CreateTagResponse{Tag: tagToGRPC(t)}, nil
+}
+
+// RenameTag renames the tag.
+func (s server) RenameTag(ctx context.Context, in *passkeeperv1.RenameTagRequest) (*passkeeperv1.RenameTagResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling rename tag request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	t, err := s.k.RenameTag(ctx, &models.Tag{ID: int(in.Id), OwnerID: uid, Name: in.Name})
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to rename tag")
+	}
+
+	lg.Info("renamed tag", slog.Int("id", t.ID))
+	return &passkeeperv1.RenameTagResponse{Tag: tagToGRPC(t)}, nil
+}
+
+// DeleteTag deletes the tag.
+func (s server) DeleteTag(ctx context.Context, in *passkeeperv1.DeleteTagRequest) (*passkeeperv1.DeleteTagResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling delete tag request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.DeleteTag(ctx, int(in.Id), uid); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to delete tag")
+	}
+
+	lg.Info("deleted tag", slog.Int("id", int(in.Id)))
+	return &passkeeperv1.DeleteTagResponse{}, nil
+}
+
+// ListTags returns all tags.
+func (s server) ListTags(ctx context.Context, _ *passkeeperv1.ListTagsRequest) (*passkeeperv1.ListTagsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list tags request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	tags, err := s.k.Tags(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list tags")
+	}
+	resp := &passkeeperv1.ListTagsResponse{Tags: make([]*passkeeperv1.Tag, 0, len(tags))}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, tagToGRPC(t))
+	}
+	return resp, nil
+}
+
+func folderToGRPC(f *models.Folder) *passkeeperv1.Folder {
+	return &passkeeperv1.Folder{
+		Id:        int64(f.ID),
+		ParentId:  int64(f.ParentID),
+		Name:      f.Name,
+		CreatedAt: timestamppb.New(f.CreatedAt),
+		UpdatedAt: timestamppb.New(f.UpdatedAt),
+	}
+}
+
+func tagToGRPC(t *models.Tag) *passkeeperv1.Tag {
+	return &passkeeperv1.Tag{
+		Id:        int64(t.ID),
+		Name:      t.Name,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
@@ -0,0 +1,103 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_UpdateFolder(t *testing.T) {
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{
+			name: "ok",
+			code: codes.OK,
+		},
+		{
+			name: "cycle",
+			err:  storage.ErrFolderCycle,
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			err:  storage.ErrFolderNotExist,
+			code: codes.NotFound,
+		},
+	}
+
+	sl.SetupLogger("test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+			k := mocks.NewKeeper(t)
+			f := &models.Folder{ID: 2, OwnerID: 1, ParentID: 3, Name: "work"}
+			var res *models.Folder
+			if tt.err == nil {
+				res = f
+			}
+			k.On("UpdateFolder", mock.Anything, f).Return(res, tt.err)
+
+			s := server{k: k}
+			resp, err := s.UpdateFolder(ctx, &passkeeperv1.UpdateFolderRequest{Id: 2, ParentId: 3, Name: "work"})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.err == nil {
+				assert.Equal(t, int64(3), resp.Folder.ParentId)
+			}
+		})
+	}
+}
+
+func TestServer_CreateTag(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("CreateTag", mock.Anything, &models.Tag{OwnerID: 1, Name: "prod"}).Return(nil, storage.ErrTagAlreadyExists)
+
+	s := server{k: k}
+	_, err := s.CreateTag(ctx, &passkeeperv1.CreateTagRequest{Name: "prod"})
+	st := status.Convert(err)
+	require.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "tag", info.Metadata["resource"])
+}
+
+func TestServer_ListEntitiesFolder(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	e := &models.Entity{ID: 1, Type: models.TypeText, FolderID: 2, TagIDs: []int{3, 4}}
+	k.On("List", mock.Anything, 1, mock.MatchedBy(func(opts models.ListOptions) bool {
+		return opts.FolderID == 2 && assert.ObjectsAreEqual([]int{3, 4}, opts.TagIDs)
+	})).Return([]*models.Entity{e}, "", nil)
+
+	s := server{k: k}
+	resp, err := s.ListEntities(ctx, &passkeeperv1.ListEntitiesRequest{FolderId: 2, TagIds: []int64{3, 4}})
+	require.NoError(t, err)
+	require.Len(t, resp.Entity, 1)
+	assert.Equal(t, int64(2), resp.Entity[0].FolderId)
+	assert.Equal(t, []int64{3, 4}, resp.Entity[0].TagIds)
+}
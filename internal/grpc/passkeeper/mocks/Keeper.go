@@ -4,47 +4,1199 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	models "github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+)
+
+// Keeper is an autogenerated mock type for the Keeper type
+type Keeper struct {
+	mock.Mock
+}
+
+// AccessEvents provides a mock function with given fields: ctx, id, userID, t
+func (_m *Keeper) AccessEvents(ctx context.Context, id int, userID int, t models.EntityType) ([]*models.AccessEvent, error) {
+	ret := _m.Called(ctx, id, userID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessEvents")
+	}
+
+	var r0 []*models.AccessEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) ([]*models.AccessEvent, error)); ok {
+		return rf(ctx, id, userID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) []*models.AccessEvent); ok {
+		r0 = rf(ctx, id, userID, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AccessEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType) error); ok {
+		r1 = rf(ctx, id, userID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRequests provides a mock function with given fields: ctx, userID
+func (_m *Keeper) AccessRequests(ctx context.Context, userID int) ([]*models.AccessRequest, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessRequests")
+	}
+
+	var r0 []*models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.AccessRequest, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.AccessRequest); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddMember provides a mock function with given fields: ctx, m, userID
+func (_m *Keeper) AddMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	ret := _m.Called(ctx, m, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 *models.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Member, int) (*models.Member, error)); ok {
+		return rf(ctx, m, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Member, int) *models.Member); ok {
+		r0 = rf(ctx, m, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Member, int) error); ok {
+		r1 = rf(ctx, m, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Conflict provides a mock function with given fields: ctx, id, ownerID
+func (_m *Keeper) Conflict(ctx context.Context, id int, ownerID int) (*models.Conflict, error) {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Conflict")
+	}
+
+	var r0 *models.Conflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Conflict, error)); ok {
+		return rf(ctx, id, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Conflict); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conflict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Conflicts provides a mock function with given fields: ctx, ownerID
+func (_m *Keeper) Conflicts(ctx context.Context, ownerID int) ([]*models.Conflict, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Conflicts")
+	}
+
+	var r0 []*models.Conflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Conflict, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Conflict); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Conflict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFolder provides a mock function with given fields: ctx, f
+func (_m *Keeper) CreateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFolder")
+	}
+
+	var r0 *models.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Folder) (*models.Folder, error)); ok {
+		return rf(ctx, f)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Folder) *models.Folder); ok {
+		r0 = rf(ctx, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Folder) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrg provides a mock function with given fields: ctx, o, userID
+func (_m *Keeper) CreateOrg(ctx context.Context, o *models.Org, userID int) (*models.Org, error) {
+	ret := _m.Called(ctx, o, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrg")
+	}
+
+	var r0 *models.Org
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Org, int) (*models.Org, error)); ok {
+		return rf(ctx, o, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Org, int) *models.Org); ok {
+		r0 = rf(ctx, o, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Org)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Org, int) error); ok {
+		r1 = rf(ctx, o, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSend provides a mock function with given fields: ctx, s, password, ttl
+func (_m *Keeper) CreateSend(ctx context.Context, s *models.Send, password string, ttl time.Duration) (*models.Send, string, error) {
+	ret := _m.Called(ctx, s, password, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSend")
+	}
+
+	var r0 *models.Send
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Send, string, time.Duration) (*models.Send, string, error)); ok {
+		return rf(ctx, s, password, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Send, string, time.Duration) *models.Send); ok {
+		r0 = rf(ctx, s, password, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Send)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Send, string, time.Duration) string); ok {
+		r1 = rf(ctx, s, password, ttl)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Send, string, time.Duration) error); ok {
+		r2 = rf(ctx, s, password, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateTag provides a mock function with given fields: ctx, t
+func (_m *Keeper) CreateTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) (*models.Tag, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) *models.Tag); ok {
+		r0 = rf(ctx, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Tag) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVault provides a mock function with given fields: ctx, v, userID
+func (_m *Keeper) CreateVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	ret := _m.Called(ctx, v, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVault")
+	}
+
+	var r0 *models.Vault
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vault, int) (*models.Vault, error)); ok {
+		return rf(ctx, v, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vault, int) *models.Vault); ok {
+		r0 = rf(ctx, v, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vault)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Vault, int) error); ok {
+		r1 = rf(ctx, v, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideAccessRequest provides a mock function with given fields: ctx, id, userID, approve, window
+func (_m *Keeper) DecideAccessRequest(ctx context.Context, id int, userID int, approve bool, window time.Duration) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, id, userID, approve, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecideAccessRequest")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool, time.Duration) (*models.AccessRequest, error)); ok {
+		return rf(ctx, id, userID, approve, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool, time.Duration) *models.AccessRequest); ok {
+		r0 = rf(ctx, id, userID, approve, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, bool, time.Duration) error); ok {
+		r1 = rf(ctx, id, userID, approve, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id, ownerID, t, revision
+func (_m *Keeper) Delete(ctx context.Context, id int, ownerID int, t models.EntityType, revision int) error {
+	ret := _m.Called(ctx, id, ownerID, t, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType, int) error); ok {
+		r0 = rf(ctx, id, ownerID, t, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFolder provides a mock function with given fields: ctx, id, ownerID
+func (_m *Keeper) DeleteFolder(ctx context.Context, id int, ownerID int) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrg provides a mock function with given fields: ctx, id, userID
+func (_m *Keeper) DeleteOrg(ctx context.Context, id int, userID int) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSend provides a mock function with given fields: ctx, id, ownerID
+func (_m *Keeper) DeleteSend(ctx context.Context, id int, ownerID int) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTag provides a mock function with given fields: ctx, id, ownerID
+func (_m *Keeper) DeleteTag(ctx context.Context, id int, ownerID int) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVault provides a mock function with given fields: ctx, id, orgID, userID
+func (_m *Keeper) DeleteVault(ctx context.Context, id int, orgID int, userID int) error {
+	ret := _m.Called(ctx, id, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVault")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) error); ok {
+		r0 = rf(ctx, id, orgID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadFile provides a mock function with given fields: id, ownerID, str
+func (_m *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_DownloadFileServer) error {
+	ret := _m.Called(id, ownerID, str)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, passkeeperv1.PassKeeper_DownloadFileServer) error); ok {
+		r0 = rf(id, ownerID, str)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmergencyContacts provides a mock function with given fields: ctx, userID
+func (_m *Keeper) EmergencyContacts(ctx context.Context, userID int) ([]*models.EmergencyContact, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmergencyContacts")
+	}
+
+	var r0 []*models.EmergencyContact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.EmergencyContact, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.EmergencyContact); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EmergencyContact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EmergencyEvents provides a mock function with given fields: ctx, id, userID
+func (_m *Keeper) EmergencyEvents(ctx context.Context, id int, userID int) ([]*models.EmergencyEvent, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmergencyEvents")
+	}
+
+	var r0 []*models.EmergencyEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.EmergencyEvent, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.EmergencyEvent); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EmergencyEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Folders provides a mock function with given fields: ctx, ownerID
+func (_m *Keeper) Folders(ctx context.Context, ownerID int) ([]*models.Folder, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Folders")
+	}
+
+	var r0 []*models.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Folder, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Folder); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, id, ownerID, t
+func (_m *Keeper) Get(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error) {
+	ret := _m.Called(ctx, id, ownerID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) (*models.Entity, error)); ok {
+		return rf(ctx, id, ownerID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) *models.Entity); ok {
+		r0 = rf(ctx, id, ownerID, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType) error); ok {
+		r1 = rf(ctx, id, ownerID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteEmergencyContact provides a mock function with given fields: ctx, c
+func (_m *Keeper) InviteEmergencyContact(ctx context.Context, c *models.EmergencyContact) (*models.EmergencyContact, error) {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteEmergencyContact")
+	}
+
+	var r0 *models.EmergencyContact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmergencyContact) (*models.EmergencyContact, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmergencyContact) *models.EmergencyContact); ok {
+		r0 = rf(ctx, c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmergencyContact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmergencyContact) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, ownerID, opts
+func (_m *Keeper) List(ctx context.Context, ownerID int, opts models.ListOptions) ([]*models.Entity, string, error) {
+	ret := _m.Called(ctx, ownerID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Entity
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.ListOptions) ([]*models.Entity, string, error)); ok {
+		return rf(ctx, ownerID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.ListOptions) []*models.Entity); ok {
+		r0 = rf(ctx, ownerID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, models.ListOptions) string); ok {
+		r1 = rf(ctx, ownerID, opts)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, models.ListOptions) error); ok {
+		r2 = rf(ctx, ownerID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Members provides a mock function with given fields: ctx, orgID, userID
+func (_m *Keeper) Members(ctx context.Context, orgID int, userID int) ([]*models.Member, error) {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Members")
+	}
+
+	var r0 []*models.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.Member, error)); ok {
+		return rf(ctx, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.Member); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenSend provides a mock function with given fields: ctx, token, password
+func (_m *Keeper) OpenSend(ctx context.Context, token string, password string) (*models.Send, error) {
+	ret := _m.Called(ctx, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenSend")
+	}
+
+	var r0 *models.Send
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Send, error)); ok {
+		return rf(ctx, token, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Send); ok {
+		r0 = rf(ctx, token, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Send)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Orgs provides a mock function with given fields: ctx, userID
+func (_m *Keeper) Orgs(ctx context.Context, userID int) ([]*models.Org, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Orgs")
+	}
+
+	var r0 []*models.Org
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Org, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Org); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Org)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, id, ownerID, t
+func (_m *Keeper) Purge(ctx context.Context, id int, ownerID int, t models.EntityType) error {
+	ret := _m.Called(ctx, id, ownerID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) error); ok {
+		r0 = rf(ctx, id, ownerID, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMember provides a mock function with given fields: ctx, orgID, userID, email
+func (_m *Keeper) RemoveMember(ctx context.Context, orgID int, userID int, email string) error {
+	ret := _m.Called(ctx, orgID, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, orgID, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameTag provides a mock function with given fields: ctx, t
+func (_m *Keeper) RenameTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTag")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) (*models.Tag, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) *models.Tag); ok {
+		r0 = rf(ctx, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Tag) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameVault provides a mock function with given fields: ctx, v, userID
+func (_m *Keeper) RenameVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	ret := _m.Called(ctx, v, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameVault")
+	}
+
+	var r0 *models.Vault
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vault, int) (*models.Vault, error)); ok {
+		return rf(ctx, v, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vault, int) *models.Vault); ok {
+		r0 = rf(ctx, v, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vault)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Vault, int) error); ok {
+		r1 = rf(ctx, v, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestAccess provides a mock function with given fields: ctx, r
+func (_m *Keeper) RequestAccess(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestAccess")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) (*models.AccessRequest, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) *models.AccessRequest); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AccessRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveConflict provides a mock function with given fields: ctx, id, ownerID, r, merged, fields, expectedRevision
+func (_m *Keeper) ResolveConflict(ctx context.Context, id int, ownerID int, r models.Resolution, merged *models.Entity, fields []models.EntityField, expectedRevision int) (*models.Entity, error) {
+	ret := _m.Called(ctx, id, ownerID, r, merged, fields, expectedRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveConflict")
+	}
+
+	var r0 *models.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.Resolution, *models.Entity, []models.EntityField, int) (*models.Entity, error)); ok {
+		return rf(ctx, id, ownerID, r, merged, fields, expectedRevision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.Resolution, *models.Entity, []models.EntityField, int) *models.Entity); ok {
+		r0 = rf(ctx, id, ownerID, r, merged, fields, expectedRevision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.Resolution, *models.Entity, []models.EntityField, int) error); ok {
+		r1 = rf(ctx, id, ownerID, r, merged, fields, expectedRevision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id, ownerID, t, revision, expectedRevision
+func (_m *Keeper) Restore(ctx context.Context, id int, ownerID int, t models.EntityType, revision int, expectedRevision int) (*models.Entity, error) {
+	ret := _m.Called(ctx, id, ownerID, t, revision, expectedRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *models.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType, int, int) (*models.Entity, error)); ok {
+		return rf(ctx, id, ownerID, t, revision, expectedRevision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType, int, int) *models.Entity); ok {
+		r0 = rf(ctx, id, ownerID, t, revision, expectedRevision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType, int, int) error); ok {
+		r1 = rf(ctx, id, ownerID, t, revision, expectedRevision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreFromTrash provides a mock function with given fields: ctx, id, ownerID, t
+func (_m *Keeper) RestoreFromTrash(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error) {
+	ret := _m.Called(ctx, id, ownerID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreFromTrash")
+	}
+
+	var r0 *models.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) (*models.Entity, error)); ok {
+		return rf(ctx, id, ownerID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) *models.Entity); ok {
+		r0 = rf(ctx, id, ownerID, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType) error); ok {
+		r1 = rf(ctx, id, ownerID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, e
+func (_m *Keeper) Save(ctx context.Context, e *models.Entity) (int, error) {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity) (int, error)); ok {
+		return rf(ctx, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity) int); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Entity) error); ok {
+		r1 = rf(ctx, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFile provides a mock function with given fields: str
+func (_m *Keeper) SaveFile(str passkeeperv1.PassKeeper_UploadFileServer) error {
+	ret := _m.Called(str)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(passkeeperv1.PassKeeper_UploadFileServer) error); ok {
+		r0 = rf(str)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, ownerID, opts
+func (_m *Keeper) Search(ctx context.Context, ownerID int, opts models.SearchOptions) ([]*models.SearchResult, error) {
+	ret := _m.Called(ctx, ownerID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*models.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.SearchOptions) ([]*models.SearchResult, error)); ok {
+		return rf(ctx, ownerID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.SearchOptions) []*models.SearchResult); ok {
+		r0 = rf(ctx, ownerID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, models.SearchOptions) error); ok {
+		r1 = rf(ctx, ownerID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sends provides a mock function with given fields: ctx, ownerID
+func (_m *Keeper) Sends(ctx context.Context, ownerID int) ([]*models.Send, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sends")
+	}
+
+	var r0 []*models.Send
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Send, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Send); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Send)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetApprovalRequired provides a mock function with given fields: ctx, id, userID, t, required
+func (_m *Keeper) SetApprovalRequired(ctx context.Context, id int, userID int, t models.EntityType, required bool) error {
+	ret := _m.Called(ctx, id, userID, t, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetApprovalRequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType, bool) error); ok {
+		r0 = rf(ctx, id, userID, t, required)
+	} else {
+		r0 = ret.Error(0)
+	}
 
-	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
-)
+	return r0
+}
 
-// Keeper is an autogenerated mock type for the Keeper type
-type Keeper struct {
-	mock.Mock
+// SetVaultKeys provides a mock function with given fields: ctx, vaultID, orgID, version, keys, userID
+func (_m *Keeper) SetVaultKeys(ctx context.Context, vaultID int, orgID int, version int, keys []*models.VaultKey, userID int) (*models.Vault, error) {
+	ret := _m.Called(ctx, vaultID, orgID, version, keys, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVaultKeys")
+	}
+
+	var r0 *models.Vault
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, []*models.VaultKey, int) (*models.Vault, error)); ok {
+		return rf(ctx, vaultID, orgID, version, keys, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, []*models.VaultKey, int) *models.Vault); ok {
+		r0 = rf(ctx, vaultID, orgID, version, keys, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vault)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, []*models.VaultKey, int) error); ok {
+		r1 = rf(ctx, vaultID, orgID, version, keys, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Share provides a mock function with given fields: ctx, sh
+func (_m *Keeper) Share(ctx context.Context, sh *models.Share) (*models.Share, error) {
+	ret := _m.Called(ctx, sh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Share")
+	}
+
+	var r0 *models.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Share) (*models.Share, error)); ok {
+		return rf(ctx, sh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Share) *models.Share); ok {
+		r0 = rf(ctx, sh)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Share) error); ok {
+		r1 = rf(ctx, sh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, id, ownerID, t
-func (_m *Keeper) Delete(ctx context.Context, id int, ownerID int, t models.EntityType) error {
+// Shares provides a mock function with given fields: ctx, id, ownerID, t
+func (_m *Keeper) Shares(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Share, error) {
 	ret := _m.Called(ctx, id, ownerID, t)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Shares")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) error); ok {
+	var r0 []*models.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) ([]*models.Share, error)); ok {
+		return rf(ctx, id, ownerID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) []*models.Share); ok {
 		r0 = rf(ctx, id, ownerID, t)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Share)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType) error); ok {
+		r1 = rf(ctx, id, ownerID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DownloadFile provides a mock function with given fields: id, ownerID, str
-func (_m *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_DownloadFileServer) error {
-	ret := _m.Called(id, ownerID, str)
+// Stream provides a mock function with given fields: ctx, ownerID, opts, send
+func (_m *Keeper) Stream(ctx context.Context, ownerID int, opts models.ListOptions, send func(e *models.Entity) error) error {
+	ret := _m.Called(ctx, ownerID, opts, send)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DownloadFile")
+		panic("no return value specified for Stream")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, int, passkeeperv1.PassKeeper_DownloadFileServer) error); ok {
-		r0 = rf(id, ownerID, str)
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.ListOptions, func(e *models.Entity) error) error); ok {
+		r0 = rf(ctx, ownerID, opts, send)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,12 +1204,72 @@ func (_m *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_
 	return r0
 }
 
-// List provides a mock function with given fields: ctx, ownerID
-func (_m *Keeper) List(ctx context.Context, ownerID int) ([]*models.Entity, error) {
+// SyncSince provides a mock function with given fields: ctx, ownerID, cursor
+func (_m *Keeper) SyncSince(ctx context.Context, ownerID int, cursor int64) (*models.SyncResult, error) {
+	ret := _m.Called(ctx, ownerID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncSince")
+	}
+
+	var r0 *models.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*models.SyncResult, error)); ok {
+		return rf(ctx, ownerID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *models.SyncResult); ok {
+		r0 = rf(ctx, ownerID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, ownerID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tags provides a mock function with given fields: ctx, ownerID
+func (_m *Keeper) Tags(ctx context.Context, ownerID int) ([]*models.Tag, error) {
 	ret := _m.Called(ctx, ownerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for List")
+		panic("no return value specified for Tags")
+	}
+
+	var r0 []*models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Tag, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Tag); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Trash provides a mock function with given fields: ctx, ownerID
+func (_m *Keeper) Trash(ctx context.Context, ownerID int) ([]*models.Entity, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
 	}
 
 	var r0 []*models.Entity
@@ -82,27 +1294,65 @@ func (_m *Keeper) List(ctx context.Context, ownerID int) ([]*models.Entity, erro
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, e
-func (_m *Keeper) Save(ctx context.Context, e *models.Entity) (int, error) {
-	ret := _m.Called(ctx, e)
+// Unshare provides a mock function with given fields: ctx, id, ownerID, t, email
+func (_m *Keeper) Unshare(ctx context.Context, id int, ownerID int, t models.EntityType, email string) error {
+	ret := _m.Called(ctx, id, ownerID, t, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for Unshare")
 	}
 
-	var r0 int
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType, string) error); ok {
+		r0 = rf(ctx, id, ownerID, t, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, e, fields
+func (_m *Keeper) Update(ctx context.Context, e *models.Entity, fields []models.EntityField) error {
+	ret := _m.Called(ctx, e, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity, []models.EntityField) error); ok {
+		r0 = rf(ctx, e, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEmergencyAccess provides a mock function with given fields: ctx, id, userID, action
+func (_m *Keeper) UpdateEmergencyAccess(ctx context.Context, id int, userID int, action models.EmergencyAction) (*models.EmergencyContact, error) {
+	ret := _m.Called(ctx, id, userID, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmergencyAccess")
+	}
+
+	var r0 *models.EmergencyContact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity) (int, error)); ok {
-		return rf(ctx, e)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EmergencyAction) (*models.EmergencyContact, error)); ok {
+		return rf(ctx, id, userID, action)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity) int); ok {
-		r0 = rf(ctx, e)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EmergencyAction) *models.EmergencyContact); ok {
+		r0 = rf(ctx, id, userID, action)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmergencyContact)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.Entity) error); ok {
-		r1 = rf(ctx, e)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EmergencyAction) error); ok {
+		r1 = rf(ctx, id, userID, action)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,17 +1360,47 @@ func (_m *Keeper) Save(ctx context.Context, e *models.Entity) (int, error) {
 	return r0, r1
 }
 
-// SaveFile provides a mock function with given fields: str
-func (_m *Keeper) SaveFile(str passkeeperv1.PassKeeper_UploadFileServer) error {
-	ret := _m.Called(str)
+// UpdateFolder provides a mock function with given fields: ctx, f
+func (_m *Keeper) UpdateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	ret := _m.Called(ctx, f)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveFile")
+		panic("no return value specified for UpdateFolder")
+	}
+
+	var r0 *models.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Folder) (*models.Folder, error)); ok {
+		return rf(ctx, f)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Folder) *models.Folder); ok {
+		r0 = rf(ctx, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Folder) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFromBase provides a mock function with given fields: ctx, e, fields, base
+func (_m *Keeper) UpdateFromBase(ctx context.Context, e *models.Entity, fields []models.EntityField, base int) error {
+	ret := _m.Called(ctx, e, fields, base)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFromBase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(passkeeperv1.PassKeeper_UploadFileServer) error); ok {
-		r0 = rf(str)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity, []models.EntityField, int) error); ok {
+		r0 = rf(ctx, e, fields, base)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -128,17 +1408,155 @@ func (_m *Keeper) SaveFile(str passkeeperv1.PassKeeper_UploadFileServer) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, e
-func (_m *Keeper) Update(ctx context.Context, e *models.Entity) error {
-	ret := _m.Called(ctx, e)
+// UpdateMember provides a mock function with given fields: ctx, m, userID
+func (_m *Keeper) UpdateMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	ret := _m.Called(ctx, m, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for UpdateMember")
+	}
+
+	var r0 *models.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Member, int) (*models.Member, error)); ok {
+		return rf(ctx, m, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Member, int) *models.Member); ok {
+		r0 = rf(ctx, m, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Member, int) error); ok {
+		r1 = rf(ctx, m, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VaultKeys provides a mock function with given fields: ctx, vaultID, orgID, userID
+func (_m *Keeper) VaultKeys(ctx context.Context, vaultID int, orgID int, userID int) ([]*models.VaultKey, error) {
+	ret := _m.Called(ctx, vaultID, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VaultKeys")
+	}
+
+	var r0 []*models.VaultKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]*models.VaultKey, error)); ok {
+		return rf(ctx, vaultID, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []*models.VaultKey); ok {
+		r0 = rf(ctx, vaultID, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VaultKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, vaultID, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Vaults provides a mock function with given fields: ctx, orgID, userID
+func (_m *Keeper) Vaults(ctx context.Context, orgID int, userID int) ([]*models.Vault, error) {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vaults")
+	}
+
+	var r0 []*models.Vault
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.Vault, error)); ok {
+		return rf(ctx, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.Vault); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Vault)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Versions provides a mock function with given fields: ctx, id, ownerID, t
+func (_m *Keeper) Versions(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Version, error) {
+	ret := _m.Called(ctx, id, ownerID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Versions")
+	}
+
+	var r0 []*models.Version
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) ([]*models.Version, error)); ok {
+		return rf(ctx, id, ownerID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.EntityType) []*models.Version); ok {
+		r0 = rf(ctx, id, ownerID, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Version)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.EntityType) error); ok {
+		r1 = rf(ctx, id, ownerID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Watch provides a mock function with given fields: ctx, ownerID, cursor, send
+func (_m *Keeper) Watch(ctx context.Context, ownerID int, cursor int64, send func(changes []*models.Change, cursor int64) error) error {
+	ret := _m.Called(ctx, ownerID, cursor, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Entity) error); ok {
-		r0 = rf(ctx, e)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64, func(changes []*models.Change, cursor int64) error) error); ok {
+		r0 = rf(ctx, ownerID, cursor, send)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchAccessRequests provides a mock function with given fields: ctx, userID, send
+func (_m *Keeper) WatchAccessRequests(ctx context.Context, userID int, send func(requests []*models.AccessRequest) error) error {
+	ret := _m.Called(ctx, userID, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchAccessRequests")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(requests []*models.AccessRequest) error) error); ok {
+		r0 = rf(ctx, userID, send)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,343 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// roles binds the grpc member roles to the keeper ones.
+var roles = map[passkeeperv1.Role]models.Role{
+	passkeeperv1.Role_VIEWER: models.RoleViewer,
+	passkeeperv1.Role_EDITOR: models.RoleEditor,
+	passkeeperv1.Role_ADMIN:  models.RoleAdmin,
+	passkeeperv1.Role_OWNER:  models.RoleOwner,
+}
+
+// CreateOrganization creates the organization, the user becomes its owner.
+func (s server) CreateOrganization(ctx context.Context, in *passkeeperv1.CreateOrganizationRequest) (*passkeeperv1.CreateOrganizationResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling create organization request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	o, err := s.k.CreateOrg(ctx, &models.Org{Name: in.Name}, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to create organization")
+	}
+
+	lg.Info("created organization", slog.Int("id", o.ID))
+	return &passkeeperv1.CreateOrganizationResponse{Organization: orgToGRPC(o)}, nil
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+func (s server) ListOrganizations(ctx context.Context, _ *passkeeperv1.ListOrganizationsRequest) (*passkeeperv1.ListOrganizationsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list organizations request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	orgs, err := s.k.Orgs(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list organizations")
+	}
+	resp := &passkeeperv1.ListOrganizationsResponse{Organizations: make([]*passkeeperv1.Organization, 0, len(orgs))}
+	for _, o := range orgs {
+		resp.Organizations = append(resp.Organizations, orgToGRPC(o))
+	}
+	return resp, nil
+}
+
+// DeleteOrganization deletes the organization with empty vaults.
+func (s server) DeleteOrganization(ctx context.Context, in *passkeeperv1.DeleteOrganizationRequest) (*passkeeperv1.DeleteOrganizationResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling delete organization request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.DeleteOrg(ctx, int(in.Id), uid); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to delete organization")
+	}
+
+	lg.Info("deleted organization", slog.Int64("id", in.Id))
+	return &passkeeperv1.DeleteOrganizationResponse{}, nil
+}
+
+// AddMember adds the registered user to the organization.
+func (s server) AddMember(ctx context.Context, in *passkeeperv1.AddMemberRequest) (*passkeeperv1.AddMemberResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling add member request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	r, ok := roles[in.Role]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %s", in.Role)
+	}
+	m, err := s.k.AddMember(ctx, &models.Member{OrgID: int(in.OrgId), Email: in.Email, Role: r}, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to add member")
+	}
+
+	lg.Info("added member", slog.Int64("org", in.OrgId), slog.String("role", string(r)))
+	return &passkeeperv1.AddMemberResponse{Member: memberToGRPC(m)}, nil
+}
+
+// UpdateMember changes the member role.
+func (s server) UpdateMember(ctx context.Context, in *passkeeperv1.UpdateMemberRequest) (*passkeeperv1.UpdateMemberResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling update member request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	r, ok := roles[in.Role]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %s", in.Role)
+	}
+	m, err := s.k.UpdateMember(ctx, &models.Member{OrgID: int(in.OrgId), Email: in.Email, Role: r}, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to update member")
+	}
+
+	lg.Info("updated member", slog.Int64("org", in.OrgId), slog.String("role", string(r)))
+	return &passkeeperv1.UpdateMemberResponse{Member: memberToGRPC(m)}, nil
+}
+
+// RemoveMember removes the member from the organization.
+func (s server) RemoveMember(ctx context.Context, in *passkeeperv1.RemoveMemberRequest) (*passkeeperv1.RemoveMemberResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling remove member request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.RemoveMember(ctx, int(in.OrgId), uid, in.Email); err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to remove member")
+	}
+
+	lg.Info("removed member", slog.Int64("org", in.OrgId))
+	return &passkeeperv1.RemoveMemberResponse{}, nil
+}
+
+// ListMembers returns the organization members.
+func (s server) ListMembers(ctx context.Context, in *passkeeperv1.ListMembersRequest) (*passkeeperv1.ListMembersResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list members request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	members, err := s.k.Members(ctx, int(in.OrgId), uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to list members")
+	}
+	resp := &passkeeperv1.ListMembersResponse{Members: make([]*passkeeperv1.Member, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, memberToGRPC(m))
+	}
+	return resp, nil
+}
+
+// CreateVault creates the organization vault.
+func (s server) CreateVault(ctx context.Context, in *passkeeperv1.CreateVaultRequest) (*passkeeperv1.CreateVaultResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling create vault request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	v, err := s.k.CreateVault(ctx, &models.Vault{OrgID: int(in.OrgId), Name: in.Name}, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to create vault")
+	}
+
+	lg.Info("created vault", slog.Int("id", v.ID))
+	return &passkeeperv1.CreateVaultResponse{Vault: vaultToGRPC(v)}, nil
+}
+
+// RenameVault renames the organization vault.
+func (s server) RenameVault(ctx context.Context, in *passkeeperv1.RenameVaultRequest) (*passkeeperv1.RenameVaultResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling rename vault request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	v, err := s.k.RenameVault(ctx, &models.Vault{ID: int(in.Id), OrgID: int(in.OrgId), Name: in.Name}, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to rename vault")
+	}
+
+	lg.Info("renamed vault", slog.Int("id", v.ID))
+	return &passkeeperv1.RenameVaultResponse{Vault: vaultToGRPC(v)}, nil
+}
+
+// DeleteVault deletes the empty organization vault.
+func (s server) DeleteVault(ctx context.Context, in *passkeeperv1.DeleteVaultRequest) (*passkeeperv1.DeleteVaultResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling delete vault request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.DeleteVault(ctx, int(in.Id), int(in.OrgId), uid); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to delete vault")
+	}
+
+	lg.Info("deleted vault", slog.Int64("id", in.Id))
+	return &passkeeperv1.DeleteVaultResponse{}, nil
+}
+
+// ListVaults returns the organization vaults.
+func (s server) ListVaults(ctx context.Context, in *passkeeperv1.ListVaultsRequest) (*passkeeperv1.ListVaultsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list vaults request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	vaults, err := s.k.Vaults(ctx, int(in.OrgId), uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.OrgId, "failed to list vaults")
+	}
+	resp := &passkeeperv1.ListVaultsResponse{Vaults: make([]*passkeeperv1.Vault, 0, len(vaults))}
+	for _, v := range vaults {
+		resp.Vaults = append(resp.Vaults, vaultToGRPC(v))
+	}
+	return resp, nil
+}
+
+// SetVaultKeys distributes the vault key encrypted to the members` public keys or rotates it.
+func (s server) SetVaultKeys(ctx context.Context, in *passkeeperv1.SetVaultKeysRequest) (*passkeeperv1.SetVaultKeysResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling set vault keys request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	keys := make([]*models.VaultKey, 0, len(in.Keys))
+	for _, k := range in.Keys {
+		keys = append(keys, &models.VaultKey{VaultID: int(in.VaultId), Email: k.Email, Version: int(in.Version), EncryptedKey: k.EncryptedKey})
+	}
+	v, err := s.k.SetVaultKeys(ctx, int(in.VaultId), int(in.OrgId), int(in.Version), keys, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.VaultId, "failed to set vault keys")
+	}
+
+	lg.Info("set vault keys", slog.Int("id", v.ID), slog.Int("version", v.KeyVersion))
+	return &passkeeperv1.SetVaultKeysResponse{Vault: vaultToGRPC(v)}, nil
+}
+
+// GetVaultKeys returns the vault keys encrypted to the user.
+func (s server) GetVaultKeys(ctx context.Context, in *passkeeperv1.GetVaultKeysRequest) (*passkeeperv1.GetVaultKeysResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling get vault keys request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	keys, err := s.k.VaultKeys(ctx, int(in.VaultId), int(in.OrgId), uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, in.VaultId, "failed to get vault keys")
+	}
+	resp := &passkeeperv1.GetVaultKeysResponse{Keys: make([]*passkeeperv1.VaultKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, &passkeeperv1.VaultKey{
+			Email:        k.Email,
+			Version:      int64(k.Version),
+			EncryptedKey: k.EncryptedKey,
+			CreatedAt:    timestamppb.New(k.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func orgToGRPC(o *models.Org) *passkeeperv1.Organization {
+	return &passkeeperv1.Organization{
+		Id:        int64(o.ID),
+		Name:      o.Name,
+		Role:      fromRole(o.Role),
+		CreatedAt: timestamppb.New(o.CreatedAt),
+	}
+}
+
+func memberToGRPC(m *models.Member) *passkeeperv1.Member {
+	return &passkeeperv1.Member{
+		Email:     m.Email,
+		Role:      fromRole(m.Role),
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
+
+func vaultToGRPC(v *models.Vault) *passkeeperv1.Vault {
+	return &passkeeperv1.Vault{
+		Id:            int64(v.ID),
+		OrgId:         int64(v.OrgID),
+		Name:          v.Name,
+		CreatedAt:     timestamppb.New(v.CreatedAt),
+		UpdatedAt:     timestamppb.New(v.UpdatedAt),
+		KeyVersion:    int64(v.KeyVersion),
+		RekeyRequired: v.RekeyRequired,
+	}
+}
+
+func fromRole(r models.Role) passkeeperv1.Role {
+	for gr, mr := range roles {
+		if mr == r {
+			return gr
+		}
+	}
+	return passkeeperv1.Role_VIEWER
+}
@@ -0,0 +1,118 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_CreateOrganization(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("CreateOrg", mock.Anything, &models.Org{Name: "team"}, 1).
+		Return(&models.Org{ID: 3, Name: "team", Role: models.RoleOwner, CreatedAt: time.Now()}, nil).Once()
+	k.On("CreateOrg", mock.Anything, &models.Org{Name: ""}, 1).Return(nil, passkeeper.ErrEmptyName).Once()
+
+	s := server{k: k}
+	resp, err := s.CreateOrganization(ctx, &passkeeperv1.CreateOrganizationRequest{Name: "team"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Organization.Id)
+	assert.Equal(t, passkeeperv1.Role_OWNER, resp.Organization.Role)
+
+	_, err = s.CreateOrganization(ctx, &passkeeperv1.CreateOrganizationRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_AddMember(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	m := &models.Member{OrgID: 3, Email: "friend@gmail.com", Role: models.RoleEditor}
+	k.On("AddMember", mock.Anything, m, 1).
+		Return(&models.Member{OrgID: 3, UserID: 2, Email: "friend@gmail.com", Role: models.RoleEditor, CreatedAt: time.Now()}, nil).Once()
+	k.On("AddMember", mock.Anything, m, 1).Return(nil, passkeeper.ErrPermissionDenied).Once()
+	k.On("AddMember", mock.Anything, m, 1).Return(nil, passkeeper.ErrOrgNotFound).Once()
+
+	s := server{k: k}
+	req := &passkeeperv1.AddMemberRequest{OrgId: 3, Email: "friend@gmail.com", Role: passkeeperv1.Role_EDITOR}
+	resp, err := s.AddMember(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, passkeeperv1.Role_EDITOR, resp.Member.Role)
+
+	_, err = s.AddMember(ctx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.AddMember(ctx, req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.AddMember(ctx, &passkeeperv1.AddMemberRequest{OrgId: 3, Email: "friend@gmail.com", Role: 9})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListVaults(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Vaults", mock.Anything, 3, 1).Return([]*models.Vault{{ID: 4, OrgID: 3, Name: "prod"}}, nil).Once()
+
+	s := server{k: k}
+	resp, err := s.ListVaults(ctx, &passkeeperv1.ListVaultsRequest{OrgId: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Vaults, 1)
+	assert.Equal(t, "prod", resp.Vaults[0].Name)
+	assert.Equal(t, int64(3), resp.Vaults[0].OrgId)
+}
+
+func TestServer_VaultKeys(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	keys := []*models.VaultKey{{VaultID: 4, Email: "friend@gmail.com", Version: 2, EncryptedKey: []byte("key")}}
+	k.On("SetVaultKeys", mock.Anything, 4, 3, 2, keys, 1).
+		Return(&models.Vault{ID: 4, OrgID: 3, Name: "prod", KeyVersion: 2}, nil).Once()
+	k.On("SetVaultKeys", mock.Anything, 4, 3, 2, keys, 1).Return(nil, passkeeper.ErrVaultKeyVersion).Once()
+	k.On("SetVaultKeys", mock.Anything, 4, 3, 2, keys, 1).Return(nil, passkeeper.ErrVaultRekeyRequired).Once()
+	k.On("VaultKeys", mock.Anything, 4, 3, 1).Return([]*models.VaultKey{
+		{VaultID: 4, UserID: 1, Version: 1, EncryptedKey: []byte("old")},
+		{VaultID: 4, UserID: 1, Version: 2, EncryptedKey: []byte("new")},
+	}, nil).Once()
+
+	s := server{k: k}
+	req := &passkeeperv1.SetVaultKeysRequest{OrgId: 3, VaultId: 4, Version: 2,
+		Keys: []*passkeeperv1.VaultKey{{Email: "friend@gmail.com", EncryptedKey: []byte("key")}}}
+	resp, err := s.SetVaultKeys(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Vault.KeyVersion)
+	assert.False(t, resp.Vault.RekeyRequired)
+
+	_, err = s.SetVaultKeys(ctx, req)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	_, err = s.SetVaultKeys(ctx, req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	kr, err := s.GetVaultKeys(ctx, &passkeeperv1.GetVaultKeysRequest{OrgId: 3, VaultId: 4})
+	require.NoError(t, err)
+	require.Len(t, kr.Keys, 2)
+	assert.Equal(t, []byte("new"), kr.Keys[1].EncryptedKey)
+}
@@ -3,12 +3,17 @@ package passkeepergrpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/vindosVP/go-pass/internal/labels"
 	"github.com/vindosVP/go-pass/internal/models"
 	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
 	"github.com/vindosVP/go-pass/internal/services/passkeeper"
@@ -21,9 +26,62 @@ import (
 //go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Keeper
 type Keeper interface {
 	Save(ctx context.Context, e *models.Entity) (int, error)
-	Update(ctx context.Context, e *models.Entity) error
-	Delete(ctx context.Context, id int, ownerID int, t models.EntityType) error
-	List(ctx context.Context, ownerID int) ([]*models.Entity, error)
+	Update(ctx context.Context, e *models.Entity, fields []models.EntityField) error
+	Delete(ctx context.Context, id int, ownerID int, t models.EntityType, revision int) error
+	Get(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error)
+	List(ctx context.Context, ownerID int, opts models.ListOptions) ([]*models.Entity, string, error)
+	Stream(ctx context.Context, ownerID int, opts models.ListOptions, send func(e *models.Entity) error) error
+	Search(ctx context.Context, ownerID int, opts models.SearchOptions) ([]*models.SearchResult, error)
+	Watch(ctx context.Context, ownerID int, cursor int64, send func(changes []*models.Change, cursor int64) error) error
+	SyncSince(ctx context.Context, ownerID int, cursor int64) (*models.SyncResult, error)
+	UpdateFromBase(ctx context.Context, e *models.Entity, fields []models.EntityField, base int) error
+	Conflicts(ctx context.Context, ownerID int) ([]*models.Conflict, error)
+	Conflict(ctx context.Context, id int, ownerID int) (*models.Conflict, error)
+	ResolveConflict(ctx context.Context, id int, ownerID int, r models.Resolution, merged *models.Entity,
+		fields []models.EntityField, expectedRevision int) (*models.Entity, error)
+	Share(ctx context.Context, sh *models.Share) (*models.Share, error)
+	Unshare(ctx context.Context, id int, ownerID int, t models.EntityType, email string) error
+	Shares(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Share, error)
+	CreateOrg(ctx context.Context, o *models.Org, userID int) (*models.Org, error)
+	Orgs(ctx context.Context, userID int) ([]*models.Org, error)
+	DeleteOrg(ctx context.Context, id int, userID int) error
+	Members(ctx context.Context, orgID int, userID int) ([]*models.Member, error)
+	AddMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error)
+	UpdateMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error)
+	RemoveMember(ctx context.Context, orgID int, userID int, email string) error
+	Vaults(ctx context.Context, orgID int, userID int) ([]*models.Vault, error)
+	SetVaultKeys(ctx context.Context, vaultID int, orgID int, version int, keys []*models.VaultKey, userID int) (*models.Vault, error)
+	VaultKeys(ctx context.Context, vaultID int, orgID int, userID int) ([]*models.VaultKey, error)
+	CreateSend(ctx context.Context, s *models.Send, password string, ttl time.Duration) (*models.Send, string, error)
+	OpenSend(ctx context.Context, token string, password string) (*models.Send, error)
+	Sends(ctx context.Context, ownerID int) ([]*models.Send, error)
+	DeleteSend(ctx context.Context, id int, ownerID int) error
+	InviteEmergencyContact(ctx context.Context, c *models.EmergencyContact) (*models.EmergencyContact, error)
+	UpdateEmergencyAccess(ctx context.Context, id int, userID int, action models.EmergencyAction) (*models.EmergencyContact, error)
+	EmergencyContacts(ctx context.Context, userID int) ([]*models.EmergencyContact, error)
+	EmergencyEvents(ctx context.Context, id int, userID int) ([]*models.EmergencyEvent, error)
+	SetApprovalRequired(ctx context.Context, id int, userID int, t models.EntityType, required bool) error
+	RequestAccess(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error)
+	DecideAccessRequest(ctx context.Context, id int, userID int, approve bool, window time.Duration) (*models.AccessRequest, error)
+	AccessRequests(ctx context.Context, userID int) ([]*models.AccessRequest, error)
+	WatchAccessRequests(ctx context.Context, userID int, send func(requests []*models.AccessRequest) error) error
+	AccessEvents(ctx context.Context, id int, userID int, t models.EntityType) ([]*models.AccessEvent, error)
+	CreateVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error)
+	RenameVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error)
+	DeleteVault(ctx context.Context, id int, orgID int, userID int) error
+	Versions(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Version, error)
+	Restore(ctx context.Context, id int, ownerID int, t models.EntityType, revision int, expectedRevision int) (*models.Entity, error)
+	Trash(ctx context.Context, ownerID int) ([]*models.Entity, error)
+	RestoreFromTrash(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error)
+	Purge(ctx context.Context, id int, ownerID int, t models.EntityType) error
+	CreateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error)
+	UpdateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error)
+	DeleteFolder(ctx context.Context, id int, ownerID int) error
+	Folders(ctx context.Context, ownerID int) ([]*models.Folder, error)
+	CreateTag(ctx context.Context, t *models.Tag) (*models.Tag, error)
+	RenameTag(ctx context.Context, t *models.Tag) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id int, ownerID int) error
+	Tags(ctx context.Context, ownerID int) ([]*models.Tag, error)
 	SaveFile(str passkeeperv1.PassKeeper_UploadFileServer) error
 	DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_DownloadFileServer) error
 }
@@ -52,8 +110,7 @@ func (s server) AddEntity(ctx context.Context, in *passkeeperv1.AddEntityRequest
 	e := grpcToDTO(in.Entity, uid)
 	id, err := s.k.Save(ctx, e)
 	if err != nil {
-		lg.Error("failed to save entity", err)
-		return nil, status.Errorf(codes.Internal, "failed to save entity")
+		return nil, errorStatus(lg, err, 0, "failed to save entity")
 	}
 
 	lg.Info("saved entity", slog.Int("id", id))
@@ -71,23 +128,27 @@ func (s server) UpdateEntity(ctx context.Context, in *passkeeperv1.UpdateEntityR
 		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
 	}
 
+	if in.BaseRevision != 0 && in.ExpectedRevision != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expected_revision and base_revision can not be set both")
+	}
+
 	e := grpcToDTO(in.Entity, uid)
 	e.ID = int(in.Id)
-	err = s.k.Update(ctx, e)
+	if in.BaseRevision != 0 {
+		err = s.k.UpdateFromBase(ctx, e, updateFields(in.UpdateMask), int(in.BaseRevision))
+	} else {
+		e.Revision = int(in.ExpectedRevision)
+		err = s.k.Update(ctx, e, updateFields(in.UpdateMask))
+	}
 	if err != nil {
-		if errors.Is(err, passkeeper.ErrUnableToUpdateFile) {
-			lg.Info("unable to update file")
-			return nil, status.Errorf(codes.InvalidArgument, "unable to update file")
-		}
-		lg.Error("failed to update entity", err)
-		return nil, status.Errorf(codes.Internal, "failed to update entity")
+		return nil, errorStatus(lg, err, in.Id, "failed to update entity")
 	}
 
 	lg.Info("updated entity", slog.Int("id", e.ID))
 	return &passkeeperv1.UpdateEntityResponse{}, nil
 }
 
-// DeleteEntity deletes the entity.
+// DeleteEntity moves the entity to the trash.
 func (s server) DeleteEntity(ctx context.Context, in *passkeeperv1.DeleteEntityRequest) (*passkeeperv1.DeleteEntityResponse, error) {
 
 	lg := sl.Log
@@ -98,17 +159,35 @@ func (s server) DeleteEntity(ctx context.Context, in *passkeeperv1.DeleteEntityR
 		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
 	}
 
-	err = s.k.Delete(ctx, int(in.Id), uid, totype(in.Type))
+	err = s.k.Delete(ctx, int(in.Id), uid, totype(in.Type), int(in.ExpectedRevision))
 	if err != nil {
-		lg.Error("failed to delete entity", err)
-		return nil, status.Errorf(codes.Internal, "failed to delete entity")
+		return nil, errorStatus(lg, err, in.Id, "failed to delete entity")
 	}
 	lg.Info("deleted entity", slog.Int("id", int(in.Id)))
 	return &passkeeperv1.DeleteEntityResponse{}, nil
 }
 
-// ListEntities lists all entities.
-func (s server) ListEntities(ctx context.Context, _ *passkeeperv1.ListEntitiesRequest) (*passkeeperv1.ListEntitiesResponse, error) {
+// GetEntity returns the entity.
+func (s server) GetEntity(ctx context.Context, in *passkeeperv1.GetEntityRequest) (*passkeeperv1.GetEntityResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling get entity request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	e, err := s.k.Get(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to get entity")
+	}
+
+	return &passkeeperv1.GetEntityResponse{Entity: dtoToGRPC(e)}, nil
+}
+
+// ListEntities lists entities matching the request filters.
+func (s server) ListEntities(ctx context.Context, in *passkeeperv1.ListEntitiesRequest) (*passkeeperv1.ListEntitiesResponse, error) {
 
 	lg := sl.Log
 	lg.Info("handling list entities request")
@@ -118,18 +197,185 @@ func (s server) ListEntities(ctx context.Context, _ *passkeeperv1.ListEntitiesRe
 		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
 	}
 
-	res, err := s.k.List(ctx, uid)
+	opts, err := listOptions(in)
 	if err != nil {
-		lg.Error("failed to list entities", err)
-		return nil, status.Errorf(codes.Internal, "failed to list entities")
+		lg.Info("invalid list options", sl.Err(err))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+
+	res, next, err := s.k.List(ctx, uid, opts)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list entities")
+	}
+	resp := &passkeeperv1.ListEntitiesResponse{
+		Entity:        make([]*passkeeperv1.Entity, 0, len(res)),
+		NextPageToken: next,
 	}
-	resp := &passkeeperv1.ListEntitiesResponse{Entity: make([]*passkeeperv1.Entity, 0, len(res))}
 	for _, e := range res {
 		resp.Entity = append(resp.Entity, dtoToGRPC(e))
 	}
 	return resp, nil
 }
 
+// StreamEntities streams entities matching the request filters.
+func (s server) StreamEntities(in *passkeeperv1.StreamEntitiesRequest, str passkeeperv1.PassKeeper_StreamEntitiesServer) error {
+
+	lg := sl.Log
+	lg.Info("handling stream entities request")
+
+	ctx := str.Context()
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	opts, err := listOptions(&passkeeperv1.ListEntitiesRequest{
+		Types:         in.Types,
+		CreatedFrom:   in.CreatedFrom,
+		CreatedTo:     in.CreatedTo,
+		UpdatedFrom:   in.UpdatedFrom,
+		UpdatedTo:     in.UpdatedTo,
+		Metadata:      in.Metadata,
+		FolderId:      in.FolderId,
+		TagIds:        in.TagIds,
+		LabelSelector: in.LabelSelector,
+		VaultId:       in.VaultId,
+	})
+	if err != nil {
+		lg.Info("invalid stream options", sl.Err(err))
+		return status.Errorf(codes.InvalidArgument, "invalid stream options: %v", err)
+	}
+
+	sent := 0
+	err = s.k.Stream(ctx, uid, opts, func(e *models.Entity) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sent++
+		return str.Send(&passkeeperv1.StreamEntitiesResponse{Entity: dtoToGRPC(e)})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			lg.Info("stream entities canceled by client", slog.Int("sent", sent))
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return errorStatus(lg, err, 0, "failed to stream entities")
+	}
+
+	lg.Info("streamed entities", slog.Int("sent", sent))
+	return nil
+}
+
+// ListEntityVersions returns the prior versions of the entity.
+func (s server) ListEntityVersions(ctx context.Context, in *passkeeperv1.ListEntityVersionsRequest) (*passkeeperv1.ListEntityVersionsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list entity versions request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	versions, err := s.k.Versions(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to list entity versions")
+	}
+
+	resp := &passkeeperv1.ListEntityVersionsResponse{
+		Versions: make([]*passkeeperv1.EntityVersion, 0, len(versions)),
+	}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, &passkeeperv1.EntityVersion{
+			Entity:     dtoToGRPC(v.Entity),
+			ArchivedAt: timestamppb.New(v.ArchivedAt),
+		})
+	}
+	return resp, nil
+}
+
+// RestoreEntityVersion makes the prior entity version the current one.
+func (s server) RestoreEntityVersion(ctx context.Context, in *passkeeperv1.RestoreEntityVersionRequest) (*passkeeperv1.RestoreEntityVersionResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling restore entity version request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	e, err := s.k.Restore(ctx, int(in.Id), uid, totype(in.Type), int(in.Revision), int(in.ExpectedRevision))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to restore entity version")
+	}
+
+	lg.Info("restored entity version", slog.Int("id", e.ID), slog.Int64("revision", in.Revision))
+	return &passkeeperv1.RestoreEntityVersionResponse{Entity: dtoToGRPC(e)}, nil
+}
+
+// ListTrash returns the entities in the trash.
+func (s server) ListTrash(ctx context.Context, _ *passkeeperv1.ListTrashRequest) (*passkeeperv1.ListTrashResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list trash request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	res, err := s.k.Trash(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list trash")
+	}
+	resp := &passkeeperv1.ListTrashResponse{Entity: make([]*passkeeperv1.Entity, 0, len(res))}
+	for _, e := range res {
+		resp.Entity = append(resp.Entity, dtoToGRPC(e))
+	}
+	return resp, nil
+}
+
+// RestoreEntity moves the entity back from the trash.
+func (s server) RestoreEntity(ctx context.Context, in *passkeeperv1.RestoreEntityRequest) (*passkeeperv1.RestoreEntityResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling restore entity request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	e, err := s.k.RestoreFromTrash(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to restore entity")
+	}
+
+	lg.Info("restored entity", slog.Int("id", e.ID))
+	return &passkeeperv1.RestoreEntityResponse{Entity: dtoToGRPC(e)}, nil
+}
+
+// PurgeEntity permanently deletes the entity in the trash.
+func (s server) PurgeEntity(ctx context.Context, in *passkeeperv1.PurgeEntityRequest) (*passkeeperv1.PurgeEntityResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling purge entity request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	err = s.k.Purge(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to purge entity")
+	}
+
+	lg.Info("purged entity", slog.Int("id", int(in.Id)))
+	return &passkeeperv1.PurgeEntityResponse{}, nil
+}
+
 // UploadFile uploads files to the server.
 func (s server) UploadFile(str passkeeperv1.PassKeeper_UploadFileServer) error {
 	return s.k.SaveFile(str)
@@ -146,30 +392,38 @@ func (s server) DownloadFile(in *passkeeperv1.DownloadFileRequest, str passkeepe
 
 func dtoToGRPC(e *models.Entity) *passkeeperv1.Entity {
 
-	var t passkeeperv1.Type
-	switch e.Type {
-	case models.TypePassword:
-		t = passkeeperv1.Type_PASSWORD
-	case models.TypeFile:
-		t = passkeeperv1.Type_FILE
-	case models.TypeCard:
-		t = passkeeperv1.Type_CARD
-	case models.TypeText:
-		t = passkeeperv1.Type_TEXT
+	var deletedAt *timestamppb.Timestamp
+	if !e.DeletedAt.IsZero() {
+		deletedAt = timestamppb.New(e.DeletedAt)
 	}
 
 	return &passkeeperv1.Entity{
-		Id:         int64(e.ID),
-		Type:       t,
-		Login:      e.Login,
-		Password:   e.Password,
-		CardNumber: e.CardNumber,
-		CardOwner:  e.CardOwner,
-		CardCVC:    e.CardCVC,
-		CardExp:    e.CardExp,
-		Text:       e.Text,
-		Filename:   e.Filename,
-		Metadata:   e.Metadata,
+		Id:           int64(e.ID),
+		Type:         fromtype(e.Type),
+		Login:        e.Login,
+		Password:     e.Password,
+		CardNumber:   e.CardNumber,
+		CardOwner:    e.CardOwner,
+		CardCVC:      e.CardCVC,
+		CardExp:      e.CardExp,
+		Text:         e.Text,
+		Filename:     e.Filename,
+		Metadata:     e.Metadata,
+		CreatedAt:    timestamppb.New(e.CreatedAt),
+		UpdatedAt:    timestamppb.New(e.UpdatedAt),
+		Revision:     int64(e.Revision),
+		DeletedAt:    deletedAt,
+		FolderId:     int64(e.FolderID),
+		TagIds:       toInt64s(e.TagIDs),
+		Labels:       e.Labels,
+		CustomFields: passkeeper.CustomFieldsToGRPC(e.Custom),
+		Shared:       e.Permission != "" && e.VaultID == 0,
+		Permission:   fromPermission(e.Permission),
+		VaultId:      int64(e.VaultID),
+
+		RequiresApproval: e.RequiresApproval,
+		Approver:         e.Approver,
+		Sealed:           e.Sealed,
 	}
 }
 
@@ -200,6 +454,113 @@ func grpcToDTO(e *passkeeperv1.Entity, ownerID int) *models.Entity {
 		Text:       e.Text,
 		Filename:   e.Filename,
 		Metadata:   e.Metadata,
+		FolderID:   int(e.FolderId),
+		TagIDs:     toInts(e.TagIds),
+		Labels:     e.Labels,
+		Custom:     passkeeper.CustomFieldsFromGRPC(e.CustomFields),
+		VaultID:    int(e.VaultId),
+	}
+}
+
+func toInt64s(ids []int) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int64(id))
+	}
+	return res
+}
+
+func toInts(ids []int64) []int {
+	if len(ids) == 0 {
+		return nil
+	}
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int(id))
+	}
+	return res
+}
+
+func updateFields(mask *fieldmaskpb.FieldMask) []models.EntityField {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		return nil
+	}
+	fields := make([]models.EntityField, 0, len(paths))
+	for _, p := range paths {
+		fields = append(fields, models.EntityField(p))
+	}
+	return fields
+}
+
+func listOptions(in *passkeeperv1.ListEntitiesRequest) (models.ListOptions, error) {
+
+	if in.PageSize < 0 {
+		return models.ListOptions{}, errors.New("page size must not be negative")
+	}
+
+	opts := models.ListOptions{
+		Metadata:  in.Metadata,
+		Desc:      in.Descending,
+		PageSize:  int(in.PageSize),
+		PageToken: in.PageToken,
+		FolderID:  int(in.FolderId),
+		TagIDs:    toInts(in.TagIds),
+		VaultID:   int(in.VaultId),
+	}
+	for _, t := range in.Types {
+		opts.Types = append(opts.Types, totype(t))
+	}
+
+	selector, err := labels.Parse(in.LabelSelector)
+	if err != nil {
+		return models.ListOptions{}, err
+	}
+	opts.Labels = selector
+
+	switch in.SortBy {
+	case passkeeperv1.SortBy_CREATED_AT:
+		opts.SortBy = models.SortByCreatedAt
+	case passkeeperv1.SortBy_UPDATED_AT:
+		opts.SortBy = models.SortByUpdatedAt
+	default:
+		return models.ListOptions{}, fmt.Errorf("unknown sort field %d", in.SortBy)
+	}
+
+	for _, ts := range []struct {
+		in  *timestamppb.Timestamp
+		out *time.Time
+	}{
+		{in.CreatedFrom, &opts.CreatedFrom},
+		{in.CreatedTo, &opts.CreatedTo},
+		{in.UpdatedFrom, &opts.UpdatedFrom},
+		{in.UpdatedTo, &opts.UpdatedTo},
+	} {
+		if ts.in == nil {
+			continue
+		}
+		if err := ts.in.CheckValid(); err != nil {
+			return models.ListOptions{}, err
+		}
+		*ts.out = ts.in.AsTime()
+	}
+
+	return opts, nil
+}
+
+func fromtype(t models.EntityType) passkeeperv1.Type {
+	switch t {
+	case models.TypeFile:
+		return passkeeperv1.Type_FILE
+	case models.TypeCard:
+		return passkeeperv1.Type_CARD
+	case models.TypeText:
+		return passkeeperv1.Type_TEXT
+	default:
+		return passkeeperv1.Type_PASSWORD
 	}
 }
 
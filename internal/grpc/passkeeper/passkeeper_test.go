@@ -0,0 +1,164 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/labels"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_UpdateEntity(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		details any
+	}{
+		{
+			name: "ok",
+			code: codes.OK,
+		},
+		{
+			name:    "not found",
+			err:     storage.ErrPasswordNotExist,
+			code:    codes.NotFound,
+			details: &errdetails.ResourceInfo{},
+		},
+		{
+			name: "revision mismatch",
+			err: &storage.Error{
+				Kind:     storage.KindConflict,
+				Resource: "password",
+				Reason:   storage.ErrRevisionMismatch.Reason,
+			},
+			code:    codes.Aborted,
+			details: &errdetails.ErrorInfo{},
+		},
+		{
+			name:    "quota exceeded",
+			err:     &storage.Error{Kind: storage.KindQuota, Resource: "password"},
+			code:    codes.ResourceExhausted,
+			details: &errdetails.QuotaFailure{},
+		},
+		{
+			name:    "invalid update mask",
+			err:     passkeeper.ErrInvalidUpdateMask,
+			code:    codes.InvalidArgument,
+			details: &errdetails.BadRequest{},
+		},
+		{
+			name: "unexpected error",
+			err:  errors.New("unexpected error"),
+			code: codes.Internal,
+		},
+	}
+
+	sl.SetupLogger("test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+			k := mocks.NewKeeper(t)
+			k.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(tt.err)
+
+			s := server{k: k}
+			_, err := s.UpdateEntity(ctx, &passkeeperv1.UpdateEntityRequest{
+				Id:     1,
+				Entity: &passkeeperv1.Entity{Type: passkeeperv1.Type_PASSWORD},
+			})
+			st := status.Convert(err)
+			assert.Equal(t, tt.code, st.Code())
+			if tt.details == nil {
+				assert.Empty(t, st.Details())
+				return
+			}
+			require.Len(t, st.Details(), 1)
+			assert.IsType(t, tt.details, st.Details()[0])
+		})
+	}
+}
+
+func TestServer_DeleteEntity(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Delete", mock.Anything, 5, 1, mock.Anything, 0).Return(storage.ErrCardNotExist)
+
+	s := server{k: k}
+	_, err := s.DeleteEntity(ctx, &passkeeperv1.DeleteEntityRequest{Id: 5, Type: passkeeperv1.Type_CARD})
+	st := status.Convert(err)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ResourceInfo)
+	require.True(t, ok)
+	assert.Equal(t, "card", info.ResourceType)
+	assert.Equal(t, "5", info.ResourceName)
+}
+
+func TestServer_ListEntitiesLabels(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("List", mock.Anything, 1, mock.MatchedBy(func(opts models.ListOptions) bool {
+		return len(opts.Labels) == 2 && opts.Labels[0].Op == labels.In && opts.Labels[1].Op == labels.DoesNotExist
+	})).Return([]*models.Entity{{ID: 1, Type: models.TypeText, Labels: map[string]string{"env": "prod"}}}, "", nil)
+
+	s := server{k: k}
+	resp, err := s.ListEntities(ctx, &passkeeperv1.ListEntitiesRequest{LabelSelector: "env in (prod,staging),!deprecated"})
+	require.NoError(t, err)
+	require.Len(t, resp.Entity, 1)
+	assert.Equal(t, map[string]string{"env": "prod"}, resp.Entity[0].Labels)
+
+	_, err = s.ListEntities(ctx, &passkeeperv1.ListEntitiesRequest{LabelSelector: "env in (prod"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_AddEntityCustomFields(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	want := []models.CustomField{
+		{Name: "pin", Type: models.CustomHidden, Value: "1234"},
+		{Name: "birthday", Type: models.CustomDate, Value: "2000-01-31"},
+	}
+	k.On("Save", mock.Anything, mock.MatchedBy(func(e *models.Entity) bool {
+		return assert.ObjectsAreEqual(want, e.Custom)
+	})).Return(1, nil).Once()
+	k.On("Save", mock.Anything, mock.Anything).Return(0, fmt.Errorf("%w: bad date", passkeeper.ErrInvalidCustomFields)).Once()
+
+	s := server{k: k}
+	custom := []*passkeeperv1.CustomField{
+		{Name: "pin", Type: passkeeperv1.CustomFieldType_CUSTOM_HIDDEN, Value: "1234"},
+		{Name: "birthday", Type: passkeeperv1.CustomFieldType_CUSTOM_DATE, Value: "2000-01-31"},
+	}
+	_, err := s.AddEntity(ctx, &passkeeperv1.AddEntityRequest{Entity: &passkeeperv1.Entity{CustomFields: custom}})
+	require.NoError(t, err)
+
+	_, err = s.AddEntity(ctx, &passkeeperv1.AddEntityRequest{Entity: &passkeeperv1.Entity{CustomFields: custom}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	assert.Equal(t, custom, dtoToGRPC(&models.Entity{Custom: want}).CustomFields)
+}
@@ -0,0 +1,50 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// SearchEntities returns the entities matching the query, the best matches first.
+func (s server) SearchEntities(ctx context.Context, in *passkeeperv1.SearchEntitiesRequest) (*passkeeperv1.SearchEntitiesResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling search entities request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+	if in.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	opts := models.SearchOptions{Query: in.Query, Limit: int(in.Limit)}
+	for _, t := range in.Types {
+		opts.Types = append(opts.Types, totype(t))
+	}
+
+	res, err := s.k.Search(ctx, uid, opts)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to search entities")
+	}
+
+	lg.Info("found entities", slog.Int("count", len(res)))
+	resp := &passkeeperv1.SearchEntitiesResponse{Results: make([]*passkeeperv1.SearchResult, 0, len(res))}
+	for _, r := range res {
+		resp.Results = append(resp.Results, &passkeeperv1.SearchResult{
+			Entity:  dtoToGRPC(r.Entity),
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		})
+	}
+	return resp, nil
+}
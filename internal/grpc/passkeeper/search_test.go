@@ -0,0 +1,52 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_SearchEntities(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Search", mock.Anything, 1, models.SearchOptions{Query: "github", Types: []models.EntityType{models.TypePassword}, Limit: 5}).
+		Return([]*models.SearchResult{{
+			Entity:  &models.Entity{ID: 7, Type: models.TypePassword, Login: "octocat"},
+			Rank:    0.5,
+			Snippet: "<mark>github</mark> account",
+		}}, nil).Once()
+	k.On("Search", mock.Anything, 1, models.SearchOptions{Query: " "}).Return(nil, passkeeper.ErrEmptyQuery).Once()
+
+	s := server{k: k}
+	resp, err := s.SearchEntities(ctx, &passkeeperv1.SearchEntitiesRequest{
+		Query: "github",
+		Types: []passkeeperv1.Type{passkeeperv1.Type_PASSWORD},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, int64(7), resp.Results[0].Entity.Id)
+	assert.Equal(t, "<mark>github</mark> account", resp.Results[0].Snippet)
+	assert.Equal(t, 0.5, resp.Results[0].Rank)
+
+	_, err = s.SearchEntities(ctx, &passkeeperv1.SearchEntitiesRequest{Query: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.SearchEntities(ctx, &passkeeperv1.SearchEntitiesRequest{Query: "github", Limit: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
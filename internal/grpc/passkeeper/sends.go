@@ -0,0 +1,133 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// sendKinds binds the grpc send kinds to the keeper ones.
+var sendKinds = map[passkeeperv1.SendKind]models.SendKind{
+	passkeeperv1.SendKind_SEND_TEXT: models.SendText,
+	passkeeperv1.SendKind_SEND_FILE: models.SendFile,
+}
+
+// CreateSend creates the one-time send and returns its token.
+func (s server) CreateSend(ctx context.Context, in *passkeeperv1.CreateSendRequest) (*passkeeperv1.CreateSendResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling create send request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	kind, ok := sendKinds[in.Kind]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown send kind %s", in.Kind)
+	}
+	sd := &models.Send{OwnerID: uid, Kind: kind, Filename: in.Filename, Payload: in.Payload, MaxViews: int(in.MaxViews)}
+	res, token, err := s.k.CreateSend(ctx, sd, in.Password, in.Ttl.AsDuration())
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to create send")
+	}
+
+	lg.Info("created send", slog.Int("id", res.ID))
+	return &passkeeperv1.CreateSendResponse{Send: sendToGRPC(res), Token: token}, nil
+}
+
+// OpenSend redeems the send token.
+func (s server) OpenSend(ctx context.Context, in *passkeeperv1.OpenSendRequest) (*passkeeperv1.OpenSendResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling open send request")
+
+	if in.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	sd, err := s.k.OpenSend(ctx, in.Token, in.Password)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to open send")
+	}
+
+	lg.Info("opened send", slog.Int("id", sd.ID))
+	return &passkeeperv1.OpenSendResponse{
+		Kind:           fromSendKind(sd.Kind),
+		Filename:       sd.Filename,
+		Payload:        sd.Payload,
+		RemainingViews: int64(sd.MaxViews - sd.Views),
+		ExpiresAt:      timestamppb.New(sd.ExpiresAt),
+	}, nil
+}
+
+// ListSends returns the active sends.
+func (s server) ListSends(ctx context.Context, _ *passkeeperv1.ListSendsRequest) (*passkeeperv1.ListSendsResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list sends request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	sends, err := s.k.Sends(ctx, uid)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to list sends")
+	}
+	resp := &passkeeperv1.ListSendsResponse{Sends: make([]*passkeeperv1.Send, 0, len(sends))}
+	for _, sd := range sends {
+		resp.Sends = append(resp.Sends, sendToGRPC(sd))
+	}
+	return resp, nil
+}
+
+// DeleteSend destroys the send before it expires.
+func (s server) DeleteSend(ctx context.Context, in *passkeeperv1.DeleteSendRequest) (*passkeeperv1.DeleteSendResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling delete send request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.DeleteSend(ctx, int(in.Id), uid); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to delete send")
+	}
+
+	lg.Info("deleted send", slog.Int64("id", in.Id))
+	return &passkeeperv1.DeleteSendResponse{}, nil
+}
+
+func sendToGRPC(sd *models.Send) *passkeeperv1.Send {
+	return &passkeeperv1.Send{
+		Id:                int64(sd.ID),
+		Kind:              fromSendKind(sd.Kind),
+		Filename:          sd.Filename,
+		PasswordProtected: len(sd.PasswordHash) > 0,
+		MaxViews:          int64(sd.MaxViews),
+		Views:             int64(sd.Views),
+		ExpiresAt:         timestamppb.New(sd.ExpiresAt),
+		CreatedAt:         timestamppb.New(sd.CreatedAt),
+	}
+}
+
+func fromSendKind(k models.SendKind) passkeeperv1.SendKind {
+	for gk, mk := range sendKinds {
+		if mk == k {
+			return gk
+		}
+	}
+	return passkeeperv1.SendKind_SEND_TEXT
+}
@@ -0,0 +1,71 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_CreateSend(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	sd := &models.Send{OwnerID: 1, Kind: models.SendFile, Filename: "key.pem", Payload: []byte("secret"), MaxViews: 2}
+	k.On("CreateSend", mock.Anything, sd, "pass", time.Hour).
+		Return(&models.Send{ID: 5, OwnerID: 1, Kind: models.SendFile, Filename: "key.pem", PasswordHash: []byte("hash"),
+			MaxViews: 2, ExpiresAt: time.Now().Add(time.Hour)}, "token", nil).Once()
+	k.On("CreateSend", mock.Anything, mock.Anything, "", time.Duration(0)).Return(nil, "", passkeeper.ErrInvalidSend).Once()
+
+	s := server{k: k}
+	resp, err := s.CreateSend(ctx, &passkeeperv1.CreateSendRequest{Kind: passkeeperv1.SendKind_SEND_FILE, Filename: "key.pem",
+		Payload: []byte("secret"), Password: "pass", MaxViews: 2, Ttl: durationpb.New(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "token", resp.Token)
+	assert.True(t, resp.Send.PasswordProtected)
+	assert.Equal(t, passkeeperv1.SendKind_SEND_FILE, resp.Send.Kind)
+
+	_, err = s.CreateSend(ctx, &passkeeperv1.CreateSendRequest{Payload: []byte("secret"), MaxViews: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_OpenSend(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	// the send is opened without the uid
+	ctx := context.Background()
+	k := mocks.NewKeeper(t)
+	k.On("OpenSend", mock.Anything, "token", "").
+		Return(&models.Send{ID: 5, Kind: models.SendText, Payload: []byte("secret"), MaxViews: 3, Views: 1}, nil).Once()
+	k.On("OpenSend", mock.Anything, "token", "").Return(nil, passkeeper.ErrSendPassword).Once()
+	k.On("OpenSend", mock.Anything, "token", "").Return(nil, passkeeper.ErrSendNotFound).Once()
+
+	s := server{k: k}
+	resp, err := s.OpenSend(ctx, &passkeeperv1.OpenSendRequest{Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), resp.Payload)
+	assert.Equal(t, int64(2), resp.RemainingViews)
+
+	_, err = s.OpenSend(ctx, &passkeeperv1.OpenSendRequest{Token: "token"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.OpenSend(ctx, &passkeeperv1.OpenSendRequest{Token: "token"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.OpenSend(ctx, &passkeeperv1.OpenSendRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
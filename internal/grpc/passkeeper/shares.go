@@ -0,0 +1,108 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// permissions binds the grpc share permissions to the keeper ones.
+var permissions = map[passkeeperv1.Permission]models.Permission{
+	passkeeperv1.Permission_READ:  models.PermissionRead,
+	passkeeperv1.Permission_WRITE: models.PermissionWrite,
+}
+
+// ShareEntity grants the user access to the entity or changes the access granted before.
+func (s server) ShareEntity(ctx context.Context, in *passkeeperv1.ShareEntityRequest) (*passkeeperv1.ShareEntityResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling share entity request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	p, ok := permissions[in.Permission]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown permission %s", in.Permission)
+	}
+	sh, err := s.k.Share(ctx, &models.Share{
+		Type:       totype(in.Type),
+		EntityID:   int(in.Id),
+		OwnerID:    uid,
+		Email:      in.Email,
+		Permission: p,
+	})
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to share entity")
+	}
+
+	lg.Info("shared entity", slog.Int64("id", in.Id), slog.String("permission", string(p)))
+	return &passkeeperv1.ShareEntityResponse{Share: shareToGRPC(sh)}, nil
+}
+
+// UnshareEntity revokes the user`s access to the entity.
+func (s server) UnshareEntity(ctx context.Context, in *passkeeperv1.UnshareEntityRequest) (*passkeeperv1.UnshareEntityResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling unshare entity request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	if err := s.k.Unshare(ctx, int(in.Id), uid, totype(in.Type), in.Email); err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to unshare entity")
+	}
+
+	lg.Info("unshared entity", slog.Int64("id", in.Id))
+	return &passkeeperv1.UnshareEntityResponse{}, nil
+}
+
+// ListEntityShares returns the users the entity is shared with.
+func (s server) ListEntityShares(ctx context.Context, in *passkeeperv1.ListEntitySharesRequest) (*passkeeperv1.ListEntitySharesResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling list entity shares request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	shares, err := s.k.Shares(ctx, int(in.Id), uid, totype(in.Type))
+	if err != nil {
+		return nil, errorStatus(lg, err, in.Id, "failed to list entity shares")
+	}
+	resp := &passkeeperv1.ListEntitySharesResponse{Shares: make([]*passkeeperv1.Share, 0, len(shares))}
+	for _, sh := range shares {
+		resp.Shares = append(resp.Shares, shareToGRPC(sh))
+	}
+	return resp, nil
+}
+
+func shareToGRPC(sh *models.Share) *passkeeperv1.Share {
+	return &passkeeperv1.Share{
+		Email:      sh.Email,
+		Permission: fromPermission(sh.Permission),
+		CreatedAt:  timestamppb.New(sh.CreatedAt),
+	}
+}
+
+// fromPermission returns the grpc permission, READ for the own entities.
+func fromPermission(p models.Permission) passkeeperv1.Permission {
+	if p == models.PermissionWrite {
+		return passkeeperv1.Permission_WRITE
+	}
+	return passkeeperv1.Permission_READ
+}
@@ -0,0 +1,93 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestServer_ShareEntity(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Share", mock.Anything, &models.Share{Type: models.TypeText, EntityID: 7, OwnerID: 1,
+		Email: "friend@gmail.com", Permission: models.PermissionWrite}).
+		Return(&models.Share{Type: models.TypeText, EntityID: 7, OwnerID: 1, UserID: 2,
+			Email: "friend@gmail.com", Permission: models.PermissionWrite, CreatedAt: time.Now()}, nil).Once()
+	k.On("Share", mock.Anything, mock.MatchedBy(func(sh *models.Share) bool { return sh.Email == "me@gmail.com" })).
+		Return(nil, passkeeper.ErrShareWithOwner).Once()
+
+	s := server{k: k}
+	resp, err := s.ShareEntity(ctx, &passkeeperv1.ShareEntityRequest{
+		Id: 7, Type: passkeeperv1.Type_TEXT, Email: "friend@gmail.com", Permission: passkeeperv1.Permission_WRITE})
+	require.NoError(t, err)
+	assert.Equal(t, "friend@gmail.com", resp.Share.Email)
+	assert.Equal(t, passkeeperv1.Permission_WRITE, resp.Share.Permission)
+
+	_, err = s.ShareEntity(ctx, &passkeeperv1.ShareEntityRequest{
+		Id: 7, Type: passkeeperv1.Type_TEXT, Email: "me@gmail.com", Permission: passkeeperv1.Permission_READ})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ShareEntity(ctx, &passkeeperv1.ShareEntityRequest{
+		Id: 7, Type: passkeeperv1.Type_TEXT, Email: "friend@gmail.com", Permission: 5})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_UnshareEntity(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Unshare", mock.Anything, 7, 1, models.TypeText, "friend@gmail.com").Return(nil).Once()
+	k.On("Unshare", mock.Anything, 7, 1, models.TypeText, "friend@gmail.com").Return(passkeeper.ErrShareNotFound).Once()
+
+	s := server{k: k}
+	req := &passkeeperv1.UnshareEntityRequest{Id: 7, Type: passkeeperv1.Type_TEXT, Email: "friend@gmail.com"}
+	_, err := s.UnshareEntity(ctx, req)
+	require.NoError(t, err)
+	_, err = s.UnshareEntity(ctx, req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_ListEntityShares(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Shares", mock.Anything, 7, 1, models.TypeText).Return([]*models.Share{
+		{Email: "friend@gmail.com", Permission: models.PermissionRead, CreatedAt: time.Now()},
+	}, nil).Once()
+
+	s := server{k: k}
+	resp, err := s.ListEntityShares(ctx, &passkeeperv1.ListEntitySharesRequest{Id: 7, Type: passkeeperv1.Type_TEXT})
+	require.NoError(t, err)
+	require.Len(t, resp.Shares, 1)
+	assert.Equal(t, passkeeperv1.Permission_READ, resp.Shares[0].Permission)
+}
+
+func Test_dtoToGRPCShared(t *testing.T) {
+
+	e := dtoToGRPC(&models.Entity{ID: 7, Type: models.TypeText, Permission: models.PermissionWrite})
+	assert.True(t, e.Shared)
+	assert.Equal(t, passkeeperv1.Permission_WRITE, e.Permission)
+
+	e = dtoToGRPC(&models.Entity{ID: 7, Type: models.TypeText})
+	assert.False(t, e.Shared)
+}
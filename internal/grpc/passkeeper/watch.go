@@ -0,0 +1,115 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// Watch streams the entity changes as they happen.
+func (s server) Watch(in *passkeeperv1.WatchRequest, str passkeeperv1.PassKeeper_WatchServer) error {
+
+	lg := sl.Log
+	lg.Info("handling watch request")
+
+	ctx := str.Context()
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	sent := 0
+	err = s.k.Watch(ctx, uid, in.Cursor, func(changes []*models.Change, cursor int64) error {
+		resp := &passkeeperv1.WatchResponse{Changes: make([]*passkeeperv1.Change, 0, len(changes)), Cursor: cursor}
+		for _, c := range changes {
+			resp.Changes = append(resp.Changes, changeToGRPC(c))
+		}
+		sent += len(changes)
+		return str.Send(resp)
+	})
+	switch {
+	case ctx.Err() != nil:
+		lg.Info("watch canceled by client", slog.Int("sent", sent))
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, passkeeper.ErrResyncRequired):
+		lg.Info("watch cursor is pruned", sl.Err(err))
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()), &errdetails.ErrorInfo{
+			Reason: "RESYNC_REQUIRED",
+			Domain: errorDomain,
+		})
+	case errors.Is(err, passkeeper.ErrWatchStopped):
+		lg.Info("watch stopped", slog.Int("sent", sent))
+		return status.Errorf(codes.Unavailable, "server is shutting down, resume the watch")
+	case err != nil:
+		return errorStatus(lg, err, 0, "failed to watch entities")
+	}
+	return nil
+}
+
+// SyncSince returns the entities changed since the cursor.
+func (s server) SyncSince(ctx context.Context, in *passkeeperv1.SyncSinceRequest) (*passkeeperv1.SyncSinceResponse, error) {
+
+	lg := sl.Log
+	lg.Info("handling sync since request")
+
+	uid, err := grpcmd.ExtractUID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uid: %v", err)
+	}
+
+	res, err := s.k.SyncSince(ctx, uid, in.Cursor)
+	if err != nil {
+		return nil, errorStatus(lg, err, 0, "failed to sync entities")
+	}
+	resp := &passkeeperv1.SyncSinceResponse{
+		Changes:        make([]*passkeeperv1.Change, 0, len(res.Changes)),
+		Cursor:         res.Cursor,
+		HasMore:        res.HasMore,
+		ResyncRequired: res.ResyncRequired,
+	}
+	for _, c := range res.Changes {
+		resp.Changes = append(resp.Changes, changeToGRPC(c))
+	}
+	return resp, nil
+}
+
+func changeToGRPC(c *models.Change) *passkeeperv1.Change {
+
+	var kind passkeeperv1.ChangeKind
+	switch c.Kind {
+	case models.ChangeCreated:
+		kind = passkeeperv1.ChangeKind_CHANGE_CREATED
+	case models.ChangeUpdated:
+		kind = passkeeperv1.ChangeKind_CHANGE_UPDATED
+	case models.ChangeTrashed:
+		kind = passkeeperv1.ChangeKind_CHANGE_TRASHED
+	case models.ChangeRestored:
+		kind = passkeeperv1.ChangeKind_CHANGE_RESTORED
+	case models.ChangeDeleted:
+		kind = passkeeperv1.ChangeKind_CHANGE_DELETED
+	}
+
+	res := &passkeeperv1.Change{
+		Cursor:    c.Seq,
+		Kind:      kind,
+		Type:      fromtype(c.Type),
+		Id:        int64(c.ID),
+		Revision:  int64(c.Revision),
+		ChangedAt: timestamppb.New(c.ChangedAt),
+	}
+	if c.Entity != nil {
+		res.Entity = dtoToGRPC(c.Entity)
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package passkeepergrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/grpc/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*passkeeperv1.WatchResponse
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *passkeeperv1.WatchResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestServer_Watch(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("Watch", mock.Anything, 1, int64(3), mock.Anything).Run(func(args mock.Arguments) {
+		send := args.Get(3).(func([]*models.Change, int64) error)
+		require.NoError(t, send(nil, 3))
+		require.NoError(t, send([]*models.Change{
+			{Seq: 4, Kind: models.ChangeUpdated, Type: models.TypeCard, ID: 7, Revision: 2, Entity: &models.Entity{ID: 7, Type: models.TypeCard}},
+			{Seq: 5, Kind: models.ChangeDeleted, Type: models.TypeText, ID: 8, Revision: 1},
+		}, 5))
+	}).Return(passkeeper.ErrWatchStopped).Once()
+	k.On("Watch", mock.Anything, 1, int64(10), mock.Anything).Return(passkeeper.ErrInvalidCursor).Once()
+
+	s := server{k: k}
+	str := &watchStream{ctx: ctx}
+	err := s.Watch(&passkeeperv1.WatchRequest{Cursor: 3}, str)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	require.Len(t, str.sent, 2)
+	assert.Empty(t, str.sent[0].Changes)
+	assert.Equal(t, int64(3), str.sent[0].Cursor)
+	require.Len(t, str.sent[1].Changes, 2)
+	assert.Equal(t, int64(5), str.sent[1].Cursor)
+	assert.Equal(t, passkeeperv1.ChangeKind_CHANGE_UPDATED, str.sent[1].Changes[0].Kind)
+	assert.Equal(t, passkeeperv1.Type_CARD, str.sent[1].Changes[0].Type)
+	assert.Equal(t, int64(7), str.sent[1].Changes[0].Entity.Id)
+	assert.Equal(t, passkeeperv1.ChangeKind_CHANGE_DELETED, str.sent[1].Changes[1].Kind)
+	assert.Nil(t, str.sent[1].Changes[1].Entity)
+
+	err = s.Watch(&passkeeperv1.WatchRequest{Cursor: 10}, &watchStream{ctx: ctx})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_SyncSince(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uid", "1"))
+	k := mocks.NewKeeper(t)
+	k.On("SyncSince", mock.Anything, 1, int64(3)).Return(&models.SyncResult{
+		Changes: []*models.Change{{Seq: 5, Kind: models.ChangeCreated, Type: models.TypeText, ID: 8, Entity: &models.Entity{ID: 8, Type: models.TypeText}}},
+		Cursor:  5,
+		HasMore: true,
+	}, nil).Once()
+	k.On("SyncSince", mock.Anything, 1, int64(1)).Return(&models.SyncResult{Cursor: 5, ResyncRequired: true}, nil).Once()
+
+	s := server{k: k}
+	resp, err := s.SyncSince(ctx, &passkeeperv1.SyncSinceRequest{Cursor: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	assert.Equal(t, int64(8), resp.Changes[0].Id)
+	assert.Equal(t, int64(5), resp.Cursor)
+	assert.True(t, resp.HasMore)
+	assert.False(t, resp.ResyncRequired)
+
+	resp, err = s.SyncSince(ctx, &passkeeperv1.SyncSinceRequest{Cursor: 1})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+	assert.True(t, resp.ResyncRequired)
+
+	k.On("Watch", mock.Anything, 1, int64(1), mock.Anything).Return(passkeeper.ErrResyncRequired).Once()
+	err = s.Watch(&passkeeperv1.WatchRequest{Cursor: 1}, &watchStream{ctx: ctx})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -2,7 +2,10 @@ package interceptors
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -13,15 +16,29 @@ import (
 	"github.com/vindosVP/go-pass/pkg/logger/sl"
 )
 
+// Devices is an API to check that the token device is not removed
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Devices
+type Devices interface {
+	DeviceExists(ctx context.Context, id int, userID int) (bool, error)
+}
+
+// deviceCheckInterval is the interval the device of the open stream is checked at.
+const deviceCheckInterval = 30 * time.Second
+
+// errDeviceRemoved - error if the token device is removed
+var errDeviceRemoved = errors.New("device removed")
+
 type AuthInterceptor struct {
-	secret string
+	secret  string
+	devices Devices
 }
 
-func NewAuthInterceptor(secret string) *AuthInterceptor {
-	return &AuthInterceptor{secret: secret}
+func NewAuthInterceptor(secret string, devices Devices) *AuthInterceptor {
+	return &AuthInterceptor{secret: secret, devices: devices}
 }
 
-var openedMethods = []string{"/auth.Auth/Login", "/auth.Auth/Register"}
+var openedMethods = []string{"/auth.Auth/Challenge", "/auth.Auth/Login", "/auth.Auth/Register", "/passkeeper.PassKeeper/OpenSend"}
 
 type wrappedStream struct {
 	grpc.ServerStream
@@ -77,18 +94,16 @@ func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		}
 		token := t[0]
 
-		email, uid, err := jwt.VerifyToken(token, a.secret)
+		email, uid, did, err := jwt.VerifyToken(token, a.secret)
 		if err != nil {
 			lg.Info("invalid token")
 			return nil, status.Errorf(codes.Unauthenticated, "invalid token")
 		}
-
-		newMD := map[string]string{
-			"email": email,
-			"uid":   strconv.Itoa(uid),
+		if err := a.checkDevice(ctx, did, uid); err != nil {
+			return nil, err
 		}
 
-		return handler(metadata.NewIncomingContext(ctx, metadata.New(newMD)), req)
+		return handler(metadata.NewIncomingContext(ctx, tokenMD(email, uid, did)), req)
 	}
 }
 
@@ -120,18 +135,70 @@ func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 		}
 		token := t[0]
 
-		email, uid, err := jwt.VerifyToken(token, a.secret)
+		email, uid, did, err := jwt.VerifyToken(token, a.secret)
 		if err != nil {
 			lg.Info("invalid token")
 			return status.Errorf(codes.Unauthenticated, "invalid token")
 		}
+		if err := a.checkDevice(stream.Context(), did, uid); err != nil {
+			return err
+		}
+
+		// the stream is canceled once the device is removed, so the removed device stops syncing
+		ctx, cancel := context.WithCancelCause(stream.Context())
+		defer cancel(nil)
+		go a.watchDevice(ctx, cancel, did, uid)
+		newCtx := metadata.NewIncomingContext(ctx, tokenMD(email, uid, did))
 
-		newMD := map[string]string{
-			"email": email,
-			"uid":   strconv.Itoa(uid),
+		err = handler(srv, &wrappedStream{stream, newCtx})
+		if errors.Is(context.Cause(ctx), errDeviceRemoved) {
+			lg.Info("stream of removed device closed", slog.Int("device", did))
+			return status.Errorf(codes.Unauthenticated, "device removed")
 		}
-		newCtx := metadata.NewIncomingContext(stream.Context(), metadata.New(newMD))
+		return err
+	}
+}
+
+func tokenMD(email string, uid int, did int) metadata.MD {
+	return metadata.New(map[string]string{
+		"email": email,
+		"uid":   strconv.Itoa(uid),
+		"did":   strconv.Itoa(did),
+	})
+}
+
+// checkDevice returns the Unauthenticated error if the token device is removed.
+func (a *AuthInterceptor) checkDevice(ctx context.Context, did int, uid int) error {
+	ok, err := a.devices.DeviceExists(ctx, did, uid)
+	if err != nil {
+		sl.Log.Error("failed to check device", sl.Err(err))
+		return status.Errorf(codes.Internal, "failed to check device")
+	}
+	if !ok {
+		sl.Log.Info("device removed", slog.Int("device", did))
+		return status.Errorf(codes.Unauthenticated, "device removed")
+	}
+	return nil
+}
 
-		return handler(srv, &wrappedStream{stream, newCtx})
+// watchDevice cancels the stream context once the device is removed.
+func (a *AuthInterceptor) watchDevice(ctx context.Context, cancel context.CancelCauseFunc, did int, uid int) {
+	t := time.NewTicker(deviceCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		ok, err := a.devices.DeviceExists(ctx, did, uid)
+		if err != nil {
+			sl.Log.Error("failed to check device", sl.Err(err))
+			continue
+		}
+		if !ok {
+			cancel(errDeviceRemoved)
+			return
+		}
 	}
 }
@@ -0,0 +1,97 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vindosVP/go-pass/internal/interceptors/mocks"
+	"github.com/vindosVP/go-pass/internal/jwt"
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/pkg/grpcmd"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const secret = "supersecret"
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+
+	token := func(did int) string {
+		tok, err := jwt.NewToken(&models.User{ID: 1, Email: "test@example.com"}, did, secret)
+		require.NoError(t, err)
+		return tok
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		exists   bool
+		checkErr error
+		wantCode codes.Code
+	}{
+		{
+			name:     "registered device",
+			token:    token(3),
+			exists:   true,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "removed device",
+			token:    token(3),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "check failed",
+			token:    token(3),
+			checkErr: errors.New("unexpected"),
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "invalid token",
+			token:    "invalid",
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := mocks.NewDevices(t)
+			if tt.token != "invalid" {
+				d.On("DeviceExists", mock.Anything, 3, 1).Return(tt.exists, tt.checkErr).Once()
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("token", tt.token))
+			info := &grpc.UnaryServerInfo{FullMethod: "/passkeeper.PassKeeper/GetEntity"}
+			_, err := NewAuthInterceptor(secret, d).Unary()(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+				did, err := grpcmd.ExtractDeviceID(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, 3, did)
+				return nil, nil
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestAuthInterceptor_UnaryOpened(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	d := mocks.NewDevices(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/passkeeper.PassKeeper/OpenSend"}
+	called := false
+	_, err := NewAuthInterceptor(secret, d).Unary()(context.Background(), nil, info, func(_ context.Context, _ any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
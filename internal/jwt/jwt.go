@@ -9,13 +9,14 @@ import (
 	"github.com/vindosVP/go-pass/internal/models"
 )
 
-// NewToken creates new JWT token for given user.
-func NewToken(user *models.User, secret string) (string, error) {
+// NewToken creates new JWT token for given user bound to the user`s device.
+func NewToken(user *models.User, deviceID int, secret string) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 
 	claims := token.Claims.(jwt.MapClaims)
 	claims["uid"] = user.ID
 	claims["email"] = user.Email
+	claims["did"] = deviceID
 
 	tokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
@@ -25,8 +26,8 @@ func NewToken(user *models.User, secret string) (string, error) {
 	return tokenString, nil
 }
 
-// VerifyToken verifies users token.
-func VerifyToken(tokenString string, secret string) (string, int, error) {
+// VerifyToken verifies users token and returns the user email, id and the device id.
+func VerifyToken(tokenString string, secret string) (string, int, int, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -34,19 +35,23 @@ func VerifyToken(tokenString string, secret string) (string, int, error) {
 		return []byte(secret), nil
 	})
 	if err != nil {
-		return "", 0, fmt.Errorf("invalid token: %w", err)
+		return "", 0, 0, fmt.Errorf("invalid token: %w", err)
 	}
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return "", 0, fmt.Errorf("invalid token: %w", err)
+		return "", 0, 0, fmt.Errorf("invalid token: %w", err)
 	}
 	email, ok := claims["email"].(string)
 	if !ok {
-		return "", 0, fmt.Errorf("failed to extract email from token")
+		return "", 0, 0, fmt.Errorf("failed to extract email from token")
 	}
 	uid, ok := claims["uid"].(float64)
 	if !ok {
-		return "", 0, fmt.Errorf("failed to extract uid from token")
+		return "", 0, 0, fmt.Errorf("failed to extract uid from token")
 	}
-	return email, int(uid), nil
+	did, ok := claims["did"].(float64)
+	if !ok {
+		return "", 0, 0, fmt.Errorf("failed to extract device id from token")
+	}
+	return email, int(uid), int(did), nil
 }
@@ -0,0 +1,229 @@
+// Package labels parses and validates the entity labels and the label selectors.
+//
+// Labels are key/value pairs such as env=prod. Selectors follow the Kubernetes syntax:
+// comma separated requirements which all must match, e.g. "env in (prod,staging),!deprecated".
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidLabels - error if label key or value is malformed
+	ErrInvalidLabels = errors.New("invalid labels")
+
+	// ErrInvalidSelector - error if label selector can not be parsed
+	ErrInvalidSelector = errors.New("invalid label selector")
+)
+
+const (
+	maxNameLength   = 63
+	maxPrefixLength = 253
+	maxValueLength  = 63
+	maxLabels       = 64
+)
+
+var (
+	nameRe   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+	setRe    = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Operator is the selector requirement operator.
+type Operator string
+
+const (
+	Equals       = Operator("=")
+	NotEquals    = Operator("!=")
+	In           = Operator("in")
+	NotIn        = Operator("notin")
+	Exists       = Operator("exists")
+	DoesNotExist = Operator("!")
+)
+
+// Requirement is the single selector condition on the label key.
+// Equals and NotEquals have one value, Exists and DoesNotExist have none.
+type Requirement struct {
+	Key    string
+	Op     Operator
+	Values []string
+}
+
+// Selector is the list of requirements all labels must match, the empty selector matches everything.
+type Selector []Requirement
+
+// Matches reports whether the labels match all selector requirements.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		v, ok := labels[r.Key]
+		switch r.Op {
+		case Equals:
+			if !ok || v != r.Values[0] {
+				return false
+			}
+		case NotEquals:
+			if ok && v == r.Values[0] {
+				return false
+			}
+		case In:
+			if !ok || !contains(r.Values, v) {
+				return false
+			}
+		case NotIn:
+			if ok && contains(r.Values, v) {
+				return false
+			}
+		case Exists:
+			if !ok {
+				return false
+			}
+		case DoesNotExist:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Parse parses the label selector, the empty selector matches everything.
+//
+// Supported requirements are "key=value" (or "key==value"), "key!=value", "key in (v1,v2)",
+// "key notin (v1,v2)", "key" and "!key". Like in Kubernetes != and notin match the labels without the key.
+func Parse(selector string) (Selector, error) {
+	parts, err := split(selector)
+	if err != nil {
+		return nil, err
+	}
+	s := make(Selector, 0, len(parts))
+	for _, p := range parts {
+		r, err := parseRequirement(p)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+// split splits the selector by the commas outside of the parentheses.
+func split(selector string) ([]string, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	parts := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(selector[start:i]))
+				start = i + 1
+			}
+		}
+		if depth < 0 || depth > 1 {
+			return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSelector)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSelector)
+	}
+	return append(parts, strings.TrimSpace(selector[start:])), nil
+}
+
+func parseRequirement(p string) (Requirement, error) {
+	if m := setRe.FindStringSubmatch(p); m != nil {
+		r := Requirement{Key: m[1], Op: Operator(m[2])}
+		for _, v := range strings.Split(m[3], ",") {
+			r.Values = append(r.Values, strings.TrimSpace(v))
+		}
+		return r, r.validate(p)
+	}
+	for _, op := range []Operator{NotEquals, "==", Equals} {
+		if k, v, ok := strings.Cut(p, string(op)); ok {
+			if op == "==" {
+				op = Equals
+			}
+			r := Requirement{Key: strings.TrimSpace(k), Op: op, Values: []string{strings.TrimSpace(v)}}
+			return r, r.validate(p)
+		}
+	}
+	if strings.HasPrefix(p, "!") {
+		r := Requirement{Key: strings.TrimSpace(p[1:]), Op: DoesNotExist}
+		return r, r.validate(p)
+	}
+	r := Requirement{Key: p, Op: Exists}
+	return r, r.validate(p)
+}
+
+func (r Requirement) validate(p string) error {
+	if err := validateKey(r.Key); err != nil {
+		return fmt.Errorf("%w: %q: %v", ErrInvalidSelector, p, err)
+	}
+	for _, v := range r.Values {
+		if err := validateValue(v); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrInvalidSelector, p, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the label keys and values.
+//
+// The key is the name with the optional DNS subdomain prefix ("example.com/env"),
+// the name and the value are at most 63 characters long, consist of alphanumerics, '-', '_' and '.'
+// and start and end with an alphanumeric character. The value may be empty.
+func Validate(labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("%w: at most %d labels are allowed", ErrInvalidLabels, maxLabels)
+	}
+	for k, v := range labels {
+		if err := validateKey(k); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidLabels, err)
+		}
+		if err := validateValue(v); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrInvalidLabels, k, err)
+		}
+	}
+	return nil
+}
+
+func validateKey(k string) error {
+	name := k
+	if prefix, n, ok := strings.Cut(k, "/"); ok {
+		if len(prefix) > maxPrefixLength || !prefixRe.MatchString(prefix) {
+			return fmt.Errorf("key %q has invalid prefix", k)
+		}
+		name = n
+	}
+	if len(name) > maxNameLength || !nameRe.MatchString(name) {
+		return fmt.Errorf("key %q has invalid name", k)
+	}
+	return nil
+}
+
+func validateValue(v string) error {
+	if v == "" {
+		return nil
+	}
+	if len(v) > maxValueLength || !nameRe.MatchString(v) {
+		return fmt.Errorf("invalid value %q", v)
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
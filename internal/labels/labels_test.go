@@ -0,0 +1,124 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		selector string
+		want     Selector
+		err      bool
+	}{
+		{
+			name:     "empty",
+			selector: " ",
+			want:     Selector{},
+		},
+		{
+			name:     "equality",
+			selector: "env=prod, team==payments,tier != db",
+			want: Selector{
+				{Key: "env", Op: Equals, Values: []string{"prod"}},
+				{Key: "team", Op: Equals, Values: []string{"payments"}},
+				{Key: "tier", Op: NotEquals, Values: []string{"db"}},
+			},
+		},
+		{
+			name:     "sets and existence",
+			selector: "env in (prod, staging),example.com/region notin (eu),owner,!deprecated",
+			want: Selector{
+				{Key: "env", Op: In, Values: []string{"prod", "staging"}},
+				{Key: "example.com/region", Op: NotIn, Values: []string{"eu"}},
+				{Key: "owner", Op: Exists},
+				{Key: "deprecated", Op: DoesNotExist},
+			},
+		},
+		{
+			name:     "empty value",
+			selector: "env=",
+			want:     Selector{{Key: "env", Op: Equals, Values: []string{""}}},
+		},
+		{
+			name:     "unbalanced parentheses",
+			selector: "env in (prod",
+			err:      true,
+		},
+		{
+			name:     "invalid key",
+			selector: "-env=prod",
+			err:      true,
+		},
+		{
+			name:     "invalid value",
+			selector: "env in (prod,st@ging)",
+			err:      true,
+		},
+		{
+			name:     "empty requirement",
+			selector: "env=prod,,team=payments",
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.selector)
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInvalidSelector)
+				return
+			}
+			require.NoError(t, err)
+			if len(tt.want) == 0 {
+				assert.Empty(t, s)
+				return
+			}
+			assert.Equal(t, tt.want, s)
+		})
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+
+	labels := map[string]string{"env": "prod", "team": "payments"}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"env=prod,team=payments", true},
+		{"env=staging", false},
+		{"env!=staging", true},
+		{"region!=eu", true},
+		{"env in (prod,staging)", true},
+		{"env notin (prod)", false},
+		{"region notin (eu)", true},
+		{"region in (eu)", false},
+		{"team", true},
+		{"!deprecated", true},
+		{"!team", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			s, err := Parse(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Matches(labels))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	assert.NoError(t, Validate(map[string]string{"env": "prod", "example.com/team": "", "a.b_c-d": "v.1"}))
+	assert.ErrorIs(t, Validate(map[string]string{"env prod": "x"}), ErrInvalidLabels)
+	assert.ErrorIs(t, Validate(map[string]string{"Example.com/env": "x"}), ErrInvalidLabels)
+	assert.ErrorIs(t, Validate(map[string]string{"env": "prod!"}), ErrInvalidLabels)
+	assert.ErrorIs(t, Validate(map[string]string{"": "x"}), ErrInvalidLabels)
+}
@@ -2,7 +2,10 @@
 package models
 
 import (
+	"log/slog"
 	"time"
+
+	"github.com/vindosVP/go-pass/internal/labels"
 )
 
 // User represents the application user.
@@ -13,91 +16,345 @@ type User struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
-// Password represents the login-password pair.
-type Password struct {
-	ID        int       `json:"id" db:"id"`
-	OwnerID   int       `json:"owner_id" db:"owner_id"`
-	Login     string    `json:"login" db:"login"`
-	Password  string    `json:"password" db:"password"`
-	Metadata  string    `json:"metadata" db:"metadata"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+// Device represents the user`s client install, the user`s sessions are bound to the devices.
+type Device struct {
+	ID          int
+	UserID      int
+	Name        string
+	PublicKey   []byte // the device ed25519 public key
+	CreatedAt   time.Time
+	LastLoginAt time.Time
 }
 
-// ToEntity transforms password model to dto entity.
-func (p *Password) ToEntity() *Entity {
-	return &Entity{
-		ID:       p.ID,
-		OwnerID:  p.OwnerID,
-		Type:     TypePassword,
-		Login:    p.Login,
-		Password: p.Password,
-		Metadata: p.Metadata,
-	}
+// DeviceProof is the device signature over the login challenge nonce, it proves the device owns its key.
+type DeviceProof struct {
+	Nonce     []byte
+	Signature []byte
 }
 
-// Card represents the bank card.
-type Card struct {
-	ID        int       `json:"id" db:"id"`
-	OwnerID   int       `json:"owner_id" db:"owner_id"`
-	Number    string    `json:"number" db:"number"`
-	CVC       string    `json:"cvc" db:"cvc"`
-	Owner     string    `json:"owner" db:"owner"`
-	Date      string    `json:"date" db:"date"`
-	Metadata  string    `json:"metadata" db:"metadata"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+// Version represents the archived prior entity version.
+// The entity keeps the revision and the update time the version had.
+type Version struct {
+	Entity     *Entity
+	ArchivedAt time.Time
+}
+
+// Folder represents the user`s folder, the folders form a tree by the parent id.
+type Folder struct {
+	ID        int
+	OwnerID   int
+	ParentID  int // 0 for the top level folder
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Tag represents the user`s tag, the tag name is unique for the user.
+type Tag struct {
+	ID        int
+	OwnerID   int
+	Name      string
+	CreatedAt time.Time
 }
 
-// ToEntity transforms card model to dto entity.
-func (c *Card) ToEntity() *Entity {
-	return &Entity{
-		ID:         c.ID,
-		OwnerID:    c.OwnerID,
-		Type:       TypeCard,
-		CardNumber: c.Number,
-		CardOwner:  c.Owner,
-		CardCVC:    c.CVC,
-		CardExp:    c.Date,
-		Metadata:   c.Metadata,
+// Role is the organization member role.
+type Role string
+
+const (
+	// RoleOwner manages the organization, its vaults and all members
+	RoleOwner Role = "OWNER"
+	// RoleAdmin manages the vaults, the editors and the viewers
+	RoleAdmin Role = "ADMIN"
+	// RoleEditor adds, updates and deletes the vault entities
+	RoleEditor Role = "EDITOR"
+	// RoleViewer reads the vault entities
+	RoleViewer Role = "VIEWER"
+)
+
+// roleRanks orders the roles, the higher role has all permissions of the lower ones.
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleAdmin: 3, RoleOwner: 4}
+
+// Known reports whether r is one of the roles.
+func (r Role) Known() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast reports whether r has all permissions of the role.
+func (r Role) AtLeast(role Role) bool {
+	return r.Known() && roleRanks[r] >= roleRanks[role]
+}
+
+// ManagedBy returns the lowest role managing the members of r: the admins manage the editors
+// and the viewers, the owners manage all members.
+func (r Role) ManagedBy() Role {
+	if r.AtLeast(RoleAdmin) {
+		return RoleOwner
 	}
+	return RoleAdmin
 }
 
-// Text represents the text information.
-type Text struct {
-	ID        int       `json:"id" db:"id"`
-	OwnerID   int       `json:"owner_id" db:"owner_id"`
-	Text      string    `json:"text" db:"text"`
-	Metadata  string    `json:"metadata" db:"metadata"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+// Org represents the organization, the group of users sharing the vaults.
+type Org struct {
+	ID        int
+	Name      string
+	Role      Role // the role of the user the organization is listed for
+	CreatedAt time.Time
+}
+
+// Member represents the organization member.
+type Member struct {
+	OrgID     int
+	UserID    int
+	Email     string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Vault represents the organization vault, the entities in the vault belong to the organization
+// and are accessed by its members according to their roles.
+type Vault struct {
+	ID            int
+	OrgID         int
+	Name          string
+	KeyVersion    int  // the version of the current vault key, 0 if the key is not distributed yet
+	RekeyRequired bool // set when a member is removed until the vault key is rotated
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
-// ToEntity transforms text model to dto entity.
-func (t *Text) ToEntity() *Entity {
-	return &Entity{
-		ID:      t.ID,
-		OwnerID: t.OwnerID,
-		Type:    TypeText,
-		Text:    t.Text,
+// UserKeys represents the user`s key pair, the private key is wrapped by the user`s master key on the client
+// and is never seen by the server in plain.
+type UserKeys struct {
+	UserID            int
+	Email             string
+	PublicKey         []byte // the X25519 public key
+	WrappedPrivateKey []byte
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// VaultKey represents the vault key version encrypted to the member`s public key.
+type VaultKey struct {
+	VaultID      int
+	UserID       int
+	Email        string
+	Version      int
+	EncryptedKey []byte
+	CreatedAt    time.Time
+}
+
+// SendKind is the kind of the one-time send payload.
+type SendKind string
+
+const (
+	SendText SendKind = "TEXT"
+	SendFile SendKind = "FILE"
+)
+
+// Known reports whether k is one of the send kinds.
+func (k SendKind) Known() bool {
+	return k == SendText || k == SendFile
+}
+
+// Send represents the one-time payload encrypted on the client, it is redeemed by its token without
+// an account and is destroyed after the last view or on expiry.
+type Send struct {
+	ID           int
+	OwnerID      int
+	Kind         SendKind
+	Filename     string // the file name of the file payload
+	Payload      []byte // set only when the send is opened
+	PasswordHash []byte // the access password bcrypt hash, empty if the send is not protected
+	MaxViews     int
+	Views        int
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// EmergencyStatus is the status of the emergency access the grantor gives to the trusted contact.
+type EmergencyStatus string
+
+const (
+	// EmergencyInvited - the grantor has invited the contact
+	EmergencyInvited EmergencyStatus = "INVITED"
+	// EmergencyAccepted - the contact has accepted the invitation and may request the access
+	EmergencyAccepted EmergencyStatus = "ACCEPTED"
+	// EmergencyRequested - the contact has requested the access, it is granted after the wait period
+	EmergencyRequested EmergencyStatus = "REQUESTED"
+	// EmergencyGranted - the contact reads the grantor`s personal entities
+	EmergencyGranted EmergencyStatus = "GRANTED"
+	// EmergencyRevoked - the emergency access has been revoked, the grantor may invite the contact again
+	EmergencyRevoked EmergencyStatus = "REVOKED"
+)
+
+// EmergencyAction is the emergency access state machine transition.
+type EmergencyAction string
+
+const (
+	EmergencyInvite  EmergencyAction = "INVITE"
+	EmergencyAccept  EmergencyAction = "ACCEPT"
+	EmergencyRequest EmergencyAction = "REQUEST"
+	EmergencyApprove EmergencyAction = "APPROVE"
+	EmergencyReject  EmergencyAction = "REJECT"
+	EmergencyGrant   EmergencyAction = "GRANT"
+	EmergencyRevoke  EmergencyAction = "REVOKE"
+)
+
+// EmergencyActor is the party performing the emergency access action.
+type EmergencyActor int
+
+const (
+	ActorGrantor EmergencyActor = iota + 1
+	ActorGrantee
+	// ActorSystem grants the requested access after the wait period
+	ActorSystem
+)
+
+// emergencyTransition is the action allowed to the actors in the from statuses, "" is the status of the new contact.
+type emergencyTransition struct {
+	from   []EmergencyStatus
+	to     EmergencyStatus
+	actors []EmergencyActor
+}
+
+var emergencyTransitions = map[EmergencyAction]emergencyTransition{
+	EmergencyInvite:  {from: []EmergencyStatus{"", EmergencyRevoked}, to: EmergencyInvited, actors: []EmergencyActor{ActorGrantor}},
+	EmergencyAccept:  {from: []EmergencyStatus{EmergencyInvited}, to: EmergencyAccepted, actors: []EmergencyActor{ActorGrantee}},
+	EmergencyRequest: {from: []EmergencyStatus{EmergencyAccepted}, to: EmergencyRequested, actors: []EmergencyActor{ActorGrantee}},
+	EmergencyApprove: {from: []EmergencyStatus{EmergencyRequested}, to: EmergencyGranted, actors: []EmergencyActor{ActorGrantor}},
+	EmergencyReject:  {from: []EmergencyStatus{EmergencyRequested}, to: EmergencyAccepted, actors: []EmergencyActor{ActorGrantor}},
+	EmergencyGrant:   {from: []EmergencyStatus{EmergencyRequested}, to: EmergencyGranted, actors: []EmergencyActor{ActorSystem}},
+	EmergencyRevoke: {
+		from:   []EmergencyStatus{EmergencyInvited, EmergencyAccepted, EmergencyRequested, EmergencyGranted},
+		to:     EmergencyRevoked,
+		actors: []EmergencyActor{ActorGrantor, ActorGrantee},
+	},
+}
+
+// Known reports whether a is one of the emergency access actions.
+func (a EmergencyAction) Known() bool {
+	_, ok := emergencyTransitions[a]
+	return ok
+}
+
+// AllowedTo reports whether the actor performs the action.
+func (a EmergencyAction) AllowedTo(actor EmergencyActor) bool {
+	for _, act := range emergencyTransitions[a].actors {
+		if act == actor {
+			return true
+		}
 	}
+	return false
 }
 
-// File represents the text uploaded file.
-type File struct {
-	ID        int       `json:"id" db:"id"`
-	OwnerID   int       `json:"owner_id" db:"owner_id"`
-	FileName  string    `json:"filename" db:"filename"`
-	Metadata  string    `json:"metadata" db:"metadata"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+// Next returns the status the action moves s to, false if the action is not allowed in s.
+func (s EmergencyStatus) Next(a EmergencyAction) (EmergencyStatus, bool) {
+	t, ok := emergencyTransitions[a]
+	if !ok {
+		return "", false
+	}
+	for _, from := range t.from {
+		if from == s {
+			return t.to, true
+		}
+	}
+	return "", false
+}
+
+// EmergencyContact represents the trusted contact the grantor gives the emergency access to.
+type EmergencyContact struct {
+	ID           int
+	GrantorID    int
+	GrantorEmail string
+	GranteeID    int
+	GranteeEmail string
+	Status       EmergencyStatus
+	WaitPeriod   time.Duration // the access is granted the wait period after the request unless rejected
+	RequestedAt  time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
-// ToEntity transforms file model to dto entity.
-func (f *File) ToEntity() *Entity {
-	return &Entity{
-		ID:       f.ID,
-		OwnerID:  f.OwnerID,
-		Type:     TypeFile,
-		Metadata: f.Metadata,
-		Filename: f.FileName,
+// Actor returns the party the user is in the emergency access, 0 if the user is neither.
+func (c *EmergencyContact) Actor(userID int) EmergencyActor {
+	switch userID {
+	case c.GrantorID:
+		return ActorGrantor
+	case c.GranteeID:
+		return ActorGrantee
 	}
+	return 0
+}
+
+// EmergencyEvent represents the audited emergency access transition.
+type EmergencyEvent struct {
+	ID        int
+	ContactID int
+	ActorID   int // 0 for the system transitions
+	Action    EmergencyAction
+	From      EmergencyStatus
+	To        EmergencyStatus
+	CreatedAt time.Time
+}
+
+// AccessStatus is the status of the request to read the entity requiring approval.
+type AccessStatus string
+
+const (
+	// AccessPending - the request waits for the approver`s decision
+	AccessPending AccessStatus = "PENDING"
+	// AccessApproved - the requester reads the entity until the request expires
+	AccessApproved AccessStatus = "APPROVED"
+	// AccessDenied - the approver has denied the request
+	AccessDenied AccessStatus = "DENIED"
+)
+
+// AccessRequest represents the request to read the entity requiring approval.
+type AccessRequest struct {
+	ID             int
+	Type           EntityType
+	EntityID       int
+	RequesterID    int
+	RequesterEmail string
+	Reason         string
+	Status         AccessStatus
+	ApproverID     int       // 0 until the request is decided
+	ExpiresAt      time.Time // the end of the view window of the approved request
+	CreatedAt      time.Time
+	DecidedAt      time.Time
+}
+
+// AccessAction is the audited action on the entity requiring approval.
+type AccessAction string
+
+const (
+	AccessRequested AccessAction = "REQUEST"
+	AccessGranted   AccessAction = "APPROVE"
+	AccessRejected  AccessAction = "DENY"
+	AccessViewed    AccessAction = "VIEW"
+	AccessRequired  AccessAction = "REQUIRE" // the approval of the reads is turned on
+	AccessWaived    AccessAction = "WAIVE"   // the approval of the reads is turned off
+)
+
+// AccessEvent represents the audited request, decision or view of the entity requiring approval.
+type AccessEvent struct {
+	ID        int
+	Type      EntityType
+	EntityID  int
+	RequestID int // 0 for the views of the approvers
+	ActorID   int
+	Action    AccessAction
+	CreatedAt time.Time
+}
+
+// JobRun represents the background job run.
+type JobRun struct {
+	Name        string    `json:"name" db:"name"`
+	ScheduledAt time.Time `json:"scheduled_at" db:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at" db:"started_at"`
+	FinishedAt  time.Time `json:"finished_at" db:"finished_at"`
+	Error       string    `json:"error" db:"error"`
 }
 
 // EntityType is the Entity type.
@@ -110,6 +367,251 @@ const (
 	TypeFile     = EntityType("FILE")
 )
 
+// Known reports whether t is one of the entity types.
+func (t EntityType) Known() bool {
+	switch t {
+	case TypePassword, TypeCard, TypeText, TypeFile:
+		return true
+	}
+	return false
+}
+
+// EntityField is the Entity field name used in the update masks.
+type EntityField string
+
+const (
+	FieldLogin      = EntityField("login")
+	FieldPassword   = EntityField("password")
+	FieldCardNumber = EntityField("cardNumber")
+	FieldCardOwner  = EntityField("cardOwner")
+	FieldCardCVC    = EntityField("cardCVC")
+	FieldCardExp    = EntityField("cardExp")
+	FieldText       = EntityField("text")
+	FieldFilename   = EntityField("filename")
+	FieldMetadata   = EntityField("metadata")
+	FieldFolder     = EntityField("folder_id")
+	FieldTags       = EntityField("tag_ids")
+	FieldLabels     = EntityField("labels")
+	FieldCustom     = EntityField("custom_fields")
+)
+
+// UpdatableFields lists the fields which can be updated for each entity type.
+var UpdatableFields = map[EntityType][]EntityField{
+	TypePassword: {FieldLogin, FieldPassword, FieldCustom, FieldMetadata},
+	TypeCard:     {FieldCardNumber, FieldCardOwner, FieldCardCVC, FieldCardExp, FieldCustom, FieldMetadata},
+	TypeText:     {FieldText, FieldCustom, FieldMetadata},
+}
+
+// OrganizeFields lists the fields placing the entity of any type into the folder, tagging and labeling it.
+// They are updated only if listed in the update mask explicitly.
+var OrganizeFields = []EntityField{FieldFolder, FieldTags, FieldLabels}
+
+// PayloadFields lists the typed payload fields of each entity type, the common fields are not listed.
+var PayloadFields = map[EntityType][]EntityField{
+	TypePassword: {FieldLogin, FieldPassword, FieldCustom},
+	TypeCard:     {FieldCardNumber, FieldCardOwner, FieldCardCVC, FieldCardExp, FieldCustom},
+	TypeText:     {FieldText, FieldCustom},
+	TypeFile:     {FieldFilename, FieldCustom},
+}
+
+// CustomFieldType is the custom field value type.
+type CustomFieldType string
+
+const (
+	CustomText   = CustomFieldType("TEXT")
+	CustomHidden = CustomFieldType("HIDDEN")
+	CustomURL    = CustomFieldType("URL")
+	CustomEmail  = CustomFieldType("EMAIL")
+	CustomDate   = CustomFieldType("DATE")
+	CustomTOTP   = CustomFieldType("TOTP")
+)
+
+// Known reports whether the custom field type is supported.
+func (t CustomFieldType) Known() bool {
+	switch t {
+	case CustomText, CustomHidden, CustomURL, CustomEmail, CustomDate, CustomTOTP:
+		return true
+	}
+	return false
+}
+
+// Secret reports whether the values of the type are secrets, which must not be logged.
+func (t CustomFieldType) Secret() bool {
+	return t == CustomHidden || t == CustomTOTP
+}
+
+// CustomField is the user defined entity field, e.g. a security question or a PIN.
+type CustomField struct {
+	Name  string          `json:"name"`
+	Type  CustomFieldType `json:"type"`
+	Value string          `json:"value"`
+}
+
+// LogValue implements slog.LogValuer, the secret values are redacted.
+func (f CustomField) LogValue() slog.Value {
+	value := f.Value
+	if f.Type.Secret() {
+		value = "[REDACTED]"
+	}
+	return slog.GroupValue(
+		slog.String("name", f.Name),
+		slog.String("type", string(f.Type)),
+		slog.String("value", value),
+	)
+}
+
+// SortField is the entities list sort field.
+type SortField string
+
+const (
+	SortByCreatedAt = SortField("created_at")
+	SortByUpdatedAt = SortField("updated_at")
+	SortByDeletedAt = SortField("deleted_at")
+)
+
+// Cursor represents the position in the sorted entities list.
+type Cursor struct {
+	Time time.Time  `json:"t"`
+	Type EntityType `json:"e"`
+	ID   int        `json:"i"`
+}
+
+// ListOptions consists of the entities list filters, sorting and pagination options.
+type ListOptions struct {
+	Types       []EntityType
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+	Metadata    string
+	SortBy      SortField
+	Desc        bool
+	PageSize    int
+	PageToken   string
+	After       *Cursor
+	Trashed     bool
+	FolderID    int   // the folder subtree to list, all folders if 0
+	TagIDs      []int // the tags every listed entity has
+	Labels      labels.Selector
+	VaultID     int // the vault to list, all accessible entities if 0
+}
+
+// ChangeKind is the kind of the entity change.
+type ChangeKind string
+
+const (
+	ChangeCreated  = ChangeKind("CREATED")
+	ChangeUpdated  = ChangeKind("UPDATED")
+	ChangeTrashed  = ChangeKind("TRASHED")
+	ChangeRestored = ChangeKind("RESTORED")
+	ChangeDeleted  = ChangeKind("DELETED") // the entity is purged
+)
+
+// Change is the entry of the user`s change log.
+type Change struct {
+	Seq       int64 // the position in the user`s change log, increases with every change
+	Kind      ChangeKind
+	Type      EntityType
+	ID        int
+	Revision  int // the entity revision after the change
+	ChangedAt time.Time
+	Entity    *Entity // the current entity, nil if the entity has been purged
+}
+
+// ChangeLog is the state of the user`s change log.
+type ChangeLog struct {
+	Last   int64 // the position of the last change, 0 if there are no changes
+	Pruned int64 // the position of the last pruned change, the changes up to it are not kept
+}
+
+// SyncResult is the page of the user`s changes since the cursor.
+type SyncResult struct {
+	Changes        []*Change // the last change of every changed entity in the change log order
+	Cursor         int64     // the cursor to sync from next time
+	HasMore        bool      // the sync has to be continued from the cursor
+	ResyncRequired bool      // the changes since the cursor are not kept, all entities have to be listed
+}
+
+// Conflict is the offline edit of the entity conflicting with the changes made since the revision it is based on.
+type Conflict struct {
+	ID                int
+	OwnerID           int
+	Type              EntityType
+	EntityID          int
+	BaseRevision      int           // the revision the client edit is based on
+	ServerRevision    int           // the entity revision when the conflict was detected
+	Fields            []EntityField // the update mask of the client edit, all fields if empty
+	ConflictingFields []EntityField // the fields changed by both the client and the server
+	Client            *Entity       // the client edit
+	Server            *Entity       // the current entity
+	CreatedAt         time.Time
+}
+
+// Resolution is the way the conflict is resolved.
+type Resolution string
+
+const (
+	// KeepServer drops the client edit
+	KeepServer Resolution = "KEEP_SERVER"
+	// KeepClient applies the client edit over the server changes
+	KeepClient Resolution = "KEEP_CLIENT"
+	// Merge applies the fields merged by the client
+	Merge Resolution = "MERGE"
+)
+
+// Permission is the access to the entity shared with another user.
+type Permission string
+
+const (
+	// PermissionRead allows reading the entity
+	PermissionRead Permission = "READ"
+	// PermissionWrite allows reading the entity and updating its content
+	PermissionWrite Permission = "WRITE"
+)
+
+// Known reports whether p is one of the permissions.
+func (p Permission) Known() bool {
+	return p == PermissionRead || p == PermissionWrite
+}
+
+// Share is the access to the owner`s entity granted to another user.
+type Share struct {
+	Type       EntityType
+	EntityID   int
+	OwnerID    int
+	UserID     int
+	Email      string // the email of the user the entity is shared with
+	Permission Permission
+	CreatedAt  time.Time
+}
+
+// SearchOptions are the entity search options.
+type SearchOptions struct {
+	Query string
+	Types []EntityType
+	Limit int
+}
+
+// SearchResult is the entity matching the search query.
+type SearchResult struct {
+	Entity  *Entity
+	Rank    float64
+	Snippet string // the matched text, matches are wrapped in <mark></mark>
+}
+
+// HasType reports whether the entities of type t are requested.
+func (o ListOptions) HasType(t EntityType) bool {
+	if len(o.Types) == 0 {
+		return true
+	}
+	for _, ot := range o.Types {
+		if ot == t {
+			return true
+		}
+	}
+	return false
+}
+
 // Entity represents the entity DTO.
 type Entity struct {
 	ID         int
@@ -124,38 +626,99 @@ type Entity struct {
 	Text       string
 	Filename   string
 	Metadata   string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Revision   int
+	DeletedAt  time.Time
+	FolderID   int // 0 if the entity is not in a folder
+	TagIDs     []int
+	Labels     map[string]string
+	Custom     []CustomField // ordered as the user defined them
+	Permission Permission    // the access of the user the entity is shared with, empty for the owner
+	VaultID    int           // 0 if the entity is the owner`s personal entity
+
+	PrunedRevision int // the versions up to the revision are pruned, 0 if none are
+
+	RequiresApproval bool // every read of the entity by other users than its approvers has to be approved
+	Approver         bool // the user approves the reads: the owner of the personal entity or the vault admin
+	Sealed           bool // the secret fields are cleared, the entity has to be read with the approved request
 }
 
-// ToPassword transforms entity to the password model.
-func (e *Entity) ToPassword() *Password {
-	return &Password{
-		ID:       e.ID,
-		OwnerID:  e.OwnerID,
-		Login:    e.Login,
-		Password: e.Password,
-		Metadata: e.Metadata,
+// Cursor returns the entity position in the list sorted by the provided field.
+func (e *Entity) Cursor(by SortField) *Cursor {
+	c := &Cursor{Time: e.CreatedAt, Type: e.Type, ID: e.ID}
+	switch by {
+	case SortByUpdatedAt:
+		c.Time = e.UpdatedAt
+	case SortByDeletedAt:
+		c.Time = e.DeletedAt
 	}
+	return c
 }
 
-// ToCard transforms entity to the card model.
-func (e *Entity) ToCard() *Card {
-	return &Card{
-		ID:       e.ID,
-		OwnerID:  e.OwnerID,
-		Number:   e.CardNumber,
-		CVC:      e.CardCVC,
-		Owner:    e.CardOwner,
-		Date:     e.CardExp,
-		Metadata: e.Metadata,
+// Field returns the value of the entity field.
+func (e *Entity) Field(f EntityField) string {
+	switch f {
+	case FieldLogin:
+		return e.Login
+	case FieldPassword:
+		return e.Password
+	case FieldCardNumber:
+		return e.CardNumber
+	case FieldCardOwner:
+		return e.CardOwner
+	case FieldCardCVC:
+		return e.CardCVC
+	case FieldCardExp:
+		return e.CardExp
+	case FieldText:
+		return e.Text
+	case FieldFilename:
+		return e.Filename
+	case FieldMetadata:
+		return e.Metadata
+	}
+	return ""
+}
+
+// sealedFields are the fields cleared by Seal.
+var sealedFields = []EntityField{FieldPassword, FieldCardNumber, FieldCardOwner, FieldCardCVC, FieldCardExp, FieldText}
+
+// Seal clears the secret fields and the custom field values of the entity read without the approval.
+func (e *Entity) Seal() {
+	for _, f := range sealedFields {
+		e.SetField(f, "")
+	}
+	custom := make([]CustomField, 0, len(e.Custom))
+	for _, c := range e.Custom {
+		custom = append(custom, CustomField{Name: c.Name, Type: c.Type})
+	}
+	if len(custom) > 0 {
+		e.Custom = custom
 	}
+	e.Sealed = true
 }
 
-// ToText transforms entity to the text model.
-func (e *Entity) ToText() *Text {
-	return &Text{
-		ID:       e.ID,
-		OwnerID:  e.OwnerID,
-		Text:     e.Text,
-		Metadata: e.Metadata,
+// SetField sets the value of the entity field.
+func (e *Entity) SetField(f EntityField, v string) {
+	switch f {
+	case FieldLogin:
+		e.Login = v
+	case FieldPassword:
+		e.Password = v
+	case FieldCardNumber:
+		e.CardNumber = v
+	case FieldCardOwner:
+		e.CardOwner = v
+	case FieldCardCVC:
+		e.CardCVC = v
+	case FieldCardExp:
+		e.CardExp = v
+	case FieldText:
+		e.Text = v
+	case FieldFilename:
+		e.Filename = v
+	case FieldMetadata:
+		e.Metadata = v
 	}
 }
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.4.0
+// - protoc-gen-go-grpc v1.5.1
 // - protoc             v4.25.0
 // source: passkeeper.proto
 
@@ -15,16 +15,67 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.62.0 or later.
-const _ = grpc.SupportPackageIsVersion8
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PassKeeper_AddEntity_FullMethodName    = "/auth.PassKeeper/AddEntity"
-	PassKeeper_UpdateEntity_FullMethodName = "/auth.PassKeeper/UpdateEntity"
-	PassKeeper_DeleteEntity_FullMethodName = "/auth.PassKeeper/DeleteEntity"
-	PassKeeper_ListEntities_FullMethodName = "/auth.PassKeeper/ListEntities"
-	PassKeeper_UploadFile_FullMethodName   = "/auth.PassKeeper/UploadFile"
-	PassKeeper_DownloadFile_FullMethodName = "/auth.PassKeeper/DownloadFile"
+	PassKeeper_AddEntity_FullMethodName              = "/auth.PassKeeper/AddEntity"
+	PassKeeper_UpdateEntity_FullMethodName           = "/auth.PassKeeper/UpdateEntity"
+	PassKeeper_DeleteEntity_FullMethodName           = "/auth.PassKeeper/DeleteEntity"
+	PassKeeper_GetEntity_FullMethodName              = "/auth.PassKeeper/GetEntity"
+	PassKeeper_ListEntities_FullMethodName           = "/auth.PassKeeper/ListEntities"
+	PassKeeper_StreamEntities_FullMethodName         = "/auth.PassKeeper/StreamEntities"
+	PassKeeper_SearchEntities_FullMethodName         = "/auth.PassKeeper/SearchEntities"
+	PassKeeper_Watch_FullMethodName                  = "/auth.PassKeeper/Watch"
+	PassKeeper_SyncSince_FullMethodName              = "/auth.PassKeeper/SyncSince"
+	PassKeeper_ListConflicts_FullMethodName          = "/auth.PassKeeper/ListConflicts"
+	PassKeeper_GetConflict_FullMethodName            = "/auth.PassKeeper/GetConflict"
+	PassKeeper_ResolveConflict_FullMethodName        = "/auth.PassKeeper/ResolveConflict"
+	PassKeeper_ShareEntity_FullMethodName            = "/auth.PassKeeper/ShareEntity"
+	PassKeeper_UnshareEntity_FullMethodName          = "/auth.PassKeeper/UnshareEntity"
+	PassKeeper_ListEntityShares_FullMethodName       = "/auth.PassKeeper/ListEntityShares"
+	PassKeeper_CreateOrganization_FullMethodName     = "/auth.PassKeeper/CreateOrganization"
+	PassKeeper_ListOrganizations_FullMethodName      = "/auth.PassKeeper/ListOrganizations"
+	PassKeeper_DeleteOrganization_FullMethodName     = "/auth.PassKeeper/DeleteOrganization"
+	PassKeeper_AddMember_FullMethodName              = "/auth.PassKeeper/AddMember"
+	PassKeeper_UpdateMember_FullMethodName           = "/auth.PassKeeper/UpdateMember"
+	PassKeeper_RemoveMember_FullMethodName           = "/auth.PassKeeper/RemoveMember"
+	PassKeeper_ListMembers_FullMethodName            = "/auth.PassKeeper/ListMembers"
+	PassKeeper_CreateVault_FullMethodName            = "/auth.PassKeeper/CreateVault"
+	PassKeeper_RenameVault_FullMethodName            = "/auth.PassKeeper/RenameVault"
+	PassKeeper_DeleteVault_FullMethodName            = "/auth.PassKeeper/DeleteVault"
+	PassKeeper_ListVaults_FullMethodName             = "/auth.PassKeeper/ListVaults"
+	PassKeeper_SetVaultKeys_FullMethodName           = "/auth.PassKeeper/SetVaultKeys"
+	PassKeeper_GetVaultKeys_FullMethodName           = "/auth.PassKeeper/GetVaultKeys"
+	PassKeeper_ListEntityVersions_FullMethodName     = "/auth.PassKeeper/ListEntityVersions"
+	PassKeeper_RestoreEntityVersion_FullMethodName   = "/auth.PassKeeper/RestoreEntityVersion"
+	PassKeeper_ListTrash_FullMethodName              = "/auth.PassKeeper/ListTrash"
+	PassKeeper_RestoreEntity_FullMethodName          = "/auth.PassKeeper/RestoreEntity"
+	PassKeeper_PurgeEntity_FullMethodName            = "/auth.PassKeeper/PurgeEntity"
+	PassKeeper_CreateFolder_FullMethodName           = "/auth.PassKeeper/CreateFolder"
+	PassKeeper_UpdateFolder_FullMethodName           = "/auth.PassKeeper/UpdateFolder"
+	PassKeeper_DeleteFolder_FullMethodName           = "/auth.PassKeeper/DeleteFolder"
+	PassKeeper_ListFolders_FullMethodName            = "/auth.PassKeeper/ListFolders"
+	PassKeeper_CreateTag_FullMethodName              = "/auth.PassKeeper/CreateTag"
+	PassKeeper_RenameTag_FullMethodName              = "/auth.PassKeeper/RenameTag"
+	PassKeeper_DeleteTag_FullMethodName              = "/auth.PassKeeper/DeleteTag"
+	PassKeeper_ListTags_FullMethodName               = "/auth.PassKeeper/ListTags"
+	PassKeeper_CreateSend_FullMethodName             = "/auth.PassKeeper/CreateSend"
+	PassKeeper_OpenSend_FullMethodName               = "/auth.PassKeeper/OpenSend"
+	PassKeeper_ListSends_FullMethodName              = "/auth.PassKeeper/ListSends"
+	PassKeeper_DeleteSend_FullMethodName             = "/auth.PassKeeper/DeleteSend"
+	PassKeeper_InviteEmergencyContact_FullMethodName = "/auth.PassKeeper/InviteEmergencyContact"
+	PassKeeper_UpdateEmergencyAccess_FullMethodName  = "/auth.PassKeeper/UpdateEmergencyAccess"
+	PassKeeper_ListEmergencyContacts_FullMethodName  = "/auth.PassKeeper/ListEmergencyContacts"
+	PassKeeper_ListEmergencyEvents_FullMethodName    = "/auth.PassKeeper/ListEmergencyEvents"
+	PassKeeper_SetApprovalRequired_FullMethodName    = "/auth.PassKeeper/SetApprovalRequired"
+	PassKeeper_RequestAccess_FullMethodName          = "/auth.PassKeeper/RequestAccess"
+	PassKeeper_DecideAccessRequest_FullMethodName    = "/auth.PassKeeper/DecideAccessRequest"
+	PassKeeper_ListAccessRequests_FullMethodName     = "/auth.PassKeeper/ListAccessRequests"
+	PassKeeper_WatchAccessRequests_FullMethodName    = "/auth.PassKeeper/WatchAccessRequests"
+	PassKeeper_ListAccessEvents_FullMethodName       = "/auth.PassKeeper/ListAccessEvents"
+	PassKeeper_UploadFile_FullMethodName             = "/auth.PassKeeper/UploadFile"
+	PassKeeper_DownloadFile_FullMethodName           = "/auth.PassKeeper/DownloadFile"
 )
 
 // PassKeeperClient is the client API for PassKeeper service.
@@ -35,14 +86,116 @@ type PassKeeperClient interface {
 	AddEntity(ctx context.Context, in *AddEntityRequest, opts ...grpc.CallOption) (*AddEntityResponse, error)
 	// UpdateEntity updates the entity.
 	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error)
-	// DeleteEntity deletes the entity.
+	// DeleteEntity moves the entity to the trash.
 	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*DeleteEntityResponse, error)
+	// GetEntity returns the entity.
+	GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*GetEntityResponse, error)
 	// ListEntities return all entities list.
 	ListEntities(ctx context.Context, in *ListEntitiesRequest, opts ...grpc.CallOption) (*ListEntitiesResponse, error)
+	// StreamEntities streams entities as they are read, ordered by the creation time.
+	StreamEntities(ctx context.Context, in *StreamEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEntitiesResponse], error)
+	// SearchEntities returns the entities matching the query, ranked and highlighted.
+	SearchEntities(ctx context.Context, in *SearchEntitiesRequest, opts ...grpc.CallOption) (*SearchEntitiesResponse, error)
+	// Watch streams the changes of the entities as they happen on any device.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error)
+	// SyncSince returns the entities changed since the cursor.
+	SyncSince(ctx context.Context, in *SyncSinceRequest, opts ...grpc.CallOption) (*SyncSinceResponse, error)
+	// ListConflicts returns the offline edits conflicting with the server changes.
+	ListConflicts(ctx context.Context, in *ListConflictsRequest, opts ...grpc.CallOption) (*ListConflictsResponse, error)
+	// GetConflict returns the conflict.
+	GetConflict(ctx context.Context, in *GetConflictRequest, opts ...grpc.CallOption) (*GetConflictResponse, error)
+	// ResolveConflict resolves the conflict by choosing a side or merging the fields.
+	ResolveConflict(ctx context.Context, in *ResolveConflictRequest, opts ...grpc.CallOption) (*ResolveConflictResponse, error)
+	// ShareEntity grants the user access to the entity or changes the access granted before.
+	ShareEntity(ctx context.Context, in *ShareEntityRequest, opts ...grpc.CallOption) (*ShareEntityResponse, error)
+	// UnshareEntity revokes the user`s access to the entity.
+	UnshareEntity(ctx context.Context, in *UnshareEntityRequest, opts ...grpc.CallOption) (*UnshareEntityResponse, error)
+	// ListEntityShares returns the users the entity is shared with.
+	ListEntityShares(ctx context.Context, in *ListEntitySharesRequest, opts ...grpc.CallOption) (*ListEntitySharesResponse, error)
+	// CreateOrganization creates the organization, the user becomes its owner.
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	// ListOrganizations returns the organizations the user is a member of.
+	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	// DeleteOrganization deletes the organization with empty vaults.
+	DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*DeleteOrganizationResponse, error)
+	// AddMember adds the registered user to the organization.
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	// UpdateMember changes the member role.
+	UpdateMember(ctx context.Context, in *UpdateMemberRequest, opts ...grpc.CallOption) (*UpdateMemberResponse, error)
+	// RemoveMember removes the member from the organization.
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	// ListMembers returns the organization members.
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	// CreateVault creates the organization vault.
+	CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*CreateVaultResponse, error)
+	// RenameVault renames the organization vault.
+	RenameVault(ctx context.Context, in *RenameVaultRequest, opts ...grpc.CallOption) (*RenameVaultResponse, error)
+	// DeleteVault deletes the empty organization vault.
+	DeleteVault(ctx context.Context, in *DeleteVaultRequest, opts ...grpc.CallOption) (*DeleteVaultResponse, error)
+	// ListVaults returns the organization vaults.
+	ListVaults(ctx context.Context, in *ListVaultsRequest, opts ...grpc.CallOption) (*ListVaultsResponse, error)
+	// SetVaultKeys distributes the vault key encrypted to the members` public keys or rotates it.
+	SetVaultKeys(ctx context.Context, in *SetVaultKeysRequest, opts ...grpc.CallOption) (*SetVaultKeysResponse, error)
+	// GetVaultKeys returns the vault keys encrypted to the user.
+	GetVaultKeys(ctx context.Context, in *GetVaultKeysRequest, opts ...grpc.CallOption) (*GetVaultKeysResponse, error)
+	// ListEntityVersions returns the prior versions of the password, card or text.
+	ListEntityVersions(ctx context.Context, in *ListEntityVersionsRequest, opts ...grpc.CallOption) (*ListEntityVersionsResponse, error)
+	// RestoreEntityVersion makes the prior version the current one.
+	RestoreEntityVersion(ctx context.Context, in *RestoreEntityVersionRequest, opts ...grpc.CallOption) (*RestoreEntityVersionResponse, error)
+	// ListTrash returns the entities in the trash.
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// RestoreEntity moves the entity back from the trash.
+	RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*RestoreEntityResponse, error)
+	// PurgeEntity permanently deletes the entity in the trash.
+	PurgeEntity(ctx context.Context, in *PurgeEntityRequest, opts ...grpc.CallOption) (*PurgeEntityResponse, error)
+	// CreateFolder creates the folder.
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error)
+	// UpdateFolder renames the folder and moves it to another parent folder.
+	UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error)
+	// DeleteFolder deletes the folder, its entities and subfolders are moved to the parent folder.
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error)
+	// ListFolders returns all folders.
+	ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	// CreateTag creates the tag.
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	// RenameTag renames the tag.
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// DeleteTag deletes the tag, the tagged entities are kept.
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	// ListTags returns all tags.
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// CreateSend creates the one-time send and returns its token.
+	CreateSend(ctx context.Context, in *CreateSendRequest, opts ...grpc.CallOption) (*CreateSendResponse, error)
+	// OpenSend redeems the send token, the call does not require authentication.
+	OpenSend(ctx context.Context, in *OpenSendRequest, opts ...grpc.CallOption) (*OpenSendResponse, error)
+	// ListSends returns the active sends.
+	ListSends(ctx context.Context, in *ListSendsRequest, opts ...grpc.CallOption) (*ListSendsResponse, error)
+	// DeleteSend destroys the send before it expires.
+	DeleteSend(ctx context.Context, in *DeleteSendRequest, opts ...grpc.CallOption) (*DeleteSendResponse, error)
+	// InviteEmergencyContact invites the user as the trusted contact.
+	InviteEmergencyContact(ctx context.Context, in *InviteEmergencyContactRequest, opts ...grpc.CallOption) (*InviteEmergencyContactResponse, error)
+	// UpdateEmergencyAccess performs the action on the emergency access the user is a party of.
+	UpdateEmergencyAccess(ctx context.Context, in *UpdateEmergencyAccessRequest, opts ...grpc.CallOption) (*UpdateEmergencyAccessResponse, error)
+	// ListEmergencyContacts returns the emergency contacts the user is the grantor or the grantee of.
+	ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	// ListEmergencyEvents returns the audit trail of the emergency access.
+	ListEmergencyEvents(ctx context.Context, in *ListEmergencyEventsRequest, opts ...grpc.CallOption) (*ListEmergencyEventsResponse, error)
+	// SetApprovalRequired sets whether the reads of the entity have to be approved.
+	SetApprovalRequired(ctx context.Context, in *SetApprovalRequiredRequest, opts ...grpc.CallOption) (*SetApprovalRequiredResponse, error)
+	// RequestAccess requests the read access to the entity requiring approval, the approvers are notified.
+	RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error)
+	// DecideAccessRequest approves or denies the pending access request.
+	DecideAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*DecideAccessRequestResponse, error)
+	// ListAccessRequests returns the access requests the user approves or has made.
+	ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error)
+	// WatchAccessRequests streams the access requests every time they change.
+	WatchAccessRequests(ctx context.Context, in *WatchAccessRequestsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchAccessRequestsResponse], error)
+	// ListAccessEvents returns the audit trail of the entity requiring approval.
+	ListAccessEvents(ctx context.Context, in *ListAccessEventsRequest, opts ...grpc.CallOption) (*ListAccessEventsResponse, error)
 	// UploadFile uploads file to the server.
-	UploadFile(ctx context.Context, opts ...grpc.CallOption) (PassKeeper_UploadFileClient, error)
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error)
 	// DownloadFile downloads file from the server.
-	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (PassKeeper_DownloadFileClient, error)
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error)
 }
 
 type passKeeperClient struct {
@@ -83,6 +236,16 @@ func (c *passKeeperClient) DeleteEntity(ctx context.Context, in *DeleteEntityReq
 	return out, nil
 }
 
+func (c *passKeeperClient) GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*GetEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEntityResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_GetEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *passKeeperClient) ListEntities(ctx context.Context, in *ListEntitiesRequest, opts ...grpc.CallOption) (*ListEntitiesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListEntitiesResponse)
@@ -90,249 +253,1874 @@ func (c *passKeeperClient) ListEntities(ctx context.Context, in *ListEntitiesReq
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *passKeeperClient) StreamEntities(ctx context.Context, in *StreamEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEntitiesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[0], PassKeeper_StreamEntities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEntitiesRequest, StreamEntitiesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_StreamEntitiesClient = grpc.ServerStreamingClient[StreamEntitiesResponse]
+
+func (c *passKeeperClient) SearchEntities(ctx context.Context, in *SearchEntitiesRequest, opts ...grpc.CallOption) (*SearchEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchEntitiesResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_SearchEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[1], PassKeeper_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_WatchClient = grpc.ServerStreamingClient[WatchResponse]
+
+func (c *passKeeperClient) SyncSince(ctx context.Context, in *SyncSinceRequest, opts ...grpc.CallOption) (*SyncSinceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncSinceResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_SyncSince_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListConflicts(ctx context.Context, in *ListConflictsRequest, opts ...grpc.CallOption) (*ListConflictsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConflictsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListConflicts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) GetConflict(ctx context.Context, in *GetConflictRequest, opts ...grpc.CallOption) (*GetConflictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConflictResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_GetConflict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ResolveConflict(ctx context.Context, in *ResolveConflictRequest, opts ...grpc.CallOption) (*ResolveConflictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveConflictResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ResolveConflict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ShareEntity(ctx context.Context, in *ShareEntityRequest, opts ...grpc.CallOption) (*ShareEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareEntityResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ShareEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) UnshareEntity(ctx context.Context, in *UnshareEntityRequest, opts ...grpc.CallOption) (*UnshareEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareEntityResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_UnshareEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListEntityShares(ctx context.Context, in *ListEntitySharesRequest, opts ...grpc.CallOption) (*ListEntitySharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEntitySharesResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListEntityShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrganizationResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*DeleteOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrganizationResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DeleteOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) UpdateMember(ctx context.Context, in *UpdateMemberRequest, opts ...grpc.CallOption) (*UpdateMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMemberResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_UpdateMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*CreateVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVaultResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_CreateVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RenameVault(ctx context.Context, in *RenameVaultRequest, opts ...grpc.CallOption) (*RenameVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameVaultResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RenameVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DeleteVault(ctx context.Context, in *DeleteVaultRequest, opts ...grpc.CallOption) (*DeleteVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVaultResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DeleteVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListVaults(ctx context.Context, in *ListVaultsRequest, opts ...grpc.CallOption) (*ListVaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVaultsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListVaults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) SetVaultKeys(ctx context.Context, in *SetVaultKeysRequest, opts ...grpc.CallOption) (*SetVaultKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetVaultKeysResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_SetVaultKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) GetVaultKeys(ctx context.Context, in *GetVaultKeysRequest, opts ...grpc.CallOption) (*GetVaultKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultKeysResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_GetVaultKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListEntityVersions(ctx context.Context, in *ListEntityVersionsRequest, opts ...grpc.CallOption) (*ListEntityVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEntityVersionsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListEntityVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RestoreEntityVersion(ctx context.Context, in *RestoreEntityVersionRequest, opts ...grpc.CallOption) (*RestoreEntityVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreEntityVersionResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RestoreEntityVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*RestoreEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreEntityResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RestoreEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) PurgeEntity(ctx context.Context, in *PurgeEntityRequest, opts ...grpc.CallOption) (*PurgeEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeEntityResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_PurgeEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFolderResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFolderResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_UpdateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFolderResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DeleteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) CreateSend(ctx context.Context, in *CreateSendRequest, opts ...grpc.CallOption) (*CreateSendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSendResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_CreateSend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) OpenSend(ctx context.Context, in *OpenSendRequest, opts ...grpc.CallOption) (*OpenSendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenSendResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_OpenSend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListSends(ctx context.Context, in *ListSendsRequest, opts ...grpc.CallOption) (*ListSendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSendsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListSends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DeleteSend(ctx context.Context, in *DeleteSendRequest, opts ...grpc.CallOption) (*DeleteSendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSendResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DeleteSend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) InviteEmergencyContact(ctx context.Context, in *InviteEmergencyContactRequest, opts ...grpc.CallOption) (*InviteEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_InviteEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) UpdateEmergencyAccess(ctx context.Context, in *UpdateEmergencyAccessRequest, opts ...grpc.CallOption) (*UpdateEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_UpdateEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListEmergencyContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListEmergencyEvents(ctx context.Context, in *ListEmergencyEventsRequest, opts ...grpc.CallOption) (*ListEmergencyEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyEventsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListEmergencyEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) SetApprovalRequired(ctx context.Context, in *SetApprovalRequiredRequest, opts ...grpc.CallOption) (*SetApprovalRequiredResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetApprovalRequiredResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_SetApprovalRequired_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestAccessResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_RequestAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) DecideAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*DecideAccessRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecideAccessRequestResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_DecideAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessRequestsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListAccessRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) WatchAccessRequests(ctx context.Context, in *WatchAccessRequestsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchAccessRequestsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[2], PassKeeper_WatchAccessRequests_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAccessRequestsRequest, WatchAccessRequestsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_WatchAccessRequestsClient = grpc.ServerStreamingClient[WatchAccessRequestsResponse]
+
+func (c *passKeeperClient) ListAccessEvents(ctx context.Context, in *ListAccessEventsRequest, opts ...grpc.CallOption) (*ListAccessEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessEventsResponse)
+	err := c.cc.Invoke(ctx, PassKeeper_ListAccessEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[3], PassKeeper_UploadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadFileRequest, UploadFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_UploadFileClient = grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse]
+
+func (c *passKeeperClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[4], PassKeeper_DownloadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadFileRequest, DownloadFileResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_DownloadFileClient = grpc.ServerStreamingClient[DownloadFileResponse]
+
+// PassKeeperServer is the server API for PassKeeper service.
+// All implementations must embed UnimplementedPassKeeperServer
+// for forward compatibility.
+type PassKeeperServer interface {
+	// AddEntity adds a new entity.
+	AddEntity(context.Context, *AddEntityRequest) (*AddEntityResponse, error)
+	// UpdateEntity updates the entity.
+	UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error)
+	// DeleteEntity moves the entity to the trash.
+	DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error)
+	// GetEntity returns the entity.
+	GetEntity(context.Context, *GetEntityRequest) (*GetEntityResponse, error)
+	// ListEntities return all entities list.
+	ListEntities(context.Context, *ListEntitiesRequest) (*ListEntitiesResponse, error)
+	// StreamEntities streams entities as they are read, ordered by the creation time.
+	StreamEntities(*StreamEntitiesRequest, grpc.ServerStreamingServer[StreamEntitiesResponse]) error
+	// SearchEntities returns the entities matching the query, ranked and highlighted.
+	SearchEntities(context.Context, *SearchEntitiesRequest) (*SearchEntitiesResponse, error)
+	// Watch streams the changes of the entities as they happen on any device.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error
+	// SyncSince returns the entities changed since the cursor.
+	SyncSince(context.Context, *SyncSinceRequest) (*SyncSinceResponse, error)
+	// ListConflicts returns the offline edits conflicting with the server changes.
+	ListConflicts(context.Context, *ListConflictsRequest) (*ListConflictsResponse, error)
+	// GetConflict returns the conflict.
+	GetConflict(context.Context, *GetConflictRequest) (*GetConflictResponse, error)
+	// ResolveConflict resolves the conflict by choosing a side or merging the fields.
+	ResolveConflict(context.Context, *ResolveConflictRequest) (*ResolveConflictResponse, error)
+	// ShareEntity grants the user access to the entity or changes the access granted before.
+	ShareEntity(context.Context, *ShareEntityRequest) (*ShareEntityResponse, error)
+	// UnshareEntity revokes the user`s access to the entity.
+	UnshareEntity(context.Context, *UnshareEntityRequest) (*UnshareEntityResponse, error)
+	// ListEntityShares returns the users the entity is shared with.
+	ListEntityShares(context.Context, *ListEntitySharesRequest) (*ListEntitySharesResponse, error)
+	// CreateOrganization creates the organization, the user becomes its owner.
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	// ListOrganizations returns the organizations the user is a member of.
+	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	// DeleteOrganization deletes the organization with empty vaults.
+	DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error)
+	// AddMember adds the registered user to the organization.
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	// UpdateMember changes the member role.
+	UpdateMember(context.Context, *UpdateMemberRequest) (*UpdateMemberResponse, error)
+	// RemoveMember removes the member from the organization.
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	// ListMembers returns the organization members.
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+	// CreateVault creates the organization vault.
+	CreateVault(context.Context, *CreateVaultRequest) (*CreateVaultResponse, error)
+	// RenameVault renames the organization vault.
+	RenameVault(context.Context, *RenameVaultRequest) (*RenameVaultResponse, error)
+	// DeleteVault deletes the empty organization vault.
+	DeleteVault(context.Context, *DeleteVaultRequest) (*DeleteVaultResponse, error)
+	// ListVaults returns the organization vaults.
+	ListVaults(context.Context, *ListVaultsRequest) (*ListVaultsResponse, error)
+	// SetVaultKeys distributes the vault key encrypted to the members` public keys or rotates it.
+	SetVaultKeys(context.Context, *SetVaultKeysRequest) (*SetVaultKeysResponse, error)
+	// GetVaultKeys returns the vault keys encrypted to the user.
+	GetVaultKeys(context.Context, *GetVaultKeysRequest) (*GetVaultKeysResponse, error)
+	// ListEntityVersions returns the prior versions of the password, card or text.
+	ListEntityVersions(context.Context, *ListEntityVersionsRequest) (*ListEntityVersionsResponse, error)
+	// RestoreEntityVersion makes the prior version the current one.
+	RestoreEntityVersion(context.Context, *RestoreEntityVersionRequest) (*RestoreEntityVersionResponse, error)
+	// ListTrash returns the entities in the trash.
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// RestoreEntity moves the entity back from the trash.
+	RestoreEntity(context.Context, *RestoreEntityRequest) (*RestoreEntityResponse, error)
+	// PurgeEntity permanently deletes the entity in the trash.
+	PurgeEntity(context.Context, *PurgeEntityRequest) (*PurgeEntityResponse, error)
+	// CreateFolder creates the folder.
+	CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error)
+	// UpdateFolder renames the folder and moves it to another parent folder.
+	UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error)
+	// DeleteFolder deletes the folder, its entities and subfolders are moved to the parent folder.
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error)
+	// ListFolders returns all folders.
+	ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error)
+	// CreateTag creates the tag.
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	// RenameTag renames the tag.
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// DeleteTag deletes the tag, the tagged entities are kept.
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	// ListTags returns all tags.
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// CreateSend creates the one-time send and returns its token.
+	CreateSend(context.Context, *CreateSendRequest) (*CreateSendResponse, error)
+	// OpenSend redeems the send token, the call does not require authentication.
+	OpenSend(context.Context, *OpenSendRequest) (*OpenSendResponse, error)
+	// ListSends returns the active sends.
+	ListSends(context.Context, *ListSendsRequest) (*ListSendsResponse, error)
+	// DeleteSend destroys the send before it expires.
+	DeleteSend(context.Context, *DeleteSendRequest) (*DeleteSendResponse, error)
+	// InviteEmergencyContact invites the user as the trusted contact.
+	InviteEmergencyContact(context.Context, *InviteEmergencyContactRequest) (*InviteEmergencyContactResponse, error)
+	// UpdateEmergencyAccess performs the action on the emergency access the user is a party of.
+	UpdateEmergencyAccess(context.Context, *UpdateEmergencyAccessRequest) (*UpdateEmergencyAccessResponse, error)
+	// ListEmergencyContacts returns the emergency contacts the user is the grantor or the grantee of.
+	ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error)
+	// ListEmergencyEvents returns the audit trail of the emergency access.
+	ListEmergencyEvents(context.Context, *ListEmergencyEventsRequest) (*ListEmergencyEventsResponse, error)
+	// SetApprovalRequired sets whether the reads of the entity have to be approved.
+	SetApprovalRequired(context.Context, *SetApprovalRequiredRequest) (*SetApprovalRequiredResponse, error)
+	// RequestAccess requests the read access to the entity requiring approval, the approvers are notified.
+	RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error)
+	// DecideAccessRequest approves or denies the pending access request.
+	DecideAccessRequest(context.Context, *DecideAccessRequestRequest) (*DecideAccessRequestResponse, error)
+	// ListAccessRequests returns the access requests the user approves or has made.
+	ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error)
+	// WatchAccessRequests streams the access requests every time they change.
+	WatchAccessRequests(*WatchAccessRequestsRequest, grpc.ServerStreamingServer[WatchAccessRequestsResponse]) error
+	// ListAccessEvents returns the audit trail of the entity requiring approval.
+	ListAccessEvents(context.Context, *ListAccessEventsRequest) (*ListAccessEventsResponse, error)
+	// UploadFile uploads file to the server.
+	UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error
+	// DownloadFile downloads file from the server.
+	DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error
+	mustEmbedUnimplementedPassKeeperServer()
+}
+
+// UnimplementedPassKeeperServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPassKeeperServer struct{}
+
+func (UnimplementedPassKeeperServer) AddEntity(context.Context, *AddEntityRequest) (*AddEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) GetEntity(context.Context, *GetEntityRequest) (*GetEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) ListEntities(context.Context, *ListEntitiesRequest) (*ListEntitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntities not implemented")
+}
+func (UnimplementedPassKeeperServer) StreamEntities(*StreamEntitiesRequest, grpc.ServerStreamingServer[StreamEntitiesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEntities not implemented")
+}
+func (UnimplementedPassKeeperServer) SearchEntities(context.Context, *SearchEntitiesRequest) (*SearchEntitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEntities not implemented")
+}
+func (UnimplementedPassKeeperServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedPassKeeperServer) SyncSince(context.Context, *SyncSinceRequest) (*SyncSinceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncSince not implemented")
+}
+func (UnimplementedPassKeeperServer) ListConflicts(context.Context, *ListConflictsRequest) (*ListConflictsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConflicts not implemented")
+}
+func (UnimplementedPassKeeperServer) GetConflict(context.Context, *GetConflictRequest) (*GetConflictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConflict not implemented")
+}
+func (UnimplementedPassKeeperServer) ResolveConflict(context.Context, *ResolveConflictRequest) (*ResolveConflictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveConflict not implemented")
+}
+func (UnimplementedPassKeeperServer) ShareEntity(context.Context, *ShareEntityRequest) (*ShareEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) UnshareEntity(context.Context, *UnshareEntityRequest) (*UnshareEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) ListEntityShares(context.Context, *ListEntitySharesRequest) (*ListEntitySharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntityShares not implemented")
+}
+func (UnimplementedPassKeeperServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedPassKeeperServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrganization not implemented")
+}
+func (UnimplementedPassKeeperServer) AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedPassKeeperServer) UpdateMember(context.Context, *UpdateMemberRequest) (*UpdateMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMember not implemented")
+}
+func (UnimplementedPassKeeperServer) RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedPassKeeperServer) ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedPassKeeperServer) CreateVault(context.Context, *CreateVaultRequest) (*CreateVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVault not implemented")
+}
+func (UnimplementedPassKeeperServer) RenameVault(context.Context, *RenameVaultRequest) (*RenameVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameVault not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteVault(context.Context, *DeleteVaultRequest) (*DeleteVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVault not implemented")
+}
+func (UnimplementedPassKeeperServer) ListVaults(context.Context, *ListVaultsRequest) (*ListVaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVaults not implemented")
+}
+func (UnimplementedPassKeeperServer) SetVaultKeys(context.Context, *SetVaultKeysRequest) (*SetVaultKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKeys not implemented")
+}
+func (UnimplementedPassKeeperServer) GetVaultKeys(context.Context, *GetVaultKeysRequest) (*GetVaultKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKeys not implemented")
+}
+func (UnimplementedPassKeeperServer) ListEntityVersions(context.Context, *ListEntityVersionsRequest) (*ListEntityVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntityVersions not implemented")
+}
+func (UnimplementedPassKeeperServer) RestoreEntityVersion(context.Context, *RestoreEntityVersionRequest) (*RestoreEntityVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEntityVersion not implemented")
+}
+func (UnimplementedPassKeeperServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedPassKeeperServer) RestoreEntity(context.Context, *RestoreEntityRequest) (*RestoreEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) PurgeEntity(context.Context, *PurgeEntityRequest) (*PurgeEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeEntity not implemented")
+}
+func (UnimplementedPassKeeperServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedPassKeeperServer) UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFolder not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedPassKeeperServer) ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedPassKeeperServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedPassKeeperServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedPassKeeperServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedPassKeeperServer) CreateSend(context.Context, *CreateSendRequest) (*CreateSendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSend not implemented")
+}
+func (UnimplementedPassKeeperServer) OpenSend(context.Context, *OpenSendRequest) (*OpenSendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSend not implemented")
+}
+func (UnimplementedPassKeeperServer) ListSends(context.Context, *ListSendsRequest) (*ListSendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSends not implemented")
+}
+func (UnimplementedPassKeeperServer) DeleteSend(context.Context, *DeleteSendRequest) (*DeleteSendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSend not implemented")
+}
+func (UnimplementedPassKeeperServer) InviteEmergencyContact(context.Context, *InviteEmergencyContactRequest) (*InviteEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteEmergencyContact not implemented")
+}
+func (UnimplementedPassKeeperServer) UpdateEmergencyAccess(context.Context, *UpdateEmergencyAccessRequest) (*UpdateEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmergencyAccess not implemented")
+}
+func (UnimplementedPassKeeperServer) ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyContacts not implemented")
+}
+func (UnimplementedPassKeeperServer) ListEmergencyEvents(context.Context, *ListEmergencyEventsRequest) (*ListEmergencyEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyEvents not implemented")
+}
+func (UnimplementedPassKeeperServer) SetApprovalRequired(context.Context, *SetApprovalRequiredRequest) (*SetApprovalRequiredResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetApprovalRequired not implemented")
+}
+func (UnimplementedPassKeeperServer) RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
+func (UnimplementedPassKeeperServer) DecideAccessRequest(context.Context, *DecideAccessRequestRequest) (*DecideAccessRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideAccessRequest not implemented")
+}
+func (UnimplementedPassKeeperServer) ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequests not implemented")
+}
+func (UnimplementedPassKeeperServer) WatchAccessRequests(*WatchAccessRequestsRequest, grpc.ServerStreamingServer[WatchAccessRequestsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAccessRequests not implemented")
+}
+func (UnimplementedPassKeeperServer) ListAccessEvents(context.Context, *ListAccessEventsRequest) (*ListAccessEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessEvents not implemented")
+}
+func (UnimplementedPassKeeperServer) UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedPassKeeperServer) DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
+}
+func (UnimplementedPassKeeperServer) mustEmbedUnimplementedPassKeeperServer() {}
+func (UnimplementedPassKeeperServer) testEmbeddedByValue()                    {}
+
+// UnsafePassKeeperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PassKeeperServer will
+// result in compilation errors.
+type UnsafePassKeeperServer interface {
+	mustEmbedUnimplementedPassKeeperServer()
+}
+
+func RegisterPassKeeperServer(s grpc.ServiceRegistrar, srv PassKeeperServer) {
+	// If the following call pancis, it indicates UnimplementedPassKeeperServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PassKeeper_ServiceDesc, srv)
+}
+
+func _PassKeeper_AddEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).AddEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_AddEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).AddEntity(ctx, req.(*AddEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).UpdateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_UpdateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).UpdateEntity(ctx, req.(*UpdateEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteEntity(ctx, req.(*DeleteEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_GetEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).GetEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_GetEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).GetEntity(ctx, req.(*GetEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListEntities(ctx, req.(*ListEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_StreamEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PassKeeperServer).StreamEntities(m, &grpc.GenericServerStream[StreamEntitiesRequest, StreamEntitiesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_StreamEntitiesServer = grpc.ServerStreamingServer[StreamEntitiesResponse]
+
+func _PassKeeper_SearchEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).SearchEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_SearchEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).SearchEntities(ctx, req.(*SearchEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PassKeeperServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_WatchServer = grpc.ServerStreamingServer[WatchResponse]
+
+func _PassKeeper_SyncSince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncSinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).SyncSince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_SyncSince_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).SyncSince(ctx, req.(*SyncSinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConflictsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListConflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListConflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListConflicts(ctx, req.(*ListConflictsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_GetConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConflictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).GetConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_GetConflict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).GetConflict(ctx, req.(*GetConflictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ResolveConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveConflictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ResolveConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ResolveConflict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ResolveConflict(ctx, req.(*ResolveConflictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ShareEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ShareEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ShareEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ShareEntity(ctx, req.(*ShareEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_UnshareEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).UnshareEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_UnshareEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).UnshareEntity(ctx, req.(*UnshareEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListEntityShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntitySharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListEntityShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListEntityShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListEntityShares(ctx, req.(*ListEntitySharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListOrganizations(ctx, req.(*ListOrganizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteOrganization(ctx, req.(*DeleteOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).AddMember(ctx, req.(*AddMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_UpdateMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).UpdateMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_UpdateMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).UpdateMember(ctx, req.(*UpdateMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListMembers(ctx, req.(*ListMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_CreateVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).CreateVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_CreateVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).CreateVault(ctx, req.(*CreateVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_RenameVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).RenameVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_RenameVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).RenameVault(ctx, req.(*RenameVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteVault(ctx, req.(*DeleteVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListVaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListVaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListVaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListVaults(ctx, req.(*ListVaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_SetVaultKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).SetVaultKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_SetVaultKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).SetVaultKeys(ctx, req.(*SetVaultKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_GetVaultKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVaultKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).GetVaultKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_GetVaultKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).GetVaultKeys(ctx, req.(*GetVaultKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListEntityVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntityVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListEntityVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListEntityVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListEntityVersions(ctx, req.(*ListEntityVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_RestoreEntityVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEntityVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).RestoreEntityVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_RestoreEntityVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).RestoreEntityVersion(ctx, req.(*RestoreEntityVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_RestoreEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).RestoreEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_RestoreEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).RestoreEntity(ctx, req.(*RestoreEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_PurgeEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).PurgeEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_PurgeEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).PurgeEntity(ctx, req.(*PurgeEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_UpdateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).UpdateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_UpdateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).UpdateFolder(ctx, req.(*UpdateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListFolders(ctx, req.(*ListFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_CreateSend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).CreateSend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_CreateSend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).CreateSend(ctx, req.(*CreateSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_OpenSend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).OpenSend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_OpenSend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).OpenSend(ctx, req.(*OpenSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_ListSends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListSends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListSends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListSends(ctx, req.(*ListSendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeper_DeleteSend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).DeleteSend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_DeleteSend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).DeleteSend(ctx, req.(*DeleteSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (PassKeeper_UploadFileClient, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[0], PassKeeper_UploadFile_FullMethodName, cOpts...)
-	if err != nil {
+func _PassKeeper_InviteEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteEmergencyContactRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &passKeeperUploadFileClient{ClientStream: stream}
-	return x, nil
-}
-
-type PassKeeper_UploadFileClient interface {
-	Send(*UploadFileRequest) error
-	CloseAndRecv() (*UploadFileResponse, error)
-	grpc.ClientStream
-}
-
-type passKeeperUploadFileClient struct {
-	grpc.ClientStream
-}
-
-func (x *passKeeperUploadFileClient) Send(m *UploadFileRequest) error {
-	return x.ClientStream.SendMsg(m)
+	if interceptor == nil {
+		return srv.(PassKeeperServer).InviteEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_InviteEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).InviteEmergencyContact(ctx, req.(*InviteEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *passKeeperUploadFileClient) CloseAndRecv() (*UploadFileResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func _PassKeeper_UpdateEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmergencyAccessRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	m := new(UploadFileResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(PassKeeperServer).UpdateEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_UpdateEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).UpdateEmergencyAccess(ctx, req.(*UpdateEmergencyAccessRequest))
 	}
-	return m, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (PassKeeper_DownloadFileClient, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &PassKeeper_ServiceDesc.Streams[1], PassKeeper_DownloadFile_FullMethodName, cOpts...)
-	if err != nil {
+func _PassKeeper_ListEmergencyContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyContactsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &passKeeperDownloadFileClient{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListEmergencyContacts(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListEmergencyContacts_FullMethodName,
 	}
-	return x, nil
-}
-
-type PassKeeper_DownloadFileClient interface {
-	Recv() (*DownloadFileResponse, error)
-	grpc.ClientStream
-}
-
-type passKeeperDownloadFileClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListEmergencyContacts(ctx, req.(*ListEmergencyContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *passKeeperDownloadFileClient) Recv() (*DownloadFileResponse, error) {
-	m := new(DownloadFileResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _PassKeeper_ListEmergencyEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyEventsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-// PassKeeperServer is the server API for PassKeeper service.
-// All implementations must embed UnimplementedPassKeeperServer
-// for forward compatibility
-type PassKeeperServer interface {
-	// AddEntity adds a new entity.
-	AddEntity(context.Context, *AddEntityRequest) (*AddEntityResponse, error)
-	// UpdateEntity updates the entity.
-	UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error)
-	// DeleteEntity deletes the entity.
-	DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error)
-	// ListEntities return all entities list.
-	ListEntities(context.Context, *ListEntitiesRequest) (*ListEntitiesResponse, error)
-	// UploadFile uploads file to the server.
-	UploadFile(PassKeeper_UploadFileServer) error
-	// DownloadFile downloads file from the server.
-	DownloadFile(*DownloadFileRequest, PassKeeper_DownloadFileServer) error
-	mustEmbedUnimplementedPassKeeperServer()
-}
-
-// UnimplementedPassKeeperServer must be embedded to have forward compatible implementations.
-type UnimplementedPassKeeperServer struct {
-}
-
-func (UnimplementedPassKeeperServer) AddEntity(context.Context, *AddEntityRequest) (*AddEntityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddEntity not implemented")
-}
-func (UnimplementedPassKeeperServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
-}
-func (UnimplementedPassKeeperServer) DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
-}
-func (UnimplementedPassKeeperServer) ListEntities(context.Context, *ListEntitiesRequest) (*ListEntitiesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListEntities not implemented")
-}
-func (UnimplementedPassKeeperServer) UploadFile(PassKeeper_UploadFileServer) error {
-	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
-}
-func (UnimplementedPassKeeperServer) DownloadFile(*DownloadFileRequest, PassKeeper_DownloadFileServer) error {
-	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
-}
-func (UnimplementedPassKeeperServer) mustEmbedUnimplementedPassKeeperServer() {}
-
-// UnsafePassKeeperServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PassKeeperServer will
-// result in compilation errors.
-type UnsafePassKeeperServer interface {
-	mustEmbedUnimplementedPassKeeperServer()
-}
-
-func RegisterPassKeeperServer(s grpc.ServiceRegistrar, srv PassKeeperServer) {
-	s.RegisterService(&PassKeeper_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListEmergencyEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListEmergencyEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListEmergencyEvents(ctx, req.(*ListEmergencyEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeper_AddEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddEntityRequest)
+func _PassKeeper_SetApprovalRequired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApprovalRequiredRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServer).AddEntity(ctx, in)
+		return srv.(PassKeeperServer).SetApprovalRequired(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeper_AddEntity_FullMethodName,
+		FullMethod: PassKeeper_SetApprovalRequired_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServer).AddEntity(ctx, req.(*AddEntityRequest))
+		return srv.(PassKeeperServer).SetApprovalRequired(ctx, req.(*SetApprovalRequiredRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeper_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateEntityRequest)
+func _PassKeeper_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServer).UpdateEntity(ctx, in)
+		return srv.(PassKeeperServer).RequestAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeper_UpdateEntity_FullMethodName,
+		FullMethod: PassKeeper_RequestAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServer).UpdateEntity(ctx, req.(*UpdateEntityRequest))
+		return srv.(PassKeeperServer).RequestAccess(ctx, req.(*RequestAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeper_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteEntityRequest)
+func _PassKeeper_DecideAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServer).DeleteEntity(ctx, in)
+		return srv.(PassKeeperServer).DecideAccessRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeper_DeleteEntity_FullMethodName,
+		FullMethod: PassKeeper_DecideAccessRequest_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServer).DeleteEntity(ctx, req.(*DeleteEntityRequest))
+		return srv.(PassKeeperServer).DecideAccessRequest(ctx, req.(*DecideAccessRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeper_ListEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListEntitiesRequest)
+func _PassKeeper_ListAccessRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServer).ListEntities(ctx, in)
+		return srv.(PassKeeperServer).ListAccessRequests(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeper_ListEntities_FullMethodName,
+		FullMethod: PassKeeper_ListAccessRequests_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServer).ListEntities(ctx, req.(*ListEntitiesRequest))
+		return srv.(PassKeeperServer).ListAccessRequests(ctx, req.(*ListAccessRequestsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeper_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(PassKeeperServer).UploadFile(&passKeeperUploadFileServer{ServerStream: stream})
+func _PassKeeper_WatchAccessRequests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAccessRequestsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PassKeeperServer).WatchAccessRequests(m, &grpc.GenericServerStream[WatchAccessRequestsRequest, WatchAccessRequestsResponse]{ServerStream: stream})
 }
 
-type PassKeeper_UploadFileServer interface {
-	SendAndClose(*UploadFileResponse) error
-	Recv() (*UploadFileRequest, error)
-	grpc.ServerStream
-}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_WatchAccessRequestsServer = grpc.ServerStreamingServer[WatchAccessRequestsResponse]
 
-type passKeeperUploadFileServer struct {
-	grpc.ServerStream
+func _PassKeeper_ListAccessEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServer).ListAccessEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeper_ListAccessEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServer).ListAccessEvents(ctx, req.(*ListAccessEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *passKeeperUploadFileServer) SendAndClose(m *UploadFileResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _PassKeeper_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PassKeeperServer).UploadFile(&grpc.GenericServerStream[UploadFileRequest, UploadFileResponse]{ServerStream: stream})
 }
 
-func (x *passKeeperUploadFileServer) Recv() (*UploadFileRequest, error) {
-	m := new(UploadFileRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_UploadFileServer = grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]
 
 func _PassKeeper_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(DownloadFileRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(PassKeeperServer).DownloadFile(m, &passKeeperDownloadFileServer{ServerStream: stream})
-}
-
-type PassKeeper_DownloadFileServer interface {
-	Send(*DownloadFileResponse) error
-	grpc.ServerStream
+	return srv.(PassKeeperServer).DownloadFile(m, &grpc.GenericServerStream[DownloadFileRequest, DownloadFileResponse]{ServerStream: stream})
 }
 
-type passKeeperDownloadFileServer struct {
-	grpc.ServerStream
-}
-
-func (x *passKeeperDownloadFileServer) Send(m *DownloadFileResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeper_DownloadFileServer = grpc.ServerStreamingServer[DownloadFileResponse]
 
 // PassKeeper_ServiceDesc is the grpc.ServiceDesc for PassKeeper service.
 // It's only intended for direct use with grpc.RegisterService,
@@ -353,12 +2141,219 @@ var PassKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _PassKeeper_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "GetEntity",
+			Handler:    _PassKeeper_GetEntity_Handler,
+		},
 		{
 			MethodName: "ListEntities",
 			Handler:    _PassKeeper_ListEntities_Handler,
 		},
+		{
+			MethodName: "SearchEntities",
+			Handler:    _PassKeeper_SearchEntities_Handler,
+		},
+		{
+			MethodName: "SyncSince",
+			Handler:    _PassKeeper_SyncSince_Handler,
+		},
+		{
+			MethodName: "ListConflicts",
+			Handler:    _PassKeeper_ListConflicts_Handler,
+		},
+		{
+			MethodName: "GetConflict",
+			Handler:    _PassKeeper_GetConflict_Handler,
+		},
+		{
+			MethodName: "ResolveConflict",
+			Handler:    _PassKeeper_ResolveConflict_Handler,
+		},
+		{
+			MethodName: "ShareEntity",
+			Handler:    _PassKeeper_ShareEntity_Handler,
+		},
+		{
+			MethodName: "UnshareEntity",
+			Handler:    _PassKeeper_UnshareEntity_Handler,
+		},
+		{
+			MethodName: "ListEntityShares",
+			Handler:    _PassKeeper_ListEntityShares_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _PassKeeper_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _PassKeeper_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "DeleteOrganization",
+			Handler:    _PassKeeper_DeleteOrganization_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _PassKeeper_AddMember_Handler,
+		},
+		{
+			MethodName: "UpdateMember",
+			Handler:    _PassKeeper_UpdateMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _PassKeeper_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _PassKeeper_ListMembers_Handler,
+		},
+		{
+			MethodName: "CreateVault",
+			Handler:    _PassKeeper_CreateVault_Handler,
+		},
+		{
+			MethodName: "RenameVault",
+			Handler:    _PassKeeper_RenameVault_Handler,
+		},
+		{
+			MethodName: "DeleteVault",
+			Handler:    _PassKeeper_DeleteVault_Handler,
+		},
+		{
+			MethodName: "ListVaults",
+			Handler:    _PassKeeper_ListVaults_Handler,
+		},
+		{
+			MethodName: "SetVaultKeys",
+			Handler:    _PassKeeper_SetVaultKeys_Handler,
+		},
+		{
+			MethodName: "GetVaultKeys",
+			Handler:    _PassKeeper_GetVaultKeys_Handler,
+		},
+		{
+			MethodName: "ListEntityVersions",
+			Handler:    _PassKeeper_ListEntityVersions_Handler,
+		},
+		{
+			MethodName: "RestoreEntityVersion",
+			Handler:    _PassKeeper_RestoreEntityVersion_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _PassKeeper_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreEntity",
+			Handler:    _PassKeeper_RestoreEntity_Handler,
+		},
+		{
+			MethodName: "PurgeEntity",
+			Handler:    _PassKeeper_PurgeEntity_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _PassKeeper_CreateFolder_Handler,
+		},
+		{
+			MethodName: "UpdateFolder",
+			Handler:    _PassKeeper_UpdateFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _PassKeeper_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _PassKeeper_ListFolders_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _PassKeeper_CreateTag_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _PassKeeper_RenameTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _PassKeeper_DeleteTag_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _PassKeeper_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateSend",
+			Handler:    _PassKeeper_CreateSend_Handler,
+		},
+		{
+			MethodName: "OpenSend",
+			Handler:    _PassKeeper_OpenSend_Handler,
+		},
+		{
+			MethodName: "ListSends",
+			Handler:    _PassKeeper_ListSends_Handler,
+		},
+		{
+			MethodName: "DeleteSend",
+			Handler:    _PassKeeper_DeleteSend_Handler,
+		},
+		{
+			MethodName: "InviteEmergencyContact",
+			Handler:    _PassKeeper_InviteEmergencyContact_Handler,
+		},
+		{
+			MethodName: "UpdateEmergencyAccess",
+			Handler:    _PassKeeper_UpdateEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "ListEmergencyContacts",
+			Handler:    _PassKeeper_ListEmergencyContacts_Handler,
+		},
+		{
+			MethodName: "ListEmergencyEvents",
+			Handler:    _PassKeeper_ListEmergencyEvents_Handler,
+		},
+		{
+			MethodName: "SetApprovalRequired",
+			Handler:    _PassKeeper_SetApprovalRequired_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _PassKeeper_RequestAccess_Handler,
+		},
+		{
+			MethodName: "DecideAccessRequest",
+			Handler:    _PassKeeper_DecideAccessRequest_Handler,
+		},
+		{
+			MethodName: "ListAccessRequests",
+			Handler:    _PassKeeper_ListAccessRequests_Handler,
+		},
+		{
+			MethodName: "ListAccessEvents",
+			Handler:    _PassKeeper_ListAccessEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEntities",
+			Handler:       _PassKeeper_StreamEntities_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _PassKeeper_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchAccessRequests",
+			Handler:       _PassKeeper_WatchAccessRequests_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "UploadFile",
 			Handler:       _PassKeeper_UploadFile_Handler,
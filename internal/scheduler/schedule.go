@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule - error if schedule spec can not be parsed
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule calculates the job activation times.
+type Schedule interface {
+	// Next returns the first activation time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// every is a fixed interval schedule aligned to the unix epoch,
+// so all replicas calculate the same activation times.
+type every struct {
+	d time.Duration
+}
+
+// Next returns the next interval boundary after t.
+func (e every) Next(t time.Time) time.Time {
+	return t.Truncate(e.d).Add(e.d)
+}
+
+// cron is a standard five fields cron schedule (minute hour dom month dow).
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// field bounds
+type bounds struct {
+	min, max int
+}
+
+var (
+	minutes = bounds{0, 59}
+	hours   = bounds{0, 23}
+	doms    = bounds{1, 31}
+	months  = bounds{1, 12}
+	dows    = bounds{0, 6}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses the schedule spec.
+//
+// Supported formats are five fields cron expressions ("*/15 * * * *"),
+// predefined descriptors ("@hourly", "@daily", ...) and fixed intervals ("@every 1h30m").
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidSchedule, spec, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("%w: %s: interval must be at least 1s", ErrInvalidSchedule, spec)
+		}
+		return every{d: d}, nil
+	}
+	if d, ok := descriptors[spec]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %s: expected 5 fields, got %d", ErrInvalidSchedule, spec, len(fields))
+	}
+
+	c := &cron{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if c.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, fmt.Errorf("%w: %s: minute: %v", ErrInvalidSchedule, spec, err)
+	}
+	if c.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, fmt.Errorf("%w: %s: hour: %v", ErrInvalidSchedule, spec, err)
+	}
+	if c.dom, err = parseField(fields[2], doms); err != nil {
+		return nil, fmt.Errorf("%w: %s: day of month: %v", ErrInvalidSchedule, spec, err)
+	}
+	if c.month, err = parseField(fields[3], months); err != nil {
+		return nil, fmt.Errorf("%w: %s: month: %v", ErrInvalidSchedule, spec, err)
+	}
+	if c.dow, err = parseField(fields[4], dows); err != nil {
+		return nil, fmt.Errorf("%w: %s: day of week: %v", ErrInvalidSchedule, spec, err)
+	}
+	// the search window covers a leap year, so the expression never matches (like "0 0 30 2 *")
+	if c.Next(time.Unix(0, 0).UTC()).IsZero() {
+		return nil, fmt.Errorf("%w: %s: never matches", ErrInvalidSchedule, spec)
+	}
+	return c, nil
+}
+
+// MustParse parses the schedule spec and panics if it is invalid.
+func MustParse(spec string) Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Next returns the first matching minute after t.
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// any valid expression matches at least once in 5 years (february 29th)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			// truncating to the hour is wrong in zones with not whole hour offsets
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention: if both day of month and day of week
+// are restricted, the day matches when either of them matches.
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// parseField parses comma separated list of values, ranges and steps (1,5-10,*/15).
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			step = s
+			part = part[:i]
+		}
+
+		lo, hi := b.min, b.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			rng := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = parseValue(rng[0], b); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(rng[1], b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := parseValue(part, b)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+
+	from := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		next time.Time
+		err  bool
+	}{
+		{
+			name: "every",
+			spec: "@every 1h",
+			next: time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "hourly",
+			spec: "@hourly",
+			next: time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "daily",
+			spec: "@daily",
+			next: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "step",
+			spec: "*/15 * * * *",
+			next: time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "list and range",
+			spec: "5,10 2-4 * * *",
+			next: time.Date(2024, time.February, 1, 2, 5, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month",
+			spec: "0 0 29 2 *",
+			next: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of week",
+			spec: "30 9 * * 1",
+			next: time.Date(2024, time.February, 5, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or week",
+			spec: "0 12 15 * 5",
+			next: time.Date(2024, time.February, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "wrong fields count",
+			spec: "* * *",
+			err:  true,
+		},
+		{
+			name: "out of range",
+			spec: "60 * * * *",
+			err:  true,
+		},
+		{
+			name: "invalid range",
+			spec: "* 5-2 * * *",
+			err:  true,
+		},
+		{
+			name: "invalid step",
+			spec: "*/0 * * * *",
+			err:  true,
+		},
+		{
+			name: "never matches",
+			spec: "0 0 30 2 *",
+			err:  true,
+		},
+		{
+			name: "invalid interval",
+			spec: "@every 1ms",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, s.Next(from))
+		})
+	}
+}
+
+func TestCron_NextLocation(t *testing.T) {
+
+	// half hour offset zone, like Asia/Kolkata
+	loc := time.FixedZone("IST", 5*60*60+30*60)
+	from := time.Date(2024, time.January, 31, 10, 17, 30, 0, loc)
+
+	tests := []struct {
+		name string
+		spec string
+		next time.Time
+	}{
+		{
+			name: "hour",
+			spec: "0 12 * * *",
+			next: time.Date(2024, time.January, 31, 12, 0, 0, 0, loc),
+		},
+		{
+			name: "hourly",
+			spec: "@hourly",
+			next: time.Date(2024, time.January, 31, 11, 0, 0, 0, loc),
+		},
+		{
+			name: "daily",
+			spec: "@daily",
+			next: time.Date(2024, time.February, 1, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, s.Next(from))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Package scheduler runs the periodic background jobs.
+//
+// Every replica of the server runs the same scheduler, a postgres advisory lock
+// elects the replica that runs each job activation.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// JobStorage is a job storage API
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=JobStorage
+type JobStorage interface {
+	TryLock(ctx context.Context, name string) (func(), bool, error)
+	LastJobRun(ctx context.Context, name string) (*models.JobRun, error)
+	SaveJobRun(ctx context.Context, run *models.JobRun) error
+}
+
+// Func is the job body.
+type Func func(ctx context.Context) error
+
+// Status represents the job status.
+type Status struct {
+	Name     string
+	Schedule string
+	NextRun  time.Time
+	Running  bool
+	LastRun  *models.JobRun
+}
+
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	fn       Func
+
+	mu      sync.Mutex
+	next    time.Time
+	running bool
+	lastRun *models.JobRun
+}
+
+// Scheduler runs the registered jobs.
+type Scheduler struct {
+	js   JobStorage
+	jobs []*job
+	now  func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new Scheduler instance
+func New(js JobStorage) *Scheduler {
+	return &Scheduler{js: js, now: time.Now}
+}
+
+// Add registers the job with the schedule spec, see Parse for supported formats.
+func (s *Scheduler) Add(name string, spec string, fn Func) error {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("job %s already registered", name)
+		}
+	}
+	schedule, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+	s.jobs = append(s.jobs, &job{name: name, spec: spec, schedule: schedule, fn: fn})
+	return nil
+}
+
+// MustAdd registers the job and panics if any error occurs.
+func (s *Scheduler) MustAdd(name string, spec string, fn Func) {
+	if err := s.Add(name, spec, fn); err != nil {
+		panic(err)
+	}
+}
+
+// Start starts the jobs loops.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j *job) {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+	sl.Log.Info("scheduler started", slog.Int("jobs", len(s.jobs)))
+}
+
+// Stop stops the jobs loops and waits for the running jobs to finish.
+func (s *Scheduler) Stop() {
+	sl.Log.Info("stopping scheduler")
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// Status returns the status of all registered jobs.
+func (s *Scheduler) Status() []Status {
+	res := make([]Status, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		res = append(res, Status{
+			Name:     j.name,
+			Schedule: j.spec,
+			NextRun:  j.next,
+			Running:  j.running,
+			LastRun:  j.lastRun,
+		})
+		j.mu.Unlock()
+	}
+	return res
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	for {
+		next := j.schedule.Next(s.now())
+		if next.IsZero() {
+			sl.Log.Error("job has no next activation, stopping job loop", slog.String("job", j.name))
+			return
+		}
+		j.mu.Lock()
+		j.next = next
+		j.mu.Unlock()
+
+		t := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		s.run(ctx, j, next)
+	}
+}
+
+// run runs the job activation scheduled at tick, unless another replica holds the job lock
+// or has already run this activation.
+func (s *Scheduler) run(ctx context.Context, j *job, tick time.Time) {
+
+	lg := sl.Log.With(slog.String("job", j.name), slog.Time("scheduled_at", tick))
+
+	unlock, ok, err := s.js.TryLock(ctx, j.name)
+	if err != nil {
+		lg.Error("failed to acquire job lock", sl.Err(err))
+		return
+	}
+	if !ok {
+		lg.Debug("job is running on another replica")
+		return
+	}
+	defer unlock()
+
+	last, err := s.js.LastJobRun(ctx, j.name)
+	if err != nil && !errors.Is(err, storage.ErrJobRunNotExist) {
+		lg.Error("failed to get last job run", sl.Err(err))
+		return
+	}
+	if last != nil && !last.ScheduledAt.Before(tick) {
+		lg.Debug("job already run by another replica")
+		j.setLastRun(last)
+		return
+	}
+
+	j.mu.Lock()
+	j.running = true
+	j.mu.Unlock()
+
+	lg.Info("running job")
+	run := &models.JobRun{Name: j.name, ScheduledAt: tick, StartedAt: s.now()}
+	if err := j.fn(ctx); err != nil {
+		lg.Error("job failed", sl.Err(err))
+		run.Error = err.Error()
+	}
+	run.FinishedAt = s.now()
+	lg.Info("job finished", slog.Duration("duration", run.FinishedAt.Sub(run.StartedAt)))
+
+	j.mu.Lock()
+	j.running = false
+	j.mu.Unlock()
+	j.setLastRun(run)
+
+	// the run is saved even if the scheduler is stopping
+	if err := s.js.SaveJobRun(context.WithoutCancel(ctx), run); err != nil {
+		lg.Error("failed to save job run", sl.Err(err))
+	}
+}
+
+func (j *job) setLastRun(run *models.JobRun) {
+	j.mu.Lock()
+	j.lastRun = run
+	j.mu.Unlock()
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/scheduler/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestScheduler_run(t *testing.T) {
+
+	unexpected := errors.New("unexpected error")
+	tick := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	type lock struct {
+		ok  bool
+		err error
+	}
+	type last struct {
+		needed bool
+		run    *models.JobRun
+		err    error
+	}
+	type w struct {
+		called bool
+		saved  bool
+		err    string
+	}
+
+	tests := []struct {
+		name   string
+		lock   lock
+		last   last
+		jobErr error
+		w      w
+	}{
+		{
+			name: "first run",
+			lock: lock{ok: true},
+			last: last{needed: true, err: storage.ErrJobRunNotExist},
+			w:    w{called: true, saved: true},
+		},
+		{
+			name: "next run",
+			lock: lock{ok: true},
+			last: last{needed: true, run: &models.JobRun{Name: "job", ScheduledAt: tick.Add(-time.Hour)}},
+			w:    w{called: true, saved: true},
+		},
+		{
+			name:   "job failed",
+			lock:   lock{ok: true},
+			last:   last{needed: true, err: storage.ErrJobRunNotExist},
+			jobErr: unexpected,
+			w:      w{called: true, saved: true, err: unexpected.Error()},
+		},
+		{
+			name: "locked by another replica",
+			lock: lock{ok: false},
+			w:    w{called: false},
+		},
+		{
+			name: "lock error",
+			lock: lock{err: unexpected},
+			w:    w{called: false},
+		},
+		{
+			name: "already run by another replica",
+			lock: lock{ok: true},
+			last: last{needed: true, run: &models.JobRun{Name: "job", ScheduledAt: tick}},
+			w:    w{called: false},
+		},
+		{
+			name: "last run error",
+			lock: lock{ok: true},
+			last: last{needed: true, err: unexpected},
+			w:    w{called: false},
+		},
+	}
+
+	sl.SetupLogger("test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			js := mocks.NewJobStorage(t)
+
+			unlocked := false
+			var unlock func()
+			if tt.lock.ok {
+				unlock = func() { unlocked = true }
+			}
+			js.On("TryLock", mock.Anything, "job").Return(unlock, tt.lock.ok, tt.lock.err)
+			if tt.last.needed {
+				js.On("LastJobRun", mock.Anything, "job").Return(tt.last.run, tt.last.err)
+			}
+			if tt.w.saved {
+				js.On("SaveJobRun", mock.Anything, mock.MatchedBy(func(run *models.JobRun) bool {
+					return run.Name == "job" && run.ScheduledAt.Equal(tick) && run.Error == tt.w.err
+				})).Return(nil)
+			}
+
+			called := false
+			s := New(js)
+			require.NoError(t, s.Add("job", "@hourly", func(ctx context.Context) error {
+				called = true
+				return tt.jobErr
+			}))
+			s.run(ctx, s.jobs[0], tick)
+
+			assert.Equal(t, tt.w.called, called)
+			assert.Equal(t, tt.lock.ok, unlocked)
+			if tt.w.saved {
+				st := s.Status()
+				require.Len(t, st, 1)
+				assert.Equal(t, tt.w.err, st[0].LastRun.Error)
+			}
+		})
+	}
+}
+
+func TestScheduler_Add(t *testing.T) {
+	s := New(nil)
+	require.NoError(t, s.Add("job", "@daily", func(ctx context.Context) error { return nil }))
+	assert.Error(t, s.Add("job", "@daily", func(ctx context.Context) error { return nil }))
+	assert.ErrorIs(t, s.Add("other", "* *", func(ctx context.Context) error { return nil }), ErrInvalidSchedule)
+}
@@ -3,6 +3,8 @@ package auth
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -24,24 +26,86 @@ type UserStorage interface {
 	UserByEmail(ctx context.Context, email string) (*models.User, error)
 }
 
+// DeviceStorage is a storage API for the user`s devices.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=DeviceStorage
+type DeviceStorage interface {
+	AddDevice(ctx context.Context, d *models.Device) (*models.Device, error)
+	GetDevice(ctx context.Context, id int, userID int) (*models.Device, error)
+	LoginDevice(ctx context.Context, id int, userID int) (*models.Device, error)
+	ListDevices(ctx context.Context, userID int) ([]*models.Device, error)
+	DeleteDevice(ctx context.Context, id int, userID int) error
+	AddChallenge(ctx context.Context, nonce []byte, expiresAt time.Time) error
+	UseChallenge(ctx context.Context, nonce []byte) error
+}
+
+// KeyStorage is a storage API for the users` key pairs.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=KeyStorage
+type KeyStorage interface {
+	SetUserKeys(ctx context.Context, k *models.UserKeys) (*models.UserKeys, error)
+	UserKeys(ctx context.Context, userID int) (*models.UserKeys, error)
+	PublicKey(ctx context.Context, email string) (*models.UserKeys, error)
+}
+
+const (
+	// maxDeviceNameLength is the maximum device name length in bytes.
+	maxDeviceNameLength = 100
+
+	// userPublicKeySize is the X25519 public key size in bytes.
+	userPublicKeySize = 32
+
+	// maxWrappedKeyLength is the maximum wrapped private key length in bytes.
+	maxWrappedKeyLength = 1024
+
+	// challengeNonceSize is the login challenge nonce size in bytes.
+	challengeNonceSize = 32
+
+	// challengeTTL is the time the login challenge can be signed and used in.
+	challengeTTL = 5 * time.Minute
+)
+
 var (
 	// ErrUserAlreadyExists - user already exists error.
 	ErrUserAlreadyExists = errors.New("user already exists")
 
 	// ErrInvalidCredentials - invalid credentials error.
 	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrInvalidDevice - error if the device name is empty or too long or the public key is malformed.
+	ErrInvalidDevice = errors.New("invalid device")
+
+	// ErrDeviceNotFound - error if the device does not exist or belongs to another user.
+	ErrDeviceNotFound = errors.New("device not found")
+
+	// ErrInvalidChallenge - error if the login challenge is unknown, expired or already used.
+	ErrInvalidChallenge = errors.New("invalid challenge")
+
+	// ErrInvalidSignature - error if the challenge signature is not made by the device key.
+	ErrInvalidSignature = errors.New("invalid device signature")
+
+	// ErrInvalidKeys - error if the public key or the wrapped private key is malformed.
+	ErrInvalidKeys = errors.New("invalid keys")
+
+	// ErrKeysNotFound - error if the user has not uploaded the key pair.
+	ErrKeysNotFound = errors.New("keys not found")
+
+	// ErrPublicKeyMismatch - error if the uploaded public key differs from the registered one.
+	ErrPublicKeyMismatch = errors.New("public key can not be changed")
 )
 
 // Auth consists the authentication fields.
 type Auth struct {
-	userStorage UserStorage
-	tokenTTL    time.Duration
-	secret      string
+	userStorage   UserStorage
+	deviceStorage DeviceStorage
+	keyStorage    KeyStorage
+	tokenTTL      time.Duration
+	secret        string
 }
 
 // New creates the Auth instance.
-func New(us UserStorage, secret string) *Auth {
-	return &Auth{userStorage: us, secret: secret}
+func New(us UserStorage, ds DeviceStorage, ks KeyStorage, secret string) *Auth {
+	return &Auth{userStorage: us, deviceStorage: ds, keyStorage: ks, secret: secret}
 }
 
 // CreateUser creates a new user with provided email and password.
@@ -69,32 +133,206 @@ func (a *Auth) CreateUser(ctx context.Context, email string, pass string) (*mode
 	return user, nil
 }
 
-// Login logs in user with provided email and password.
-func (a *Auth) Login(ctx context.Context, email string, pass string) (string, error) {
+// Challenge returns a new login challenge nonce and the time it expires at.
+// The device signs the nonce with its private key to log in.
+func (a *Auth) Challenge(ctx context.Context) ([]byte, time.Time, error) {
+	sl.Log.Info("creating login challenge")
+	nonce := make([]byte, challengeNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		sl.Log.Error("failed to generate nonce", sl.Err(err))
+		return nil, time.Time{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	expiresAt := time.Now().Add(challengeTTL)
+	if err := a.deviceStorage.AddChallenge(ctx, nonce, expiresAt); err != nil {
+		sl.Log.Error("failed to add challenge", sl.Err(err))
+		return nil, time.Time{}, fmt.Errorf("failed to add challenge: %w", err)
+	}
+	return nonce, expiresAt, nil
+}
+
+// Login logs in user with provided email and password on the device and returns the token bound to the device.
+// The device is registered with its name and public key unless its id is set, the registered device
+// has to belong to the user. The proof has to be the device signature over an unused login challenge.
+func (a *Auth) Login(ctx context.Context, email string, pass string, d *models.Device, p *models.DeviceProof) (string, *models.Device, error) {
 
 	lg := sl.Log.With(slog.String("email", email))
 	lg.Info("logging in user")
 
+	if d.ID == 0 {
+		if err := validateDevice(d); err != nil {
+			lg.Info("invalid device", sl.Err(err))
+			return "", nil, err
+		}
+	}
 	user, err := a.userStorage.UserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotExist) {
 			lg.Info("invalid credentials")
-			return "", ErrInvalidCredentials
+			return "", nil, ErrInvalidCredentials
 		}
 		lg.Error("failed to get user", sl.Err(err))
-		return "", fmt.Errorf("failed to get user: %w", err)
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	err = bcrypt.CompareHashAndPassword(user.PassHash, []byte(pass))
 	if err != nil {
 		lg.Info("invalid credentials")
-		return "", ErrInvalidCredentials
+		return "", nil, ErrInvalidCredentials
+	}
+	if err := a.verifyDevice(ctx, user.ID, d, p); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidChallenge), errors.Is(err, ErrInvalidSignature):
+			lg.Info("device verification failed", sl.Err(err))
+			return "", nil, err
+		case errors.Is(err, storage.ErrDeviceNotExist):
+			lg.Info("device not found", slog.Int("device", d.ID))
+			return "", nil, ErrDeviceNotFound
+		}
+		lg.Error("failed to verify device", sl.Err(err))
+		return "", nil, fmt.Errorf("failed to verify device: %w", err)
 	}
-	token, err := jwt.NewToken(user, a.secret)
+	device, err := a.loginDevice(ctx, user.ID, d)
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceNotExist) {
+			lg.Info("device not found", slog.Int("device", d.ID))
+			return "", nil, ErrDeviceNotFound
+		}
+		lg.Error("failed to login device", sl.Err(err))
+		return "", nil, fmt.Errorf("failed to login device: %w", err)
+	}
+	token, err := jwt.NewToken(user, device.ID, a.secret)
 	if err != nil {
 		lg.Error("failed to create token", sl.Err(err))
-		return "", fmt.Errorf("failed to create token: %w", err)
+		return "", nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	lg.Info("user logged in", slog.Int("device", device.ID))
+	return token, device, nil
+}
+
+// verifyDevice uses the login challenge and checks its signature by the registered device key
+// or by the key of the new device.
+func (a *Auth) verifyDevice(ctx context.Context, userID int, d *models.Device, p *models.DeviceProof) error {
+	if p == nil || len(p.Nonce) == 0 {
+		return ErrInvalidChallenge
+	}
+	if err := a.deviceStorage.UseChallenge(ctx, p.Nonce); err != nil {
+		if errors.Is(err, storage.ErrChallengeNotExist) {
+			return ErrInvalidChallenge
+		}
+		return err
+	}
+	key := d.PublicKey
+	if d.ID != 0 {
+		registered, err := a.deviceStorage.GetDevice(ctx, d.ID, userID)
+		if err != nil {
+			return err
+		}
+		key = registered.PublicKey
+	}
+	if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, p.Nonce, p.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (a *Auth) loginDevice(ctx context.Context, userID int, d *models.Device) (*models.Device, error) {
+	if d.ID != 0 {
+		return a.deviceStorage.LoginDevice(ctx, d.ID, userID)
+	}
+	return a.deviceStorage.AddDevice(ctx, &models.Device{UserID: userID, Name: d.Name, PublicKey: d.PublicKey})
+}
+
+func validateDevice(d *models.Device) error {
+	if d.Name == "" || len(d.Name) > maxDeviceNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d bytes long", ErrInvalidDevice, maxDeviceNameLength)
+	}
+	if len(d.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: public key must be %d bytes long", ErrInvalidDevice, ed25519.PublicKeySize)
+	}
+	return nil
+}
+
+// Devices returns the user`s devices.
+func (a *Auth) Devices(ctx context.Context, userID int) ([]*models.Device, error) {
+	sl.Log.Info("getting devices")
+	devices, err := a.deviceStorage.ListDevices(ctx, userID)
+	if err != nil {
+		sl.Log.Error("failed to list devices", sl.Err(err))
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	return devices, nil
+}
+
+// RemoveDevice removes the user`s device, the device tokens are no longer accepted.
+func (a *Auth) RemoveDevice(ctx context.Context, id int, userID int) error {
+
+	lg := sl.Log.With(slog.Int("device", id))
+	lg.Info("removing device")
+
+	if err := a.deviceStorage.DeleteDevice(ctx, id, userID); err != nil {
+		if errors.Is(err, storage.ErrDeviceNotExist) {
+			lg.Info("device not found")
+			return ErrDeviceNotFound
+		}
+		lg.Error("failed to remove device", sl.Err(err))
+		return fmt.Errorf("failed to remove device: %w", err)
+	}
+
+	lg.Info("device removed")
+	return nil
+}
+
+// SetKeys registers the user`s key pair. The registered key pair only gets the private key re-wrapped,
+// e.g. after the master password change, its public key can not be changed.
+func (a *Auth) SetKeys(ctx context.Context, k *models.UserKeys) (*models.UserKeys, error) {
+
+	lg := sl.Log.With(slog.Int("user", k.UserID))
+	lg.Info("setting user keys")
+
+	if len(k.PublicKey) != userPublicKeySize {
+		return nil, fmt.Errorf("%w: public key must be %d bytes long", ErrInvalidKeys, userPublicKeySize)
+	}
+	if len(k.WrappedPrivateKey) == 0 || len(k.WrappedPrivateKey) > maxWrappedKeyLength {
+		return nil, fmt.Errorf("%w: wrapped private key must be 1 to %d bytes long", ErrInvalidKeys, maxWrappedKeyLength)
+	}
+	res, err := a.keyStorage.SetUserKeys(ctx, k)
+	if err != nil {
+		if errors.Is(err, storage.ErrPublicKeyMismatch) {
+			lg.Info("public key mismatch")
+			return nil, ErrPublicKeyMismatch
+		}
+		lg.Error("failed to set user keys", sl.Err(err))
+		return nil, fmt.Errorf("failed to set user keys: %w", err)
 	}
 
-	lg.Info("user logged in")
-	return token, nil
+	lg.Info("user keys set")
+	return res, nil
+}
+
+// Keys returns the user`s key pair with the wrapped private key.
+func (a *Auth) Keys(ctx context.Context, userID int) (*models.UserKeys, error) {
+	sl.Log.Info("getting user keys")
+	k, err := a.keyStorage.UserKeys(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserKeysNotExist) {
+			return nil, ErrKeysNotFound
+		}
+		sl.Log.Error("failed to get user keys", sl.Err(err))
+		return nil, fmt.Errorf("failed to get user keys: %w", err)
+	}
+	return k, nil
+}
+
+// PublicKey looks up the public key of the user with the email in the key directory.
+func (a *Auth) PublicKey(ctx context.Context, email string) (*models.UserKeys, error) {
+	sl.Log.Info("getting public key")
+	k, err := a.keyStorage.PublicKey(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserKeysNotExist) {
+			return nil, ErrKeysNotFound
+		}
+		sl.Log.Error("failed to get public key", sl.Err(err))
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+	return k, nil
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"testing"
 	"time"
@@ -22,6 +23,13 @@ const (
 	secret = "supersecret"
 )
 
+var publicKey, privateKey, _ = ed25519.GenerateKey(nil)
+
+// signedProof returns the proof of the login challenge signed by the device key.
+func signedProof(nonce string, key ed25519.PrivateKey) *models.DeviceProof {
+	return &models.DeviceProof{Nonce: []byte(nonce), Signature: ed25519.Sign(key, []byte(nonce))}
+}
+
 func newUser(email string, pass string) *models.User {
 	passHash, _ := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.DefaultCost)
 	return &models.User{
@@ -112,14 +120,22 @@ func TestAuth_Login(t *testing.T) {
 			sl.SetupLogger("test")
 			ms := mocks.NewUserStorage(t)
 			ms.On("UserByEmail", mock.Anything, tt.f.email).Return(tt.sm.user, tt.sm.err)
-			a := New(ms, secret)
-			token, err := a.Login(context.Background(), tt.f.email, tt.f.password)
+			ds := mocks.NewDeviceStorage(t)
+			if tt.w.checkToken {
+				ds.On("UseChallenge", mock.Anything, []byte("nonce")).Return(nil)
+				ds.On("AddDevice", mock.Anything, &models.Device{UserID: 1, Name: "laptop", PublicKey: publicKey}).
+					Return(&models.Device{ID: 3, UserID: 1, Name: "laptop", PublicKey: publicKey}, nil)
+			}
+			a := New(ms, ds, nil, secret)
+			token, _, err := a.Login(context.Background(), tt.f.email, tt.f.password,
+				&models.Device{Name: "laptop", PublicKey: publicKey}, signedProof("nonce", privateKey))
 			require.ErrorIs(t, err, tt.w.err)
 			if tt.w.checkToken {
 				require.NotEqual(t, "", token)
-				email, _, err := jwt.VerifyToken(token, secret)
+				email, _, did, err := jwt.VerifyToken(token, secret)
 				require.NoError(t, err)
 				assert.Equal(t, tt.f.email, email)
+				assert.Equal(t, 3, did)
 			}
 		})
 	}
@@ -198,7 +214,7 @@ func TestAuth_CreateUser(t *testing.T) {
 			sl.SetupLogger("test")
 			ms := mocks.NewUserStorage(t)
 			ms.On("CreateUser", mock.Anything, tt.f.email, mock.Anything).Return(tt.sm.user, tt.sm.err)
-			a := New(ms, secret)
+			a := New(ms, nil, nil, secret)
 			usr, err := a.CreateUser(context.Background(), tt.f.email, tt.f.pass)
 			if tt.w.user != nil {
 				assert.Equal(t, tt.w.user.Email, usr.Email)
@@ -209,3 +225,206 @@ func TestAuth_CreateUser(t *testing.T) {
 	}
 
 }
+
+func TestAuth_LoginDevice(t *testing.T) {
+
+	user := newUser("test@test.com", "password")
+	unexpected := errors.New("unexpected")
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+
+	tests := []struct {
+		name      string
+		device    *models.Device
+		proof     *models.DeviceProof
+		useErr    error // the error of the challenge use, the challenge is not used if the device is invalid
+		getErr    error // the error of the registered device lookup
+		loginErr  error // the error of the registered device login
+		wantLogin bool
+		wantErr   error
+	}{
+		{
+			name:      "registered device",
+			device:    &models.Device{ID: 3},
+			proof:     signedProof("nonce", privateKey),
+			wantLogin: true,
+		},
+		{
+			name:    "device of another user",
+			device:  &models.Device{ID: 4},
+			proof:   signedProof("nonce", privateKey),
+			getErr:  storage.ErrDeviceNotExist,
+			wantErr: ErrDeviceNotFound,
+		},
+		{
+			name:      "unexpected error",
+			device:    &models.Device{ID: 3},
+			proof:     signedProof("nonce", privateKey),
+			loginErr:  unexpected,
+			wantLogin: true,
+			wantErr:   unexpected,
+		},
+		{
+			name:    "challenge used",
+			device:  &models.Device{ID: 3},
+			proof:   signedProof("nonce", privateKey),
+			useErr:  storage.ErrChallengeNotExist,
+			wantErr: ErrInvalidChallenge,
+		},
+		{
+			name:    "signed by another key",
+			device:  &models.Device{ID: 3},
+			proof:   signedProof("nonce", otherKey),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "new device signed by another key",
+			device:  &models.Device{Name: "laptop", PublicKey: publicKey},
+			proof:   signedProof("nonce", otherKey),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "no proof",
+			device:  &models.Device{ID: 3},
+			wantErr: ErrInvalidChallenge,
+		},
+		{
+			name:    "no name",
+			device:  &models.Device{PublicKey: publicKey},
+			wantErr: ErrInvalidDevice,
+		},
+		{
+			name:    "malformed public key",
+			device:  &models.Device{Name: "laptop", PublicKey: []byte("key")},
+			wantErr: ErrInvalidDevice,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := mocks.NewUserStorage(t)
+			ds := mocks.NewDeviceStorage(t)
+			if !errors.Is(tt.wantErr, ErrInvalidDevice) {
+				ms.On("UserByEmail", mock.Anything, user.Email).Return(user, nil)
+			}
+			if tt.proof != nil && !errors.Is(tt.wantErr, ErrInvalidDevice) {
+				ds.On("UseChallenge", mock.Anything, tt.proof.Nonce).Return(tt.useErr)
+				if tt.useErr == nil && tt.device.ID != 0 {
+					var d *models.Device
+					if tt.getErr == nil {
+						d = &models.Device{ID: tt.device.ID, UserID: user.ID, PublicKey: publicKey}
+					}
+					ds.On("GetDevice", mock.Anything, tt.device.ID, user.ID).Return(d, tt.getErr)
+				}
+			}
+			if tt.wantLogin {
+				var d *models.Device
+				if tt.loginErr == nil {
+					d = &models.Device{ID: tt.device.ID, UserID: user.ID}
+				}
+				ds.On("LoginDevice", mock.Anything, tt.device.ID, user.ID).Return(d, tt.loginErr)
+			}
+			a := New(ms, ds, nil, secret)
+			token, d, err := a.Login(context.Background(), user.Email, "password", tt.device, tt.proof)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			_, _, did, err := jwt.VerifyToken(token, secret)
+			require.NoError(t, err)
+			assert.Equal(t, tt.device.ID, did)
+			assert.Equal(t, tt.device.ID, d.ID)
+		})
+	}
+}
+
+func TestAuth_Challenge(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ds := mocks.NewDeviceStorage(t)
+	ds.On("AddChallenge", mock.Anything, mock.MatchedBy(func(n []byte) bool {
+		return len(n) == challengeNonceSize
+	}), mock.Anything).Return(nil).Once()
+
+	a := New(nil, ds, nil, secret)
+	nonce, expiresAt, err := a.Challenge(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, nonce, challengeNonceSize)
+	assert.WithinDuration(t, time.Now().Add(challengeTTL), expiresAt, time.Minute)
+}
+
+func TestAuth_RemoveDevice(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ds := mocks.NewDeviceStorage(t)
+	ds.On("DeleteDevice", mock.Anything, 3, 1).Return(nil).Once()
+	ds.On("DeleteDevice", mock.Anything, 4, 1).Return(storage.ErrDeviceNotExist).Once()
+
+	a := New(nil, ds, nil, secret)
+	assert.NoError(t, a.RemoveDevice(context.Background(), 3, 1))
+	assert.ErrorIs(t, a.RemoveDevice(context.Background(), 4, 1), ErrDeviceNotFound)
+}
+
+func TestAuth_SetKeys(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	pub := make([]byte, 32)
+	tests := []struct {
+		name       string
+		keys       *models.UserKeys
+		storageErr error
+		wantErr    error
+	}{
+		{name: "ok", keys: &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: []byte("wrapped")}},
+		{name: "short public key", keys: &models.UserKeys{UserID: 1, PublicKey: pub[:16], WrappedPrivateKey: []byte("wrapped")}, wantErr: ErrInvalidKeys},
+		{name: "empty private key", keys: &models.UserKeys{UserID: 1, PublicKey: pub}, wantErr: ErrInvalidKeys},
+		{name: "long private key", keys: &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: make([]byte, 1025)}, wantErr: ErrInvalidKeys},
+		{
+			name:       "public key changed",
+			keys:       &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: []byte("wrapped")},
+			storageErr: storage.ErrPublicKeyMismatch,
+			wantErr:    ErrPublicKeyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := mocks.NewKeyStorage(t)
+			if tt.wantErr == nil || tt.storageErr != nil {
+				var res *models.UserKeys
+				if tt.storageErr == nil {
+					res = &models.UserKeys{UserID: 1, Email: "test@example.com", PublicKey: pub}
+				}
+				ks.On("SetUserKeys", mock.Anything, tt.keys).Return(res, tt.storageErr).Once()
+			}
+			a := New(nil, nil, ks, secret)
+			k, err := a.SetKeys(context.Background(), tt.keys)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "test@example.com", k.Email)
+		})
+	}
+}
+
+func TestAuth_PublicKey(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	ks := mocks.NewKeyStorage(t)
+	ks.On("PublicKey", mock.Anything, "friend@example.com").Return(&models.UserKeys{UserID: 2, Email: "friend@example.com"}, nil).Once()
+	ks.On("PublicKey", mock.Anything, "unknown@example.com").Return(nil, storage.ErrUserKeysNotExist).Once()
+
+	a := New(nil, nil, ks, secret)
+	k, err := a.PublicKey(context.Background(), "friend@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, k.UserID)
+	_, err = a.PublicKey(context.Background(), "unknown@example.com")
+	assert.ErrorIs(t, err, ErrKeysNotFound)
+}
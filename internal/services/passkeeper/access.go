@@ -0,0 +1,238 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const (
+	// maxViewWindow is the maximum time the approved request grants the read access for.
+	maxViewWindow = 24 * time.Hour
+
+	// maxReasonLength is the maximum access request reason length in bytes.
+	maxReasonLength = 500
+)
+
+var (
+	// ErrApprovalRequired - matches the storage error if the entity is read without the approved access request
+	ErrApprovalRequired = storage.ErrApprovalRequired
+
+	// ErrApprovalNotRequired - error if the access is requested to the entity not requiring approval or by its approver
+	ErrApprovalNotRequired = errors.New("approval is not required")
+
+	// ErrInvalidReason - error if the access request reason is too long
+	ErrInvalidReason = errors.New("invalid reason")
+
+	// ErrInvalidViewWindow - error if the view window of the approved request is not positive or too long
+	ErrInvalidViewWindow = errors.New("invalid view window")
+
+	// ErrAccessRequestNotFound - matches the storage error if the request does not exist or the user does not approve it
+	ErrAccessRequestNotFound = storage.ErrAccessRequestNotExist
+
+	// ErrAccessRequestDecided - matches the storage error if the request has been approved or denied already
+	ErrAccessRequestDecided = storage.ErrAccessRequestDecided
+)
+
+// AccessStorage is a storage API for the requests to read the entities requiring approval and their audit trail
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=AccessStorage
+type AccessStorage interface {
+	SetApprovalRequired(ctx context.Context, t models.EntityType, id int, actorID int, required bool) error
+	AddAccessRequest(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error)
+	GetAccessRequest(ctx context.Context, id int) (*models.AccessRequest, error)
+	DecideAccessRequest(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error)
+	ListAccessRequests(ctx context.Context, userID int, now time.Time) ([]*models.AccessRequest, error)
+	ActiveAccessRequest(ctx context.Context, t models.EntityType, id int, userID int, now time.Time) (*models.AccessRequest, error)
+	AddAccessEvent(ctx context.Context, ev *models.AccessEvent) error
+	ListAccessEvents(ctx context.Context, t models.EntityType, id int) ([]*models.AccessEvent, error)
+}
+
+// SetApprovalRequired sets whether the reads of the entity by other users than its approvers have to be approved,
+// the change is audited.
+func (k *Keeper) SetApprovalRequired(ctx context.Context, id int, userID int, t models.EntityType, required bool) error {
+
+	lg := sl.Log.With(slog.String("type", string(t)), slog.Int("id", id))
+	lg.Info("setting approval required", slog.Bool("required", required))
+
+	if err := k.requireApprover(ctx, id, userID, t); err != nil {
+		return err
+	}
+	return k.acs.SetApprovalRequired(ctx, t, id, userID, required)
+}
+
+// RequestAccess requests the read access to the entity requiring approval, the approvers are notified.
+func (k *Keeper) RequestAccess(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error) {
+
+	lg := sl.Log.With(slog.String("type", string(r.Type)), slog.Int("id", r.EntityID))
+	lg.Info("requesting access")
+
+	if !r.Type.Known() {
+		return nil, ErrUnknownEntity
+	}
+	if len(r.Reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: reason must be up to %d bytes long", ErrInvalidReason, maxReasonLength)
+	}
+	e, err := k.es.GetEntity(ctx, r.Type, r.EntityID, r.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	if !e.RequiresApproval || e.Approver {
+		lg.Info("approval is not required")
+		return nil, ErrApprovalNotRequired
+	}
+	res, err := k.acs.AddAccessRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Info("requested access", slog.Int("request", res.ID))
+	return res, nil
+}
+
+// DecideAccessRequest approves or denies the pending request to the entity the user approves the reads of.
+// The approved request grants the read access for the view window.
+func (k *Keeper) DecideAccessRequest(ctx context.Context, id int, userID int, approve bool, window time.Duration) (*models.AccessRequest, error) {
+
+	lg := sl.Log.With(slog.Int("request", id))
+	lg.Info("deciding access request", slog.Bool("approve", approve))
+
+	if approve && (window <= 0 || window > maxViewWindow) {
+		return nil, fmt.Errorf("%w: view window must be up to %s", ErrInvalidViewWindow, maxViewWindow)
+	}
+	r, err := k.acs.GetAccessRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.requireApprover(ctx, r.EntityID, userID, r.Type); err != nil {
+		if errors.Is(err, ErrEntityNotFound) {
+			return nil, ErrAccessRequestNotFound
+		}
+		return nil, err
+	}
+	if r.Status != models.AccessPending {
+		return nil, ErrAccessRequestDecided
+	}
+	decision := &models.AccessRequest{ID: id, ApproverID: userID, Status: models.AccessDenied}
+	if approve {
+		decision.Status = models.AccessApproved
+		decision.ExpiresAt = time.Now().Add(window)
+	}
+	res, err := k.acs.DecideAccessRequest(ctx, decision)
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Info("decided access request", slog.String("status", string(res.Status)))
+	return res, nil
+}
+
+// AccessRequests returns the pending requests the user approves and the user`s own requests but the expired ones.
+func (k *Keeper) AccessRequests(ctx context.Context, userID int) ([]*models.AccessRequest, error) {
+	sl.Log.Info("getting access requests")
+	return k.acs.ListAccessRequests(ctx, userID, time.Now())
+}
+
+// WatchAccessRequests sends the user`s access requests as AccessRequests returns them every time they change
+// until ctx is done. The first send is made right away, the requests are resent on every poll interval
+// as the view windows expire.
+func (k *Keeper) WatchAccessRequests(ctx context.Context, userID int, send func(requests []*models.AccessRequest) error) error {
+
+	sl.Log.Info("watching access requests")
+
+	// the feed is subscribed before the requests are read to not miss the changes in between
+	signals, unsubscribe := k.af.Subscribe(userID)
+	defer unsubscribe()
+
+	poll := time.NewTicker(watchPollInterval)
+	defer poll.Stop()
+	for {
+		requests, err := k.AccessRequests(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := send(requests); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-signals:
+			if !ok {
+				return ErrWatchStopped
+			}
+		case <-poll.C:
+		}
+	}
+}
+
+// AccessEvents returns the audit trail of the requests, decisions and views of the entity the user approves.
+func (k *Keeper) AccessEvents(ctx context.Context, id int, userID int, t models.EntityType) ([]*models.AccessEvent, error) {
+	if err := k.requireApprover(ctx, id, userID, t); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("getting access events", slog.String("type", string(t)), slog.Int("id", id))
+	return k.acs.ListAccessEvents(ctx, t, id)
+}
+
+// requireApprover checks that the user approves the reads of the entity.
+func (k *Keeper) requireApprover(ctx context.Context, id int, userID int, t models.EntityType) error {
+	if !t.Known() {
+		return ErrUnknownEntity
+	}
+	e, err := k.es.GetEntity(ctx, t, id, userID)
+	if err != nil {
+		return err
+	}
+	if !e.Approver {
+		sl.Log.Info("user is not the approver")
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// approveRead checks that the user reads the entity requiring approval within the view window of the approved
+// request and audits the view. The approvers read the entity without the request, their views are audited too.
+func (k *Keeper) approveRead(ctx context.Context, e *models.Entity, userID int) error {
+	if !e.RequiresApproval {
+		return nil
+	}
+	ev := &models.AccessEvent{Type: e.Type, EntityID: e.ID, ActorID: userID, Action: models.AccessViewed}
+	if !e.Approver {
+		r, err := k.acs.ActiveAccessRequest(ctx, e.Type, e.ID, userID, time.Now())
+		if err != nil {
+			if errors.Is(err, storage.ErrAccessRequestNotExist) {
+				sl.Log.Info("read is not approved", slog.String("type", string(e.Type)), slog.Int("id", e.ID))
+				return ErrApprovalRequired
+			}
+			return err
+		}
+		ev.RequestID = r.ID
+	}
+	return k.acs.AddAccessEvent(ctx, ev)
+}
+
+// sealUnapproved seals the entities requiring approval the user does not approve, they are read one by one
+// with the approved request only.
+func sealUnapproved(entities ...*models.Entity) {
+	for _, e := range entities {
+		if e.RequiresApproval && !e.Approver {
+			e.Seal()
+		}
+	}
+}
+
+// sealChanges seals the changed entities requiring approval the user does not approve.
+func sealChanges(changes []*models.Change) {
+	for _, c := range changes {
+		if c.Entity != nil {
+			sealUnapproved(c.Entity)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_GetRequiringApproval(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		entity    *models.Entity
+		active    *models.AccessRequest // the approved request of the user, not looked up if nil and the user approves
+		wantEvent *models.AccessEvent
+		wantErr   error
+	}{
+		{
+			name:   "not requiring approval",
+			entity: &models.Entity{ID: 3, Type: models.TypePassword, Password: "secret"},
+		},
+		{
+			name:      "approver",
+			entity:    &models.Entity{ID: 3, Type: models.TypePassword, Password: "secret", RequiresApproval: true, Approver: true},
+			wantEvent: &models.AccessEvent{Type: models.TypePassword, EntityID: 3, ActorID: 2, Action: models.AccessViewed},
+		},
+		{
+			name:      "approved request",
+			entity:    &models.Entity{ID: 3, Type: models.TypePassword, Password: "secret", RequiresApproval: true},
+			active:    &models.AccessRequest{ID: 7},
+			wantEvent: &models.AccessEvent{Type: models.TypePassword, EntityID: 3, RequestID: 7, ActorID: 2, Action: models.AccessViewed},
+		},
+		{
+			name:    "not approved",
+			entity:  &models.Entity{ID: 3, Type: models.TypePassword, Password: "secret", RequiresApproval: true},
+			active:  &models.AccessRequest{},
+			wantErr: ErrApprovalRequired,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			acs := mocks.NewAccessStorage(t)
+			es.On("GetEntity", mock.Anything, models.TypePassword, 3, 2).Return(tt.entity, nil).Once()
+			if tt.active != nil {
+				if tt.active.ID == 0 {
+					acs.On("ActiveAccessRequest", mock.Anything, models.TypePassword, 3, 2, mock.Anything).
+						Return(nil, storage.ErrAccessRequestNotExist).Once()
+				} else {
+					acs.On("ActiveAccessRequest", mock.Anything, models.TypePassword, 3, 2, mock.Anything).Return(tt.active, nil).Once()
+				}
+			}
+			if tt.wantEvent != nil {
+				acs.On("AddAccessEvent", mock.Anything, tt.wantEvent).Return(nil).Once()
+			}
+			k := New(Deps{Entities: es, Access: acs}, "")
+			e, err := k.Get(context.Background(), 3, 2, models.TypePassword)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "secret", e.Password)
+		})
+	}
+}
+
+func TestKeeper_ListSealsUnapproved(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	es := mocks.NewEntityStorage(t)
+	es.On("ListEntities", mock.Anything, 2, mock.Anything).Return([]*models.Entity{
+		{ID: 1, Type: models.TypePassword, Login: "admin", Password: "secret", RequiresApproval: true,
+			Custom: []models.CustomField{{Name: "token", Type: models.CustomHidden, Value: "abc"}}},
+		{ID: 2, Type: models.TypePassword, Login: "admin", Password: "secret", RequiresApproval: true, Approver: true},
+		{ID: 3, Type: models.TypePassword, Login: "admin", Password: "secret"},
+	}, nil).Once()
+
+	k := New(Deps{Entities: es}, "")
+	res, _, err := k.List(context.Background(), 2, models.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	assert.True(t, res[0].Sealed)
+	assert.Equal(t, "", res[0].Password)
+	assert.Equal(t, "admin", res[0].Login)
+	assert.Equal(t, []models.CustomField{{Name: "token", Type: models.CustomHidden}}, res[0].Custom)
+	assert.False(t, res[1].Sealed)
+	assert.Equal(t, "secret", res[1].Password)
+	assert.False(t, res[2].Sealed)
+}
+
+func TestKeeper_TrashSealsUnapproved(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	es := mocks.NewEntityStorage(t)
+	es.On("ListEntities", mock.Anything, 2, mock.Anything).Return([]*models.Entity{
+		{ID: 1, Type: models.TypeText, Text: "secret", VaultID: 3, RequiresApproval: true},
+	}, nil).Once()
+
+	k := New(Deps{Entities: es}, "")
+	res, err := k.Trash(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.True(t, res[0].Sealed)
+	assert.Equal(t, "", res[0].Text)
+}
+
+func TestKeeper_SyncSealsUnapproved(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	cs := mocks.NewChangeStorage(t)
+	cs.On("ChangeLog", mock.Anything, 2).Return(models.ChangeLog{Last: 2}, nil).Once()
+	cs.On("Changes", mock.Anything, 2, int64(0), syncPageSize+1).Return([]*models.Change{
+		{Seq: 1, Kind: models.ChangeUpdated, Type: models.TypeText, ID: 1,
+			Entity: &models.Entity{ID: 1, Type: models.TypeText, Text: "secret", RequiresApproval: true}},
+		{Seq: 2, Kind: models.ChangeDeleted, Type: models.TypeText, ID: 2},
+	}, nil).Once()
+
+	k := New(Deps{Changes: cs}, "")
+	res, err := k.SyncSince(context.Background(), 2, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Changes, 2)
+	assert.True(t, res.Changes[0].Entity.Sealed)
+	assert.Equal(t, "", res.Changes[0].Entity.Text)
+	assert.Nil(t, res.Changes[1].Entity)
+}
+
+func TestKeeper_RequestAccess(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		entity  *models.Entity
+		reason  string
+		wantErr error
+	}{
+		{name: "ok", entity: &models.Entity{ID: 3, RequiresApproval: true}, reason: "incident"},
+		{name: "not requiring approval", entity: &models.Entity{ID: 3}, wantErr: ErrApprovalNotRequired},
+		{name: "approver", entity: &models.Entity{ID: 3, RequiresApproval: true, Approver: true}, wantErr: ErrApprovalNotRequired},
+		{name: "long reason", reason: string(make([]byte, maxReasonLength+1)), wantErr: ErrInvalidReason},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			acs := mocks.NewAccessStorage(t)
+			if tt.entity != nil {
+				es.On("GetEntity", mock.Anything, models.TypePassword, 3, 2).Return(tt.entity, nil).Once()
+			}
+			r := &models.AccessRequest{Type: models.TypePassword, EntityID: 3, RequesterID: 2, Reason: tt.reason}
+			if tt.wantErr == nil {
+				acs.On("AddAccessRequest", mock.Anything, r).Return(&models.AccessRequest{ID: 7, Status: models.AccessPending}, nil).Once()
+			}
+			k := New(Deps{Entities: es, Access: acs}, "")
+			res, err := k.RequestAccess(context.Background(), r)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, res.ID)
+		})
+	}
+}
+
+func TestKeeper_DecideAccessRequest(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		approver bool
+		status   models.AccessStatus
+		approve  bool
+		window   time.Duration
+		wantErr  error
+	}{
+		{name: "approve", approver: true, status: models.AccessPending, approve: true, window: time.Hour},
+		{name: "deny", approver: true, status: models.AccessPending},
+		{name: "no window", approver: true, status: models.AccessPending, approve: true, wantErr: ErrInvalidViewWindow},
+		{name: "long window", approver: true, status: models.AccessPending, approve: true, window: maxViewWindow + time.Hour, wantErr: ErrInvalidViewWindow},
+		{name: "not approver", status: models.AccessPending, approve: true, window: time.Hour, wantErr: ErrPermissionDenied},
+		{name: "decided", approver: true, status: models.AccessDenied, approve: true, window: time.Hour, wantErr: ErrAccessRequestDecided},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			acs := mocks.NewAccessStorage(t)
+			if tt.wantErr != ErrInvalidViewWindow {
+				acs.On("GetAccessRequest", mock.Anything, 7).
+					Return(&models.AccessRequest{ID: 7, Type: models.TypePassword, EntityID: 3, RequesterID: 2, Status: tt.status}, nil).Once()
+				es.On("GetEntity", mock.Anything, models.TypePassword, 3, 1).
+					Return(&models.Entity{ID: 3, RequiresApproval: true, Approver: tt.approver}, nil).Once()
+			}
+			if tt.wantErr == nil {
+				want := models.AccessDenied
+				if tt.approve {
+					want = models.AccessApproved
+				}
+				acs.On("DecideAccessRequest", mock.Anything, mock.MatchedBy(func(r *models.AccessRequest) bool {
+					return r.ID == 7 && r.ApproverID == 1 && r.Status == want && tt.approve != r.ExpiresAt.IsZero()
+				})).Return(&models.AccessRequest{ID: 7, Status: want}, nil).Once()
+			}
+			k := New(Deps{Entities: es, Access: acs}, "")
+			_, err := k.DecideAccessRequest(context.Background(), 7, 1, tt.approve, tt.window)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestKeeper_WatchAccessRequests(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	acs := mocks.NewAccessStorage(t)
+	af := mocks.NewChangeFeed(t)
+
+	// the signal resends the requests, the closed channel stops the watch then
+	signals := make(chan struct{}, 1)
+	signals <- struct{}{}
+	close(signals)
+	af.On("Subscribe", 1).Return((<-chan struct{})(signals), func() {})
+	acs.On("ListAccessRequests", mock.Anything, 1, mock.Anything).Return([]*models.AccessRequest{{ID: 7}}, nil).Once()
+	acs.On("ListAccessRequests", mock.Anything, 1, mock.Anything).Return([]*models.AccessRequest{}, nil).Once()
+
+	k := New(Deps{Access: acs, AccessFeed: af}, "")
+	sent := make([]int, 0)
+	err := k.WatchAccessRequests(context.Background(), 1, func(requests []*models.AccessRequest) error {
+		sent = append(sent, len(requests))
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrWatchStopped)
+	assert.Equal(t, []int{1, 0}, sent)
+}
@@ -0,0 +1,313 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+var (
+	// ErrConflict - matches the ConflictError
+	ErrConflict = errors.New("edit conflicts with the changes since the base revision")
+
+	// ErrInvalidBaseRevision - error if the base revision is ahead of the entity revision
+	ErrInvalidBaseRevision = errors.New("invalid base revision")
+
+	// ErrInvalidResolution - error if the conflict resolution is unknown
+	ErrInvalidResolution = errors.New("invalid conflict resolution")
+
+	// ErrConflictNotFound - matches the storage error if the conflict does not exist or belongs to another user
+	ErrConflictNotFound = storage.ErrConflictNotExist
+)
+
+// ConflictError is returned if the offline edit conflicts with the changes made since its base revision,
+// the conflict is stored to be resolved by the user.
+type ConflictError struct {
+	Conflict *models.Conflict
+}
+
+// Error returns the error message.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: conflict %d on %v", ErrConflict, e.Conflict.ID, e.Conflict.ConflictingFields)
+}
+
+// Unwrap returns ErrConflict.
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// ConflictStorage is a storage API for the conflicting offline edits
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=ConflictStorage
+type ConflictStorage interface {
+	AddConflict(ctx context.Context, c *models.Conflict) (int, error)
+	GetConflict(ctx context.Context, id int, ownerID int) (*models.Conflict, error)
+	ListConflicts(ctx context.Context, ownerID int) ([]*models.Conflict, error)
+	DeleteConflict(ctx context.Context, id int, ownerID int) error
+}
+
+// mergeAttempts is the number of merge attempts if the entity changes while the edit is merged.
+const mergeAttempts = 3
+
+// UpdateFromBase updates the entity with the offline edit based on the base revision.
+//
+// If the entity has been changed since the base revision, the edit is merged field by field: the fields changed
+// only by the client are updated, the fields changed only on the server are kept. If the same field is changed
+// on both sides the edit is stored as a conflict and the ConflictError is returned, nothing is updated.
+// The base content is taken from the version history, the folder, the tags and the labels are not versioned,
+// so they conflict if the client values differ from the current ones.
+// The edit is merged for the users who can update the entity and read it: the merge and the conflict
+// reveal the server values, so they are audited as the reads of the entity requiring approval.
+func (k *Keeper) UpdateFromBase(ctx context.Context, e *models.Entity, fields []models.EntityField, base int) error {
+	if err := validateUpdate(e, fields); err != nil {
+		return err
+	}
+	if base < 1 {
+		return ErrInvalidBaseRevision
+	}
+
+	lg := sl.Log.With(slog.String("type", string(e.Type)), slog.Int("id", e.ID), slog.Int("base", base))
+	lg.Info("updating entity from base revision")
+
+	mask := fields
+	if len(mask) == 0 {
+		mask = models.UpdatableFields[e.Type]
+	}
+	approved := false
+	for range mergeAttempts {
+		cur, err := k.es.GetEntity(ctx, e.Type, e.ID, e.OwnerID)
+		if err != nil {
+			return err
+		}
+		if cur.Permission == models.PermissionRead {
+			lg.Info("user can not update entity")
+			return ErrPermissionDenied
+		}
+		if base > cur.Revision {
+			return ErrInvalidBaseRevision
+		}
+		upd := *e
+		upd.Revision = cur.Revision
+		apply := fields
+		if cur.Revision != base {
+			if !approved {
+				if err := k.approveRead(ctx, cur, e.OwnerID); err != nil {
+					return err
+				}
+				approved = true
+			}
+			b, err := k.baseEntity(ctx, cur, base, e.OwnerID)
+			if err != nil {
+				return err
+			}
+			changed, conflicting := mergeFields(b, cur, e, mask)
+			if len(conflicting) > 0 {
+				return k.addConflict(ctx, e, fields, conflicting, base, cur)
+			}
+			if len(changed) == 0 {
+				lg.Info("edit has already been applied")
+				return nil
+			}
+			lg.Info("merging edit", slog.Int("revision", cur.Revision), slog.Any("fields", changed))
+			apply = changed
+		}
+		err = k.es.UpdateEntity(ctx, &upd, apply)
+		if errors.Is(err, ErrRevisionMismatch) {
+			lg.Info("entity changed while merging, retrying")
+			continue
+		}
+		return err
+	}
+	return ErrRevisionMismatch
+}
+
+// baseEntity returns the entity content at the base revision, nil if it is unknown.
+// The content is archived on every update with the revision it had, so the content at the base revision
+// is the first version archived since it. The content is unknown if the versions since the base revision
+// are pruned or missing. The history of the personal entity is read as its owner`s, the vault entity history
+// is read as the user`s, so it is kept after its creator leaves the vault.
+func (k *Keeper) baseEntity(ctx context.Context, cur *models.Entity, base int, userID int) (*models.Entity, error) {
+	if cur.Type == models.TypeFile || base <= cur.PrunedRevision {
+		return nil, nil
+	}
+	reader := cur.OwnerID
+	if cur.VaultID != 0 {
+		reader = userID
+	}
+	versions, err := k.vs.ListVersions(ctx, cur.Type, cur.ID, reader)
+	if err != nil {
+		return nil, err
+	}
+	var b *models.Entity
+	for _, v := range versions {
+		if v.Entity.Revision >= base && v.Entity.Revision < cur.Revision && (b == nil || v.Entity.Revision < b.Revision) {
+			b = v.Entity
+		}
+	}
+	return b, nil
+}
+
+// mergeFields returns the fields of the update mask changed only by the client and the fields changed
+// on both sides to different values. The organize fields are not versioned, so they are conflicting
+// if the client values differ from the server ones.
+func mergeFields(base, server, client *models.Entity, mask []models.EntityField) (changed, conflicting []models.EntityField) {
+	for _, f := range mask {
+		if sameField(client, server, f) {
+			continue
+		}
+		if base == nil || slices.Contains(models.OrganizeFields, f) {
+			conflicting = append(conflicting, f)
+			continue
+		}
+		switch {
+		case sameField(base, client, f):
+			// changed only on the server
+		case sameField(base, server, f):
+			changed = append(changed, f)
+		default:
+			conflicting = append(conflicting, f)
+		}
+	}
+	return changed, conflicting
+}
+
+// sameField reports whether the entities have the same value of the field.
+func sameField(a, b *models.Entity, f models.EntityField) bool {
+	switch f {
+	case models.FieldCustom:
+		return slices.Equal(a.Custom, b.Custom)
+	case models.FieldFolder:
+		return a.FolderID == b.FolderID
+	case models.FieldTags:
+		return slices.Equal(uniqueIDs(a.TagIDs), uniqueIDs(b.TagIDs))
+	case models.FieldLabels:
+		return maps.Equal(a.Labels, b.Labels)
+	}
+	return a.Field(f) == b.Field(f)
+}
+
+func uniqueIDs(ids []int) []int {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func (k *Keeper) addConflict(ctx context.Context, e *models.Entity, fields, conflicting []models.EntityField,
+	base int, cur *models.Entity) error {
+	c := &models.Conflict{
+		OwnerID:           e.OwnerID,
+		Type:              e.Type,
+		EntityID:          e.ID,
+		BaseRevision:      base,
+		ServerRevision:    cur.Revision,
+		Fields:            fields,
+		ConflictingFields: conflicting,
+		Client:            e,
+		Server:            cur,
+	}
+	id, err := k.cos.AddConflict(ctx, c)
+	if err != nil {
+		sl.Log.Error("failed to add conflict", sl.Err(err))
+		return err
+	}
+	c.ID = id
+	sealUnapproved(c.Server)
+	sl.Log.Info("edit conflicts with server changes", slog.Int("conflict", id), slog.Any("fields", conflicting))
+	return &ConflictError{Conflict: c}
+}
+
+// Conflicts returns all user`s conflicts with the current entities, the entity is nil if it is in the trash.
+func (k *Keeper) Conflicts(ctx context.Context, ownerID int) ([]*models.Conflict, error) {
+	sl.Log.Info("getting conflicts")
+	conflicts, err := k.cos.ListConflicts(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		if err := k.serverEntity(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+	return conflicts, nil
+}
+
+// Conflict returns the user`s conflict with the current entity, the entity is nil if it is in the trash.
+func (k *Keeper) Conflict(ctx context.Context, id int, ownerID int) (*models.Conflict, error) {
+	sl.Log.Info("getting conflict", slog.Int("id", id))
+	c, err := k.cos.GetConflict(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.serverEntity(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// serverEntity sets the current entity of the conflict, sealed if the user does not approve its reads.
+func (k *Keeper) serverEntity(ctx context.Context, c *models.Conflict) error {
+	e, err := k.es.GetEntity(ctx, c.Type, c.EntityID, c.OwnerID)
+	if err != nil {
+		if errors.Is(err, ErrEntityNotFound) {
+			return nil
+		}
+		return err
+	}
+	sealUnapproved(e)
+	c.Server = e
+	return nil
+}
+
+// ResolveConflict resolves the user`s conflict and returns the resulting entity.
+//
+// KeepServer drops the client edit, KeepClient applies it over the server changes and Merge applies
+// the merged entity fields. If expectedRevision is set, the entity is updated only if it has not been
+// changed since that revision, so the user resolves the conflict against the server entity they have seen.
+func (k *Keeper) ResolveConflict(ctx context.Context, id int, ownerID int, r models.Resolution,
+	merged *models.Entity, fields []models.EntityField, expectedRevision int) (*models.Entity, error) {
+
+	lg := sl.Log.With(slog.Int("id", id), slog.String("resolution", string(r)))
+	lg.Info("resolving conflict")
+
+	c, err := k.cos.GetConflict(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	switch r {
+	case models.KeepServer:
+	case models.KeepClient:
+		e := *c.Client
+		e.Revision = expectedRevision
+		err = k.Update(ctx, &e, c.Fields)
+	case models.Merge:
+		if merged == nil {
+			return nil, ErrInvalidResolution
+		}
+		e := *merged
+		e.ID, e.OwnerID, e.Type, e.Revision = c.EntityID, ownerID, c.Type, expectedRevision
+		err = k.Update(ctx, &e, fields)
+	default:
+		return nil, ErrInvalidResolution
+	}
+	if err != nil {
+		lg.Info("failed to apply resolution", sl.Err(err))
+		return nil, err
+	}
+	if err := k.cos.DeleteConflict(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+	lg.Info("resolved conflict")
+	e, err := k.es.GetEntity(ctx, c.Type, c.EntityID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	sealUnapproved(e)
+	return e, nil
+}
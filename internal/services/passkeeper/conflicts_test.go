@@ -0,0 +1,374 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_UpdateFromBase(t *testing.T) {
+
+	password := func(revision int, login, pass string) *models.Entity {
+		return &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Revision: revision, Login: login, Password: pass}
+	}
+	// the login is changed at the revision 2, the password at the revision 3
+	versions := []*models.Version{
+		{Entity: password(3, "server", "p1")},
+		{Entity: password(2, "login", "p1")},
+		{Entity: password(1, "login", "p0")},
+	}
+	current := password(4, "server", "p2")
+	fields := []models.EntityField{models.FieldLogin, models.FieldPassword}
+
+	tests := []struct {
+		name            string
+		base            int
+		pruned          int
+		client          *models.Entity
+		fields          []models.EntityField
+		wantFields      []models.EntityField // the updated fields, no update if nil
+		wantConflicting []models.EntityField
+		wantErr         error
+	}{
+		{
+			name:       "not changed since base",
+			base:       4,
+			client:     password(0, "client", "p2"),
+			fields:     fields,
+			wantFields: fields,
+		},
+		{
+			name:       "changes merged",
+			base:       3,
+			client:     password(0, "client", "p1"),
+			fields:     fields,
+			wantFields: []models.EntityField{models.FieldLogin},
+		},
+		{
+			name:            "base version pruned",
+			base:            3,
+			pruned:          3,
+			client:          password(0, "client", "p1"),
+			fields:          fields,
+			wantConflicting: fields,
+			wantErr:         ErrConflict,
+		},
+		{
+			name:   "already applied",
+			base:   2,
+			client: password(0, "login", "p2"),
+			fields: fields,
+		},
+		{
+			name:       "client change applied",
+			base:       3,
+			client:     &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Login: "server", Password: "p1", Metadata: "client"},
+			wantFields: []models.EntityField{models.FieldMetadata},
+		},
+		{
+			name:            "same field changed",
+			base:            2,
+			client:          password(0, "login", "client"),
+			fields:          fields,
+			wantConflicting: []models.EntityField{models.FieldPassword},
+			wantErr:         ErrConflict,
+		},
+		{
+			name:            "organize field differs",
+			base:            3,
+			client:          &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, FolderID: 5},
+			fields:          []models.EntityField{models.FieldFolder},
+			wantConflicting: []models.EntityField{models.FieldFolder},
+			wantErr:         ErrConflict,
+		},
+		{
+			name:    "base ahead of entity",
+			base:    5,
+			client:  password(0, "client", "p2"),
+			fields:  fields,
+			wantErr: ErrInvalidBaseRevision,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			vs := mocks.NewVersionStorage(t)
+			cos := mocks.NewConflictStorage(t)
+			cur := *current
+			cur.PrunedRevision = tt.pruned
+			es.On("GetEntity", mock.Anything, models.TypePassword, 1, 1).Return(&cur, nil)
+			vs.On("ListVersions", mock.Anything, models.TypePassword, 1, 1).Return(versions, nil).Maybe()
+			if tt.wantFields != nil {
+				es.On("UpdateEntity", mock.Anything, mock.MatchedBy(func(e *models.Entity) bool {
+					return e.Revision == current.Revision
+				}), tt.wantFields).Return(nil).Once()
+			}
+			if tt.wantConflicting != nil {
+				cos.On("AddConflict", mock.Anything, mock.MatchedBy(func(c *models.Conflict) bool {
+					return c.BaseRevision == tt.base && c.ServerRevision == current.Revision && c.Client == tt.client
+				})).Return(9, nil).Once()
+			}
+
+			k := New(Deps{Entities: es, Versions: vs, Conflicts: cos}, "")
+			err := k.UpdateFromBase(context.Background(), tt.client, tt.fields, tt.base)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantConflicting != nil {
+				var ce *ConflictError
+				require.ErrorAs(t, err, &ce)
+				assert.Equal(t, 9, ce.Conflict.ID)
+				assert.Equal(t, tt.wantConflicting, ce.Conflict.ConflictingFields)
+			}
+		})
+	}
+}
+
+func TestKeeper_UpdateFromBaseVault(t *testing.T) {
+
+	// the entity is created by the user 1 who has left the vault, the user 2 edits it
+	current := &models.Entity{ID: 1, OwnerID: 1, VaultID: 5, Type: models.TypePassword, Revision: 3, Login: "server", Password: "p1"}
+	client := &models.Entity{ID: 1, OwnerID: 2, Type: models.TypePassword, Login: "login", Password: "client"}
+	fields := []models.EntityField{models.FieldLogin, models.FieldPassword}
+
+	tests := []struct {
+		name            string
+		versions        []*models.Version
+		wantFields      []models.EntityField
+		wantConflicting []models.EntityField
+	}{
+		{
+			name:       "changes merged",
+			versions:   []*models.Version{{Entity: &models.Entity{Revision: 2, Login: "login", Password: "p1"}}},
+			wantFields: []models.EntityField{models.FieldPassword},
+		},
+		{
+			name:            "base version missing",
+			versions:        []*models.Version{},
+			wantConflicting: []models.EntityField{models.FieldLogin, models.FieldPassword},
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			vs := mocks.NewVersionStorage(t)
+			cos := mocks.NewConflictStorage(t)
+			cur := *current
+			es.On("GetEntity", mock.Anything, models.TypePassword, 1, 2).Return(&cur, nil)
+			vs.On("ListVersions", mock.Anything, models.TypePassword, 1, 2).Return(tt.versions, nil).Once()
+			if tt.wantFields != nil {
+				es.On("UpdateEntity", mock.Anything, mock.Anything, tt.wantFields).Return(nil).Once()
+			}
+			if tt.wantConflicting != nil {
+				cos.On("AddConflict", mock.Anything, mock.Anything).Return(9, nil).Once()
+			}
+
+			k := New(Deps{Entities: es, Versions: vs, Conflicts: cos}, "")
+			err := k.UpdateFromBase(context.Background(), client, fields, 2)
+			if tt.wantConflicting == nil {
+				require.NoError(t, err)
+				return
+			}
+			var ce *ConflictError
+			require.ErrorAs(t, err, &ce)
+			assert.Equal(t, tt.wantConflicting, ce.Conflict.ConflictingFields)
+		})
+	}
+}
+
+func TestKeeper_UpdateFromBaseAccess(t *testing.T) {
+
+	// the user 2 guesses the password of the entity changed since the base revision
+	client := &models.Entity{ID: 1, OwnerID: 2, Type: models.TypePassword, Password: "guess"}
+	fields := []models.EntityField{models.FieldPassword}
+
+	tests := []struct {
+		name    string
+		entity  *models.Entity
+		wantErr error
+	}{
+		{
+			name:    "read only sharee",
+			entity:  &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Revision: 3, Password: "secret", Permission: models.PermissionRead},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "unapproved reader",
+			entity: &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Revision: 3, Password: "secret",
+				Permission: models.PermissionWrite, RequiresApproval: true},
+			wantErr: ErrApprovalRequired,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			acs := mocks.NewAccessStorage(t)
+			es.On("GetEntity", mock.Anything, models.TypePassword, 1, 2).Return(tt.entity, nil).Once()
+			acs.On("ActiveAccessRequest", mock.Anything, models.TypePassword, 1, 2, mock.Anything).
+				Return(nil, storage.ErrAccessRequestNotExist).Maybe()
+
+			// no versions are listed and no conflict is stored, the storage mocks fail on unexpected calls
+			k := New(Deps{Entities: es, Versions: mocks.NewVersionStorage(t), Conflicts: mocks.NewConflictStorage(t), Access: acs}, "")
+			err := k.UpdateFromBase(context.Background(), client, fields, 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NotErrorIs(t, err, ErrConflict)
+		})
+	}
+}
+
+func TestKeeper_UpdateFromBaseRetry(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	es := mocks.NewEntityStorage(t)
+	es.On("GetEntity", mock.Anything, models.TypeText, 1, 1).
+		Return(&models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Revision: 2, Text: "text"}, nil).Times(mergeAttempts)
+	es.On("UpdateEntity", mock.Anything, mock.Anything, []models.EntityField{models.FieldText}).
+		Return(ErrRevisionMismatch).Times(mergeAttempts)
+
+	k := New(Deps{Entities: es}, "")
+	err := k.UpdateFromBase(context.Background(), &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Text: "client"},
+		[]models.EntityField{models.FieldText}, 2)
+	assert.ErrorIs(t, err, ErrRevisionMismatch)
+}
+
+func TestKeeper_ResolveConflict(t *testing.T) {
+
+	conflict := func() *models.Conflict {
+		return &models.Conflict{
+			ID:       9,
+			OwnerID:  1,
+			Type:     models.TypePassword,
+			EntityID: 1,
+			Fields:   []models.EntityField{models.FieldPassword},
+			Client:   &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Password: "client"},
+		}
+	}
+	resolved := &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Revision: 5}
+	unexpected := errors.New("unexpected error")
+
+	tests := []struct {
+		name       string
+		resolution models.Resolution
+		merged     *models.Entity
+		fields     []models.EntityField
+		want       *models.Entity // the updated entity, no update if nil
+		updateErr  error
+		wantErr    error
+	}{
+		{
+			name:       "keep server",
+			resolution: models.KeepServer,
+		},
+		{
+			name:       "keep client",
+			resolution: models.KeepClient,
+			fields:     []models.EntityField{models.FieldPassword},
+			want:       &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Password: "client", Revision: 4},
+		},
+		{
+			name:       "merge",
+			resolution: models.Merge,
+			merged:     &models.Entity{Login: "merged", Password: "merged"},
+			fields:     []models.EntityField{models.FieldLogin, models.FieldPassword},
+			want:       &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Login: "merged", Password: "merged", Revision: 4},
+		},
+		{
+			name:       "merge without entity",
+			resolution: models.Merge,
+			wantErr:    ErrInvalidResolution,
+		},
+		{
+			name:       "unknown resolution",
+			resolution: models.Resolution("UNKNOWN"),
+			wantErr:    ErrInvalidResolution,
+		},
+		{
+			name:       "entity changed",
+			resolution: models.KeepClient,
+			fields:     []models.EntityField{models.FieldPassword},
+			want:       &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Password: "client", Revision: 4},
+			updateErr:  ErrRevisionMismatch,
+			wantErr:    ErrRevisionMismatch,
+		},
+		{
+			name:       "unexpected error",
+			resolution: models.KeepClient,
+			fields:     []models.EntityField{models.FieldPassword},
+			want:       &models.Entity{ID: 1, OwnerID: 1, Type: models.TypePassword, Password: "client", Revision: 4},
+			updateErr:  unexpected,
+			wantErr:    unexpected,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			cos := mocks.NewConflictStorage(t)
+			cos.On("GetConflict", mock.Anything, 9, 1).Return(conflict(), nil).Once()
+			if tt.want != nil {
+				es.On("UpdateEntity", mock.Anything, tt.want, tt.fields).Return(tt.updateErr).Once()
+			}
+			if tt.wantErr == nil {
+				cos.On("DeleteConflict", mock.Anything, 9, 1).Return(nil).Once()
+				es.On("GetEntity", mock.Anything, models.TypePassword, 1, 1).Return(resolved, nil).Once()
+			}
+
+			k := New(Deps{Entities: es, Conflicts: cos}, "")
+			e, err := k.ResolveConflict(context.Background(), 9, 1, tt.resolution, tt.merged, tt.fields, 4)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, resolved, e)
+		})
+	}
+}
+
+func TestKeeper_Conflicts(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	es := mocks.NewEntityStorage(t)
+	cos := mocks.NewConflictStorage(t)
+	cos.On("ListConflicts", mock.Anything, 1).Return([]*models.Conflict{
+		{ID: 1, OwnerID: 1, Type: models.TypeText, EntityID: 1},
+		{ID: 2, OwnerID: 1, Type: models.TypeText, EntityID: 2},
+		{ID: 3, OwnerID: 1, Type: models.TypeText, EntityID: 3},
+	}, nil).Once()
+	current := &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Text: "server"}
+	es.On("GetEntity", mock.Anything, models.TypeText, 1, 1).Return(current, nil).Once()
+	es.On("GetEntity", mock.Anything, models.TypeText, 2, 1).Return(nil, ErrEntityNotFound).Once()
+	// the entity requiring approval is sealed for the users not approving its reads
+	es.On("GetEntity", mock.Anything, models.TypeText, 3, 1).
+		Return(&models.Entity{ID: 3, Type: models.TypeText, Text: "secret", RequiresApproval: true}, nil).Once()
+
+	k := New(Deps{Entities: es, Conflicts: cos}, "")
+	conflicts, err := k.Conflicts(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 3)
+	assert.Equal(t, current, conflicts[0].Server)
+	assert.Nil(t, conflicts[1].Server)
+	require.NotNil(t, conflicts[2].Server)
+	assert.True(t, conflicts[2].Server.Sealed)
+	assert.Equal(t, "", conflicts[2].Server.Text)
+}
@@ -0,0 +1,106 @@
+package passkeeper
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
+)
+
+const maxCustomFields = 100
+
+var customTypes = map[passkeeperv1.CustomFieldType]models.CustomFieldType{
+	passkeeperv1.CustomFieldType_CUSTOM_TEXT:   models.CustomText,
+	passkeeperv1.CustomFieldType_CUSTOM_HIDDEN: models.CustomHidden,
+	passkeeperv1.CustomFieldType_CUSTOM_URL:    models.CustomURL,
+	passkeeperv1.CustomFieldType_CUSTOM_EMAIL:  models.CustomEmail,
+	passkeeperv1.CustomFieldType_CUSTOM_DATE:   models.CustomDate,
+	passkeeperv1.CustomFieldType_CUSTOM_TOTP:   models.CustomTOTP,
+}
+
+// CustomFieldsFromGRPC converts the custom fields of the request, unknown types are kept to fail the validation.
+func CustomFieldsFromGRPC(fields []*passkeeperv1.CustomField) []models.CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]models.CustomField, 0, len(fields))
+	for _, f := range fields {
+		t, ok := customTypes[f.Type]
+		if !ok {
+			t = models.CustomFieldType(f.Type.String())
+		}
+		res = append(res, models.CustomField{Name: f.Name, Type: t, Value: f.Value})
+	}
+	return res
+}
+
+// CustomFieldsToGRPC converts the custom fields of the entity.
+func CustomFieldsToGRPC(fields []models.CustomField) []*passkeeperv1.CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]*passkeeperv1.CustomField, 0, len(fields))
+	for _, f := range fields {
+		cf := &passkeeperv1.CustomField{Name: f.Name, Value: f.Value}
+		for pt, t := range customTypes {
+			if t == f.Type {
+				cf.Type = pt
+			}
+		}
+		res = append(res, cf)
+	}
+	return res
+}
+
+// validateCustom checks the custom field names, types and values, the empty values are allowed.
+// Secret values are never included in the error.
+func validateCustom(fields []models.CustomField) error {
+	if len(fields) > maxCustomFields {
+		return fmt.Errorf("%w: at most %d fields are allowed", ErrInvalidCustomFields, maxCustomFields)
+	}
+	for i, f := range fields {
+		if strings.TrimSpace(f.Name) == "" {
+			return fmt.Errorf("%w: field %d has empty name", ErrInvalidCustomFields, i)
+		}
+		if err := validateCustomValue(f); err != nil {
+			return fmt.Errorf("%w: field %q: %v", ErrInvalidCustomFields, f.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateCustomValue(f models.CustomField) error {
+	if !f.Type.Known() {
+		return fmt.Errorf("unknown type %q", f.Type)
+	}
+	if f.Value == "" {
+		return nil
+	}
+	switch f.Type {
+	case models.CustomURL:
+		u, err := url.Parse(f.Value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid url %q", f.Value)
+		}
+	case models.CustomEmail:
+		addr, err := mail.ParseAddress(f.Value)
+		if err != nil || addr.Address != f.Value {
+			return fmt.Errorf("invalid email %q", f.Value)
+		}
+	case models.CustomDate:
+		if _, err := time.Parse(time.DateOnly, f.Value); err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", f.Value)
+		}
+	case models.CustomTOTP:
+		seed := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(f.Value, " ", ""), "="))
+		if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(seed); err != nil {
+			return fmt.Errorf("invalid TOTP seed, expected base32")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// maxEmergencyWait is the maximum wait period of the emergency access request.
+const maxEmergencyWait = 90 * 24 * time.Hour
+
+var (
+	// ErrInvalidWaitPeriod - error if the emergency access wait period is not positive or too long
+	ErrInvalidWaitPeriod = errors.New("invalid wait period")
+
+	// ErrInvalidEmergencyAction - error if the emergency access action is unknown or is not performed by the users
+	ErrInvalidEmergencyAction = errors.New("invalid emergency access action")
+
+	// ErrEmergencyContactNotFound - matches the storage error if the contact does not exist or the user is neither party
+	ErrEmergencyContactNotFound = storage.ErrEmergencyContactNotExist
+
+	// ErrEmergencyContactSelf - matches the storage error if the user invites themselves
+	ErrEmergencyContactSelf = storage.ErrEmergencyContactSelf
+
+	// ErrEmergencyTransition - matches the storage error if the action is not allowed in the current status
+	ErrEmergencyTransition = storage.ErrEmergencyTransition
+)
+
+// EmergencyStorage is a storage API for the emergency contacts and their audited transitions
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=EmergencyStorage
+type EmergencyStorage interface {
+	InviteEmergencyContact(ctx context.Context, c *models.EmergencyContact) (*models.EmergencyContact, error)
+	TransitionEmergencyContact(ctx context.Context, ev *models.EmergencyEvent) (*models.EmergencyContact, error)
+	GetEmergencyContact(ctx context.Context, id int, userID int) (*models.EmergencyContact, error)
+	ListEmergencyContacts(ctx context.Context, userID int) ([]*models.EmergencyContact, error)
+	ListEmergencyEvents(ctx context.Context, contactID int) ([]*models.EmergencyEvent, error)
+	GrantEmergencyRequests(ctx context.Context, now time.Time) (int64, error)
+}
+
+// InviteEmergencyContact invites the registered user with the grantee email as the grantor`s trusted contact.
+// The accepted contact requests the access which is granted after the wait period unless the grantor rejects it.
+func (k *Keeper) InviteEmergencyContact(ctx context.Context, c *models.EmergencyContact) (*models.EmergencyContact, error) {
+	c.GranteeEmail = strings.TrimSpace(c.GranteeEmail)
+	if c.GranteeEmail == "" {
+		return nil, ErrEmptyEmail
+	}
+	if c.WaitPeriod <= 0 || c.WaitPeriod > maxEmergencyWait {
+		return nil, fmt.Errorf("%w: wait period must be up to %s", ErrInvalidWaitPeriod, maxEmergencyWait)
+	}
+	sl.Log.Info("inviting emergency contact", slog.Duration("wait", c.WaitPeriod))
+	return k.ems.InviteEmergencyContact(ctx, c)
+}
+
+// UpdateEmergencyAccess performs the action on the emergency access the user is a party of:
+// the grantee accepts the invitation and requests the access, the grantor approves or rejects the request,
+// either party revokes the access. The transition is audited.
+func (k *Keeper) UpdateEmergencyAccess(ctx context.Context, id int, userID int, action models.EmergencyAction) (*models.EmergencyContact, error) {
+
+	lg := sl.Log.With(slog.Int("contact", id), slog.String("action", string(action)))
+	lg.Info("updating emergency access")
+
+	if !action.Known() || action == models.EmergencyInvite || action == models.EmergencyGrant {
+		return nil, ErrInvalidEmergencyAction
+	}
+	c, err := k.ems.GetEmergencyContact(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !action.AllowedTo(c.Actor(userID)) {
+		lg.Info("action is not allowed to the user")
+		return nil, ErrPermissionDenied
+	}
+	next, ok := c.Status.Next(action)
+	if !ok {
+		lg.Info("action is not allowed in the status", slog.String("status", string(c.Status)))
+		return nil, ErrEmergencyTransition
+	}
+	res, err := k.ems.TransitionEmergencyContact(ctx, &models.EmergencyEvent{
+		ContactID: id, ActorID: userID, Action: action, From: c.Status, To: next,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Info("updated emergency access", slog.String("status", string(res.Status)))
+	return res, nil
+}
+
+// EmergencyContacts returns the emergency contacts the user is the grantor or the grantee of.
+func (k *Keeper) EmergencyContacts(ctx context.Context, userID int) ([]*models.EmergencyContact, error) {
+	sl.Log.Info("getting emergency contacts")
+	return k.ems.ListEmergencyContacts(ctx, userID)
+}
+
+// EmergencyEvents returns the audit trail of the emergency access the user is a party of.
+func (k *Keeper) EmergencyEvents(ctx context.Context, id int, userID int) ([]*models.EmergencyEvent, error) {
+	if _, err := k.ems.GetEmergencyContact(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("getting emergency access events", slog.Int("contact", id))
+	return k.ems.ListEmergencyEvents(ctx, id)
+}
+
+// GrantEmergencyRequests grants the emergency access requests whose wait period has passed.
+func (k *Keeper) GrantEmergencyRequests(ctx context.Context) error {
+
+	lg := sl.Log
+	lg.Info("granting emergency access requests")
+
+	n, err := k.ems.GrantEmergencyRequests(ctx, time.Now())
+	if err != nil {
+		lg.Error("failed to grant emergency access requests", sl.Err(err))
+		return err
+	}
+
+	lg.Info("granted emergency access requests", slog.Int64("count", n))
+	return nil
+}
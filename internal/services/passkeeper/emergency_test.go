@@ -0,0 +1,123 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_InviteEmergencyContact(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		email   string
+		wait    time.Duration
+		wantErr error
+	}{
+		{name: "ok", email: " friend@gmail.com ", wait: 48 * time.Hour},
+		{name: "empty email", email: " ", wait: time.Hour, wantErr: ErrEmptyEmail},
+		{name: "no wait period", email: "friend@gmail.com", wantErr: ErrInvalidWaitPeriod},
+		{name: "long wait period", email: "friend@gmail.com", wait: maxEmergencyWait + time.Hour, wantErr: ErrInvalidWaitPeriod},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ems := mocks.NewEmergencyStorage(t)
+			if tt.wantErr == nil {
+				ems.On("InviteEmergencyContact", mock.Anything,
+					&models.EmergencyContact{GrantorID: 1, GranteeEmail: "friend@gmail.com", WaitPeriod: tt.wait}).
+					Return(&models.EmergencyContact{ID: 3, Status: models.EmergencyInvited}, nil).Once()
+			}
+			k := New(Deps{Emergency: ems}, "")
+			c, err := k.InviteEmergencyContact(context.Background(),
+				&models.EmergencyContact{GrantorID: 1, GranteeEmail: tt.email, WaitPeriod: tt.wait})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 3, c.ID)
+		})
+	}
+}
+
+func TestKeeper_UpdateEmergencyAccess(t *testing.T) {
+
+	const grantor, grantee = 1, 2
+
+	tests := []struct {
+		name    string
+		status  models.EmergencyStatus
+		user    int
+		action  models.EmergencyAction
+		want    models.EmergencyStatus
+		wantErr error
+	}{
+		{name: "grantee accepts", status: models.EmergencyInvited, user: grantee, action: models.EmergencyAccept, want: models.EmergencyAccepted},
+		{name: "grantee requests", status: models.EmergencyAccepted, user: grantee, action: models.EmergencyRequest, want: models.EmergencyRequested},
+		{name: "grantor approves", status: models.EmergencyRequested, user: grantor, action: models.EmergencyApprove, want: models.EmergencyGranted},
+		{name: "grantor rejects", status: models.EmergencyRequested, user: grantor, action: models.EmergencyReject, want: models.EmergencyAccepted},
+		{name: "grantee revokes", status: models.EmergencyGranted, user: grantee, action: models.EmergencyRevoke, want: models.EmergencyRevoked},
+		{name: "grantor accepts", status: models.EmergencyInvited, user: grantor, action: models.EmergencyAccept, wantErr: ErrPermissionDenied},
+		{name: "grantee approves", status: models.EmergencyRequested, user: grantee, action: models.EmergencyApprove, wantErr: ErrPermissionDenied},
+		{name: "request before accept", status: models.EmergencyInvited, user: grantee, action: models.EmergencyRequest, wantErr: ErrEmergencyTransition},
+		{name: "revoke revoked", status: models.EmergencyRevoked, user: grantor, action: models.EmergencyRevoke, wantErr: ErrEmergencyTransition},
+		{name: "grant by user", status: models.EmergencyRequested, user: grantor, action: models.EmergencyGrant, wantErr: ErrInvalidEmergencyAction},
+		{name: "unknown action", status: models.EmergencyInvited, user: grantee, action: "FORGET", wantErr: ErrInvalidEmergencyAction},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ems := mocks.NewEmergencyStorage(t)
+			if tt.wantErr != ErrInvalidEmergencyAction {
+				ems.On("GetEmergencyContact", mock.Anything, 3, tt.user).
+					Return(&models.EmergencyContact{ID: 3, GrantorID: grantor, GranteeID: grantee, Status: tt.status}, nil).Once()
+			}
+			if tt.wantErr == nil {
+				ems.On("TransitionEmergencyContact", mock.Anything, &models.EmergencyEvent{
+					ContactID: 3, ActorID: tt.user, Action: tt.action, From: tt.status, To: tt.want,
+				}).Return(&models.EmergencyContact{ID: 3, Status: tt.want}, nil).Once()
+			}
+			k := New(Deps{Emergency: ems}, "")
+			c, err := k.UpdateEmergencyAccess(context.Background(), 3, tt.user, tt.action)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Status)
+		})
+	}
+}
+
+func TestKeeper_EmergencyEvents(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ems := mocks.NewEmergencyStorage(t)
+	ems.On("GetEmergencyContact", mock.Anything, 3, 1).Return(&models.EmergencyContact{ID: 3, GrantorID: 1, GranteeID: 2}, nil).Once()
+	ems.On("GetEmergencyContact", mock.Anything, 3, 5).Return(nil, storage.ErrEmergencyContactNotExist).Once()
+	ems.On("ListEmergencyEvents", mock.Anything, 3).Return([]*models.EmergencyEvent{{ID: 1, ContactID: 3}}, nil).Once()
+	ems.On("GrantEmergencyRequests", mock.Anything, mock.Anything).Return(int64(2), nil).Once()
+
+	k := New(Deps{Emergency: ems}, "")
+	events, err := k.EmergencyEvents(ctx, 3, 1)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	_, err = k.EmergencyEvents(ctx, 3, 5)
+	assert.ErrorIs(t, err, ErrEmergencyContactNotFound)
+
+	assert.NoError(t, k.GrantEmergencyRequests(ctx))
+}
@@ -0,0 +1,59 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+// maxVaultKeyLength is the maximum encrypted vault key length in bytes.
+const maxVaultKeyLength = 1024
+
+var (
+	// ErrInvalidVaultKey - error if no vault keys are given or a key has no member email or is malformed
+	ErrInvalidVaultKey = errors.New("invalid vault key")
+
+	// ErrVaultKeyVersion - matches the storage error if the vault key version is neither the current nor the next one
+	ErrVaultKeyVersion = storage.ErrVaultKeyVersion
+
+	// ErrVaultKeysIncomplete - matches the storage error if the rotated vault key is not encrypted to every member
+	ErrVaultKeysIncomplete = storage.ErrVaultKeysIncomplete
+
+	// ErrVaultRekeyRequired - matches the storage error if the current vault key is distributed after a member removal
+	ErrVaultRekeyRequired = storage.ErrVaultRekeyRequired
+)
+
+// SetVaultKeys stores the vault key version encrypted to the members` public keys, the admins and the owners
+// distribute the vault keys. The current version is given to the new members, the next version rotates
+// the vault key, e.g. after a member is removed, and has to be encrypted to every member.
+func (k *Keeper) SetVaultKeys(ctx context.Context, vaultID int, orgID int, version int, keys []*models.VaultKey, userID int) (*models.Vault, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: at least one key is required", ErrInvalidVaultKey)
+	}
+	for _, vk := range keys {
+		vk.Email = strings.TrimSpace(vk.Email)
+		if vk.Email == "" {
+			return nil, fmt.Errorf("%w: member email is required", ErrInvalidVaultKey)
+		}
+		if len(vk.EncryptedKey) == 0 || len(vk.EncryptedKey) > maxVaultKeyLength {
+			return nil, fmt.Errorf("%w: encrypted key must be 1 to %d bytes long", ErrInvalidVaultKey, maxVaultKeyLength)
+		}
+	}
+	sl.Log.Info("setting vault keys", slog.Int("vault", vaultID), slog.Int("version", version))
+	return k.ors.SetVaultKeys(ctx, vaultID, orgID, version, keys, userID)
+}
+
+// VaultKeys returns the vault key versions encrypted to the user, any member gets the own keys.
+func (k *Keeper) VaultKeys(ctx context.Context, vaultID int, orgID int, userID int) ([]*models.VaultKey, error) {
+	if _, err := k.requireRole(ctx, orgID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("getting vault keys", slog.Int("vault", vaultID))
+	return k.ors.ListVaultKeys(ctx, vaultID, orgID, userID)
+}
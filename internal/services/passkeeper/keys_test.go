@@ -0,0 +1,97 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_SetVaultKeys(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		keys       []*models.VaultKey
+		storageErr error
+		wantErr    error
+	}{
+		{
+			name: "distributed",
+			keys: []*models.VaultKey{{Email: " friend@gmail.com ", EncryptedKey: []byte("key")}},
+		},
+		{
+			name:    "no keys",
+			wantErr: ErrInvalidVaultKey,
+		},
+		{
+			name:    "empty email",
+			keys:    []*models.VaultKey{{Email: " ", EncryptedKey: []byte("key")}},
+			wantErr: ErrInvalidVaultKey,
+		},
+		{
+			name:    "empty key",
+			keys:    []*models.VaultKey{{Email: "friend@gmail.com"}},
+			wantErr: ErrInvalidVaultKey,
+		},
+		{
+			name:       "editor",
+			keys:       []*models.VaultKey{{Email: "friend@gmail.com", EncryptedKey: []byte("key")}},
+			storageErr: storage.ErrForbidden,
+			wantErr:    ErrPermissionDenied,
+		},
+		{
+			name:       "incomplete rotation",
+			keys:       []*models.VaultKey{{Email: "friend@gmail.com", EncryptedKey: []byte("key")}},
+			storageErr: storage.ErrVaultKeysIncomplete,
+			wantErr:    ErrVaultKeysIncomplete,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors := mocks.NewOrgStorage(t)
+			if tt.wantErr == nil || tt.storageErr != nil {
+				var res *models.Vault
+				if tt.storageErr == nil {
+					res = &models.Vault{ID: 3, OrgID: 1, KeyVersion: 2}
+				}
+				ors.On("SetVaultKeys", mock.Anything, 3, 1, 2, tt.keys, 1).Return(res, tt.storageErr)
+			}
+			k := New(Deps{Orgs: ors}, "")
+			v, err := k.SetVaultKeys(context.Background(), 3, 1, 2, tt.keys, 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, v.KeyVersion)
+			assert.Equal(t, "friend@gmail.com", tt.keys[0].Email)
+		})
+	}
+}
+
+func TestKeeper_VaultKeys(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ors := mocks.NewOrgStorage(t)
+	ors.On("MemberRole", mock.Anything, 1, 2).Return(models.RoleViewer, nil)
+	ors.On("MemberRole", mock.Anything, 1, 5).Return(models.Role(""), storage.ErrOrgNotExist)
+	ors.On("ListVaultKeys", mock.Anything, 3, 1, 2).Return([]*models.VaultKey{{VaultID: 3, UserID: 2, Version: 1}}, nil).Once()
+
+	k := New(Deps{Orgs: ors}, "")
+	keys, err := k.VaultKeys(ctx, 3, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	_, err = k.VaultKeys(ctx, 3, 1, 5)
+	assert.ErrorIs(t, err, ErrOrgNotFound)
+}
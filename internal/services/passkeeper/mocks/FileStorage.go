@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	models "github.com/vindosVP/go-pass/internal/models"
@@ -14,105 +15,29 @@ type FileStorage struct {
 	mock.Mock
 }
 
-// AddFile provides a mock function with given fields: ctx, f
-func (_m *FileStorage) AddFile(ctx context.Context, f *models.File) (int, error) {
-	ret := _m.Called(ctx, f)
+// DeleteStaleUploads provides a mock function with given fields: ctx, before
+func (_m *FileStorage) DeleteStaleUploads(ctx context.Context, before time.Time) ([]*models.Entity, error) {
+	ret := _m.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddFile")
+		panic("no return value specified for DeleteStaleUploads")
 	}
 
-	var r0 int
+	var r0 []*models.Entity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.File) (int, error)); ok {
-		return rf(ctx, f)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Entity, error)); ok {
+		return rf(ctx, before)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.File) int); ok {
-		r0 = rf(ctx, f)
-	} else {
-		r0 = ret.Get(0).(int)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, *models.File) error); ok {
-		r1 = rf(ctx, f)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteFile provides a mock function with given fields: ctx, id, ownerID
-func (_m *FileStorage) DeleteFile(ctx context.Context, id int, ownerID int) error {
-	ret := _m.Called(ctx, id, ownerID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteFile")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
-		r0 = rf(ctx, id, ownerID)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// GetFile provides a mock function with given fields: ctx, id, ownerID
-func (_m *FileStorage) GetFile(ctx context.Context, id int, ownerID int) (*models.File, error) {
-	ret := _m.Called(ctx, id, ownerID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetFile")
-	}
-
-	var r0 *models.File
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.File, error)); ok {
-		return rf(ctx, id, ownerID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.File); ok {
-		r0 = rf(ctx, id, ownerID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.File)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, id, ownerID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetFiles provides a mock function with given fields: ctx, ownerID
-func (_m *FileStorage) GetFiles(ctx context.Context, ownerID int) ([]*models.File, error) {
-	ret := _m.Called(ctx, ownerID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetFiles")
-	}
-
-	var r0 []*models.File
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.File, error)); ok {
-		return rf(ctx, ownerID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.File); ok {
-		r0 = rf(ctx, ownerID)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Entity); ok {
+		r0 = rf(ctx, before)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.File)
+			r0 = ret.Get(0).([]*models.Entity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, ownerID)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
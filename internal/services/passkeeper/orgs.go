@@ -0,0 +1,156 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+var (
+	// ErrInvalidRole - error if the member role is unknown
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrPermissionDenied - matches the storage errors if the user`s role does not allow the change
+	ErrPermissionDenied = storage.ErrForbidden
+
+	// ErrOrgNotFound - matches the storage error if the organization does not exist or the user is not its member
+	ErrOrgNotFound = storage.ErrOrgNotExist
+
+	// ErrLastOwner - matches the storage error if the last organization owner is removed or gets another role
+	ErrLastOwner = storage.ErrLastOwner
+)
+
+// OrgStorage is a storage API for the organizations, their members and vaults
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=OrgStorage
+type OrgStorage interface {
+	CreateOrg(ctx context.Context, o *models.Org, userID int) (*models.Org, error)
+	ListOrgs(ctx context.Context, userID int) ([]*models.Org, error)
+	DeleteOrg(ctx context.Context, id int, userID int) error
+	MemberRole(ctx context.Context, orgID int, userID int) (models.Role, error)
+	AddMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error)
+	UpdateMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error)
+	RemoveMember(ctx context.Context, orgID int, email string, userID int) error
+	ListMembers(ctx context.Context, orgID int) ([]*models.Member, error)
+	CreateVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error)
+	RenameVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error)
+	DeleteVault(ctx context.Context, id int, orgID int, userID int) error
+	ListVaults(ctx context.Context, orgID int) ([]*models.Vault, error)
+	SetVaultKeys(ctx context.Context, vaultID int, orgID int, version int, keys []*models.VaultKey, userID int) (*models.Vault, error)
+	ListVaultKeys(ctx context.Context, vaultID int, orgID int, userID int) ([]*models.VaultKey, error)
+}
+
+// CreateOrg creates the organization, the user becomes its owner.
+func (k *Keeper) CreateOrg(ctx context.Context, o *models.Org, userID int) (*models.Org, error) {
+	o.Name = strings.TrimSpace(o.Name)
+	if o.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("creating organization")
+	return k.ors.CreateOrg(ctx, o, userID)
+}
+
+// Orgs returns the organizations the user is a member of.
+func (k *Keeper) Orgs(ctx context.Context, userID int) ([]*models.Org, error) {
+	sl.Log.Info("getting organizations")
+	return k.ors.ListOrgs(ctx, userID)
+}
+
+// DeleteOrg deletes the organization, only the owner deletes it and its vaults must be empty.
+func (k *Keeper) DeleteOrg(ctx context.Context, id int, userID int) error {
+	sl.Log.Info("deleting organization", slog.Int("id", id))
+	return k.ors.DeleteOrg(ctx, id, userID)
+}
+
+// Members returns the organization members, any member lists them.
+func (k *Keeper) Members(ctx context.Context, orgID int, userID int) ([]*models.Member, error) {
+	if _, err := k.requireRole(ctx, orgID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("getting organization members", slog.Int("org", orgID))
+	return k.ors.ListMembers(ctx, orgID)
+}
+
+// AddMember adds the registered user to the organization with the member role.
+// The admins add the editors and the viewers, the owners add the members of any role.
+// The user`s role is checked within the change, so a concurrent demotion does not let it through.
+func (k *Keeper) AddMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	if !m.Role.Known() {
+		return nil, ErrInvalidRole
+	}
+	m.Email = strings.TrimSpace(m.Email)
+	if m.Email == "" {
+		return nil, ErrEmptyEmail
+	}
+	sl.Log.Info("adding organization member", slog.Int("org", m.OrgID), slog.String("role", string(m.Role)))
+	return k.ors.AddMember(ctx, m, userID)
+}
+
+// UpdateMember changes the member role, the user has to be allowed to manage both the current and the new role.
+func (k *Keeper) UpdateMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	if !m.Role.Known() {
+		return nil, ErrInvalidRole
+	}
+	m.Email = strings.TrimSpace(m.Email)
+	sl.Log.Info("updating organization member", slog.Int("org", m.OrgID), slog.String("role", string(m.Role)))
+	return k.ors.UpdateMember(ctx, m, userID)
+}
+
+// RemoveMember removes the member from the organization, the user has to be allowed to manage the member role.
+func (k *Keeper) RemoveMember(ctx context.Context, orgID int, userID int, email string) error {
+	sl.Log.Info("removing organization member", slog.Int("org", orgID))
+	return k.ors.RemoveMember(ctx, orgID, strings.TrimSpace(email), userID)
+}
+
+// Vaults returns the organization vaults, any member lists them.
+func (k *Keeper) Vaults(ctx context.Context, orgID int, userID int) ([]*models.Vault, error) {
+	if _, err := k.requireRole(ctx, orgID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("getting vaults", slog.Int("org", orgID))
+	return k.ors.ListVaults(ctx, orgID)
+}
+
+// CreateVault creates the organization vault, the admins and the owners manage the vaults.
+func (k *Keeper) CreateVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("creating vault", slog.Int("org", v.OrgID))
+	return k.ors.CreateVault(ctx, v, userID)
+}
+
+// RenameVault renames the organization vault.
+func (k *Keeper) RenameVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("renaming vault", slog.Int("id", v.ID))
+	return k.ors.RenameVault(ctx, v, userID)
+}
+
+// DeleteVault deletes the organization vault, the vault must be empty.
+func (k *Keeper) DeleteVault(ctx context.Context, id int, orgID int, userID int) error {
+	sl.Log.Info("deleting vault", slog.Int("id", id))
+	return k.ors.DeleteVault(ctx, id, orgID, userID)
+}
+
+// requireRole returns the user`s role in the organization, the forbidden error if it is lower than the role.
+func (k *Keeper) requireRole(ctx context.Context, orgID int, userID int, role models.Role) (models.Role, error) {
+	r, err := k.ors.MemberRole(ctx, orgID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !r.AtLeast(role) {
+		sl.Log.Info("role does not allow the action", slog.Int("org", orgID), slog.String("role", string(r)))
+		return "", ErrPermissionDenied
+	}
+	return r, nil
+}
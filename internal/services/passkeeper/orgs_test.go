@@ -0,0 +1,142 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_AddMember(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		email      string
+		role       models.Role
+		storageErr error // the storage checks the user`s role within the change
+		wantErr    error
+	}{
+		{name: "added", email: " friend@gmail.com ", role: models.RoleAdmin},
+		{name: "role does not allow", email: "friend@gmail.com", role: models.RoleAdmin,
+			storageErr: storage.ErrForbidden, wantErr: ErrPermissionDenied},
+		{name: "not a member", email: "friend@gmail.com", role: models.RoleViewer,
+			storageErr: storage.ErrOrgNotExist, wantErr: ErrOrgNotFound},
+		{name: "unknown role", email: "friend@gmail.com", role: "GUEST", wantErr: ErrInvalidRole},
+		{name: "empty email", email: " ", role: models.RoleViewer, wantErr: ErrEmptyEmail},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors := mocks.NewOrgStorage(t)
+			m := &models.Member{OrgID: 1, Email: tt.email, Role: tt.role}
+			if tt.wantErr == nil || tt.storageErr != nil {
+				var res *models.Member
+				if tt.storageErr == nil {
+					res = &models.Member{OrgID: 1, UserID: 2, Email: "friend@gmail.com", Role: tt.role}
+				}
+				ors.On("AddMember", mock.Anything, mock.MatchedBy(func(m *models.Member) bool {
+					return m.Email == "friend@gmail.com"
+				}), 1).Return(res, tt.storageErr).Once()
+			}
+			k := New(Deps{Orgs: ors}, "")
+			res, err := k.AddMember(context.Background(), m, 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, res.UserID)
+		})
+	}
+}
+
+func TestKeeper_UpdateMember(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ors := mocks.NewOrgStorage(t)
+	m := &models.Member{OrgID: 1, Email: " friend@gmail.com ", Role: models.RoleEditor}
+	ors.On("UpdateMember", mock.Anything, mock.MatchedBy(func(m *models.Member) bool {
+		return m.Email == "friend@gmail.com"
+	}), 1).Return(&models.Member{OrgID: 1, UserID: 2, Email: "friend@gmail.com", Role: models.RoleEditor}, nil).Once()
+	ors.On("UpdateMember", mock.Anything, mock.Anything, 2).Return(nil, storage.ErrForbidden).Once()
+
+	k := New(Deps{Orgs: ors}, "")
+	res, err := k.UpdateMember(ctx, m, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleEditor, res.Role)
+
+	_, err = k.UpdateMember(ctx, &models.Member{OrgID: 1, Email: "friend@gmail.com", Role: models.RoleOwner}, 2)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = k.UpdateMember(ctx, &models.Member{OrgID: 1, Email: "friend@gmail.com", Role: "GUEST"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestKeeper_RemoveMember(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ors := mocks.NewOrgStorage(t)
+	ors.On("RemoveMember", mock.Anything, 1, "friend@gmail.com", 1).Return(nil).Once()
+	ors.On("RemoveMember", mock.Anything, 1, "friend@gmail.com", 2).Return(storage.ErrForbidden).Once()
+
+	k := New(Deps{Orgs: ors}, "")
+	assert.NoError(t, k.RemoveMember(ctx, 1, 1, " friend@gmail.com "))
+	assert.ErrorIs(t, k.RemoveMember(ctx, 1, 2, "friend@gmail.com"), ErrPermissionDenied)
+}
+
+func TestKeeper_Vaults(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ors := mocks.NewOrgStorage(t)
+	ors.On("MemberRole", mock.Anything, 1, 2).Return(models.RoleEditor, nil)
+	ors.On("CreateVault", mock.Anything, &models.Vault{OrgID: 1, Name: "prod"}, 1).
+		Return(&models.Vault{ID: 3, OrgID: 1, Name: "prod"}, nil).Once()
+	ors.On("CreateVault", mock.Anything, &models.Vault{OrgID: 1, Name: "stage"}, 2).Return(nil, storage.ErrForbidden).Once()
+	ors.On("ListVaults", mock.Anything, 1).Return([]*models.Vault{{ID: 3, OrgID: 1, Name: "prod"}}, nil).Once()
+	ors.On("DeleteVault", mock.Anything, 3, 1, 1).Return(storage.ErrVaultNotEmpty).Once()
+	ors.On("DeleteVault", mock.Anything, 3, 1, 2).Return(storage.ErrForbidden).Once()
+
+	k := New(Deps{Orgs: ors}, "")
+
+	v, err := k.CreateVault(ctx, &models.Vault{OrgID: 1, Name: " prod "}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v.ID)
+
+	_, err = k.CreateVault(ctx, &models.Vault{OrgID: 1, Name: " "}, 1)
+	assert.ErrorIs(t, err, ErrEmptyName)
+	_, err = k.CreateVault(ctx, &models.Vault{OrgID: 1, Name: "stage"}, 2)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	vaults, err := k.Vaults(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, vaults, 1)
+
+	assert.ErrorIs(t, k.DeleteVault(ctx, 3, 1, 1), storage.ErrVaultNotEmpty)
+	assert.ErrorIs(t, k.DeleteVault(ctx, 3, 1, 2), ErrPermissionDenied)
+}
+
+func TestKeeper_DeleteOrg(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ors := mocks.NewOrgStorage(t)
+	ors.On("DeleteOrg", mock.Anything, 1, 2).Return(storage.ErrForbidden).Once()
+	ors.On("DeleteOrg", mock.Anything, 1, 1).Return(nil).Once()
+
+	k := New(Deps{Orgs: ors}, "")
+	assert.ErrorIs(t, k.DeleteOrg(ctx, 1, 2), ErrPermissionDenied)
+	assert.NoError(t, k.DeleteOrg(ctx, 1, 1))
+}
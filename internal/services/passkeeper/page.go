@@ -0,0 +1,30 @@
+package passkeeper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/vindosVP/go-pass/internal/models"
+)
+
+// encodePageToken turns the cursor to the opaque page token.
+func encodePageToken(c *models.Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken turns the page token back to the cursor.
+func decodePageToken(token string) (*models.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	c := &models.Cursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if !c.Type.Known() {
+		return nil, ErrInvalidPageToken
+	}
+	return c, nil
+}
@@ -6,11 +6,16 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/vindosVP/go-pass/internal/filemanager"
+	"github.com/vindosVP/go-pass/internal/labels"
 	"github.com/vindosVP/go-pass/internal/models"
 	passkeeperv1 "github.com/vindosVP/go-pass/internal/proto/passkeeper"
 	"github.com/vindosVP/go-pass/internal/storage"
@@ -28,170 +33,331 @@ var (
 
 	// ErrUnableToSaveFile - error if tried to save file, to save file, use SaveFile method
 	ErrUnableToSaveFile = errors.New("unable to save file")
+
+	// ErrEntityNotFound - matches the storage errors if entity does not exist or belongs to another user
+	ErrEntityNotFound = storage.ErrNotFound
+
+	// ErrInvalidPageToken - error if page token is malformed
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrInvalidSortField - error if entities can not be sorted by the field
+	ErrInvalidSortField = errors.New("invalid sort field")
+
+	// ErrRevisionMismatch - matches the storage errors if the entity has been changed since the expected revision
+	ErrRevisionMismatch = storage.ErrRevisionMismatch
+
+	// ErrNotVersioned - error if the entity type does not keep the version history
+	ErrNotVersioned = errors.New("entity type is not versioned")
+
+	// ErrInvalidUpdateMask - error if update mask contains fields the entity does not have
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+
+	// ErrEmptyName - error if folder or tag name is empty
+	ErrEmptyName = errors.New("name must not be empty")
+
+	// ErrInvalidLabels - error if entity label key or value is malformed
+	ErrInvalidLabels = labels.ErrInvalidLabels
+
+	// ErrInvalidCustomFields - error if custom field has no name, unknown type or malformed value
+	ErrInvalidCustomFields = errors.New("invalid custom fields")
+
+	// ErrEmptyQuery - error if search query is empty
+	ErrEmptyQuery = errors.New("search query must not be empty")
+
+	// ErrFolderCycle - matches the storage error if the folder is moved into itself or its subfolder
+	ErrFolderCycle = storage.ErrFolderCycle
 )
 
-// PasswordStorage is a password storage API
+// EntityStorage is a storage API for the entities of all types, the entity is identified by its type and id
 //
-//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=PasswordStorage
-type PasswordStorage interface {
-	AddPassword(ctx context.Context, pwd *models.Password) (int, error)
-	UpdatePassword(ctx context.Context, pwd *models.Password) error
-	DeletePassword(ctx context.Context, id int, ownerID int) error
-	GetPasswords(ctx context.Context, ownerID int) ([]*models.Password, error)
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=EntityStorage
+type EntityStorage interface {
+	AddEntity(ctx context.Context, e *models.Entity) (int, error)
+	UpdateEntity(ctx context.Context, e *models.Entity, fields []models.EntityField) error
+	DeleteEntity(ctx context.Context, t models.EntityType, id int, ownerID int, revision int) error
+	GetEntity(ctx context.Context, t models.EntityType, id int, ownerID int) (*models.Entity, error)
+	ListEntities(ctx context.Context, ownerID int, opts models.ListOptions) ([]*models.Entity, error)
+	IterateEntities(ctx context.Context, ownerID int, opts models.ListOptions, fn func(e *models.Entity) error) error
+	SearchEntities(ctx context.Context, ownerID int, opts models.SearchOptions) ([]*models.SearchResult, error)
 }
 
-// CardStorage is a card storage API
+// FileStorage is a storage API for the file uploads
 //
-//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=CardStorage
-type CardStorage interface {
-	AddCard(ctx context.Context, card *models.Card) (int, error)
-	UpdateCard(ctx context.Context, card *models.Card) error
-	DeleteCard(ctx context.Context, id int, ownerID int) error
-	GetCards(ctx context.Context, ownerID int) ([]*models.Card, error)
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=FileStorage
+type FileStorage interface {
+	MarkFileAsUploaded(ctx context.Context, id int, ownerID int) error
+	DeleteStaleUploads(ctx context.Context, before time.Time) ([]*models.Entity, error)
 }
 
-// TextStorage is a text storage API
+// VersionStorage is a storage API for the archived entity versions
 //
-//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=TextStorage
-type TextStorage interface {
-	AddText(ctx context.Context, t *models.Text) (int, error)
-	UpdateText(ctx context.Context, t *models.Text) error
-	DeleteText(ctx context.Context, id int, ownerID int) error
-	GetTexts(ctx context.Context, ownerID int) ([]*models.Text, error)
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=VersionStorage
+type VersionStorage interface {
+	ListVersions(ctx context.Context, t models.EntityType, id int, ownerID int) ([]*models.Version, error)
+	GetVersion(ctx context.Context, t models.EntityType, id int, ownerID int, revision int) (*models.Version, error)
+	DeleteOldVersions(ctx context.Context, keep int) (int64, error)
 }
 
-// FileStorage is a file storage API
+// TrashStorage is a storage API for the entities moved to the trash
 //
-//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=FileStorage
-type FileStorage interface {
-	GetFiles(ctx context.Context, ownerID int) ([]*models.File, error)
-	GetFile(ctx context.Context, id int, ownerID int) (*models.File, error)
-	DeleteFile(ctx context.Context, id int, ownerID int) error
-	AddFile(ctx context.Context, f *models.File) (int, error)
-	MarkFileAsUploaded(ctx context.Context, id int, ownerID int) error
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=TrashStorage
+type TrashStorage interface {
+	RestoreEntity(ctx context.Context, t models.EntityType, id int, ownerID int) error
+	PurgeEntity(ctx context.Context, t models.EntityType, id int, ownerID int) (*models.Entity, error)
+	PurgeTrash(ctx context.Context, before time.Time) ([]*models.Entity, error)
+}
+
+// FolderStorage is a storage API for the user`s folders tree
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=FolderStorage
+type FolderStorage interface {
+	CreateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error)
+	UpdateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error)
+	DeleteFolder(ctx context.Context, id int, ownerID int) error
+	ListFolders(ctx context.Context, ownerID int) ([]*models.Folder, error)
+}
+
+// TagStorage is a storage API for the user`s tags
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=TagStorage
+type TagStorage interface {
+	CreateTag(ctx context.Context, t *models.Tag) (*models.Tag, error)
+	RenameTag(ctx context.Context, t *models.Tag) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id int, ownerID int) error
+	ListTags(ctx context.Context, ownerID int) ([]*models.Tag, error)
 }
 
 type Keeper struct {
-	ps    PasswordStorage
-	cs    CardStorage
-	ts    TextStorage
+	es    EntityStorage
 	fs    FileStorage
+	vs    VersionStorage
+	tr    TrashStorage
+	fos   FolderStorage
+	ts    TagStorage
+	cs    ChangeStorage
+	cf    ChangeFeed
+	cos   ConflictStorage
+	ss    ShareStorage
+	ors   OrgStorage
+	sds   SendStorage
+	ems   EmergencyStorage
+	acs   AccessStorage
+	af    ChangeFeed
 	fPath string
 }
 
-const chunkSize = 4 * 1024
+const (
+	chunkSize = 4 * 1024
+
+	defaultPageSize = 100
+	maxPageSize     = 1000
+
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
 
-// List returns all user`s entities.
-func (k *Keeper) List(ctx context.Context, ownerID int) ([]*models.Entity, error) {
+// List returns a page of user`s entities matching the list options and the next page token.
+// The next page token is empty for the last page.
+func (k *Keeper) List(ctx context.Context, ownerID int, opts models.ListOptions) ([]*models.Entity, string, error) {
 
 	sl.Log.Info("getting list of entities")
 
-	res := make([]*models.Entity, 0)
+	if opts.SortBy == "" {
+		opts.SortBy = models.SortByCreatedAt
+	}
+	if opts.SortBy != models.SortByCreatedAt && opts.SortBy != models.SortByUpdatedAt {
+		return nil, "", ErrInvalidSortField
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.PageSize > maxPageSize {
+		opts.PageSize = maxPageSize
+	}
+	pageSize := opts.PageSize
+	if opts.PageToken != "" {
+		c, err := decodePageToken(opts.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.After = c
+	}
+	// one extra entity is requested to find out if there is the next page
+	opts.PageSize++
 
-	pwds, err := k.ps.GetPasswords(ctx, ownerID)
+	res, err := k.es.ListEntities(ctx, ownerID, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	for _, pwd := range pwds {
-		res = append(res, pwd.ToEntity())
+
+	sealUnapproved(res...)
+	if len(res) <= pageSize {
+		return res, "", nil
 	}
+	res = res[:pageSize]
+	return res, encodePageToken(res[pageSize-1].Cursor(opts.SortBy)), nil
+}
 
-	cards, err := k.cs.GetCards(ctx, ownerID)
-	if err != nil {
-		return nil, err
+// Search returns the user`s entities matching the query, the best matches first.
+func (k *Keeper) Search(ctx context.Context, ownerID int, opts models.SearchOptions) ([]*models.SearchResult, error) {
+
+	sl.Log.Info("searching entities")
+
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Query == "" {
+		return nil, ErrEmptyQuery
+	}
+	for _, t := range opts.Types {
+		if !t.Known() {
+			sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+			return nil, ErrUnknownEntity
+		}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultSearchLimit
 	}
-	for _, card := range cards {
-		res = append(res, card.ToEntity())
+	if opts.Limit > maxSearchLimit {
+		opts.Limit = maxSearchLimit
 	}
 
-	texts, err := k.ts.GetTexts(ctx, ownerID)
+	res, err := k.es.SearchEntities(ctx, ownerID, opts)
 	if err != nil {
 		return nil, err
 	}
-	for _, text := range texts {
-		res = append(res, text.ToEntity())
+	for _, r := range res {
+		if r.Entity.RequiresApproval && !r.Entity.Approver {
+			r.Entity.Seal()
+			r.Snippet = ""
+		}
 	}
+	return res, nil
+}
+
+// Stream calls send for each user`s entity matching the list options as they are read
+// from the storage, entities are ordered by the creation time. Pagination options are ignored.
+func (k *Keeper) Stream(ctx context.Context, ownerID int, opts models.ListOptions, send func(e *models.Entity) error) error {
+
+	sl.Log.Info("streaming entities")
+
+	opts.PageSize = 0
+	opts.PageToken = ""
+	opts.After = nil
+
+	return k.es.IterateEntities(ctx, ownerID, opts, func(e *models.Entity) error {
+		sealUnapproved(e)
+		return send(e)
+	})
+}
 
-	files, err := k.fs.GetFiles(ctx, ownerID)
+// Get returns the user`s entity. The entity requiring approval is returned to its approvers
+// and within the view window of the user`s approved request only, the read is audited.
+func (k *Keeper) Get(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error) {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return nil, ErrUnknownEntity
+	}
+	sl.Log.Info("getting entity", slog.String("type", string(t)), slog.Int("id", id))
+	e, err := k.es.GetEntity(ctx, t, id, ownerID)
 	if err != nil {
 		return nil, err
 	}
-	for _, file := range files {
-		res = append(res, file.ToEntity())
+	if err := k.approveRead(ctx, e, ownerID); err != nil {
+		return nil, err
 	}
-
-	return res, nil
+	return e, nil
 }
 
 // Save saves the entity (password, card or text).
 func (k *Keeper) Save(ctx context.Context, e *models.Entity) (int, error) {
-	switch e.Type {
-	case models.TypePassword:
-		sl.Log.Info("adding new password")
-		return k.ps.AddPassword(ctx, e.ToPassword())
-	case models.TypeCard:
-		sl.Log.Info("adding new card")
-		return k.cs.AddCard(ctx, e.ToCard())
-	case models.TypeText:
-		sl.Log.Info("adding new text")
-		return k.ts.AddText(ctx, e.ToText())
-	case models.TypeFile:
+	if e.Type == models.TypeFile {
 		sl.Log.Error("unable to save file")
 		return 0, ErrUnableToSaveFile
 	}
-	sl.Log.Error("unknown entity type", slog.String("type", string(e.Type)))
-	return 0, ErrUnknownEntity
-}
-
-// Delete deletes the entity.
-func (k *Keeper) Delete(ctx context.Context, id int, ownerID int, t models.EntityType) error {
-	switch t {
-	case models.TypePassword:
-		sl.Log.Info("deleting password", slog.Int("id", id))
-		return k.ps.DeletePassword(ctx, id, ownerID)
-	case models.TypeCard:
-		sl.Log.Info("deleting card", slog.Int("id", id))
-		return k.cs.DeleteCard(ctx, id, ownerID)
-	case models.TypeText:
-		sl.Log.Info("deleting text", slog.Int("id", id))
-		return k.ts.DeleteText(ctx, id, ownerID)
-	case models.TypeFile:
-		sl.Log.Info("deleting text", slog.Int("id", id))
-		file, err := k.fs.GetFile(ctx, id, ownerID)
-		if err != nil {
+	if !e.Type.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(e.Type)))
+		return 0, ErrUnknownEntity
+	}
+	if err := labels.Validate(e.Labels); err != nil {
+		sl.Log.Info("invalid labels", sl.Err(err))
+		return 0, err
+	}
+	if err := validateCustom(e.Custom); err != nil {
+		sl.Log.Info("invalid custom fields", sl.Err(err))
+		return 0, err
+	}
+	sl.Log.Info("adding new entity", slog.String("type", string(e.Type)))
+	return k.es.AddEntity(ctx, e)
+}
+
+// Delete moves the entity to the trash, the file content is kept until the entity is purged.
+// If revision is set, the entity is deleted only if it has not been changed since that revision.
+func (k *Keeper) Delete(ctx context.Context, id int, ownerID int, t models.EntityType, revision int) error {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return ErrUnknownEntity
+	}
+	sl.Log.Info("deleting entity", slog.String("type", string(t)), slog.Int("id", id))
+	return k.es.DeleteEntity(ctx, t, id, ownerID, revision)
+}
+
+// Update updates the entity (password, card or text) fields, all fields but the folder and the tags if none provided.
+// The file can only be moved to another folder and retagged.
+// If e.Revision is set, the entity is updated only if it has not been changed since that revision.
+func (k *Keeper) Update(ctx context.Context, e *models.Entity, fields []models.EntityField) error {
+	if err := validateUpdate(e, fields); err != nil {
+		return err
+	}
+	sl.Log.Info("updating entity", slog.String("type", string(e.Type)), slog.Int("id", e.ID))
+	return k.es.UpdateEntity(ctx, e, fields)
+}
+
+// validateUpdate checks that the entity fields can be updated and their values are valid.
+func validateUpdate(e *models.Entity, fields []models.EntityField) error {
+	if e.Type == models.TypeFile && !organizeOnly(fields) {
+		sl.Log.Error("unable to update file")
+		return ErrUnableToUpdateFile
+	}
+	if _, ok := models.UpdatableFields[e.Type]; !ok && e.Type != models.TypeFile {
+		sl.Log.Error("unknown entity type", slog.String("type", string(e.Type)))
+		return ErrUnknownEntity
+	}
+	if err := validateFields(e.Type, fields); err != nil {
+		sl.Log.Info("invalid update mask", sl.Err(err))
+		return err
+	}
+	if slices.Contains(fields, models.FieldLabels) {
+		if err := labels.Validate(e.Labels); err != nil {
+			sl.Log.Info("invalid labels", sl.Err(err))
 			return err
 		}
-		deleter := filemanager.NewFileDeleter()
-		filename := fmt.Sprintf("%d_%s", file.ID, file.FileName)
-		deleter.SetFile(filename, k.fPath)
-		err = deleter.Delete()
-		if err != nil {
+	}
+	if len(fields) == 0 || slices.Contains(fields, models.FieldCustom) {
+		if err := validateCustom(e.Custom); err != nil {
+			sl.Log.Info("invalid custom fields", sl.Err(err))
 			return err
 		}
-		return k.fs.DeleteFile(ctx, id, ownerID)
-	}
-	sl.Log.Error("unknown entity type", slog.String("type", string(t)))
-	return ErrUnknownEntity
-}
-
-// Update updates the entity (password, card or text).
-func (k *Keeper) Update(ctx context.Context, e *models.Entity) error {
-	switch e.Type {
-	case models.TypePassword:
-		sl.Log.Info("updating password", slog.Int("id", e.ID))
-		return k.ps.UpdatePassword(ctx, e.ToPassword())
-	case models.TypeCard:
-		sl.Log.Info("updating card", slog.Int("id", e.ID))
-		return k.cs.UpdateCard(ctx, e.ToCard())
-	case models.TypeText:
-		sl.Log.Info("updating text", slog.Int("id", e.ID))
-		return k.ts.UpdateText(ctx, e.ToText())
-	case models.TypeFile:
-		sl.Log.Error("unable to update file")
-		return ErrUnableToUpdateFile
 	}
-	sl.Log.Error("unknown entity type", slog.String("type", string(e.Type)))
-	return ErrUnknownEntity
+	return nil
+}
+
+// organizeOnly reports whether only the folder and the tags are updated.
+func organizeOnly(fields []models.EntityField) bool {
+	for _, f := range fields {
+		if !slices.Contains(models.OrganizeFields, f) {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+// validateFields checks that all fields can be updated for the entity type.
+func validateFields(t models.EntityType, fields []models.EntityField) error {
+	for _, f := range fields {
+		if !slices.Contains(models.UpdatableFields[t], f) && !slices.Contains(models.OrganizeFields, f) {
+			return fmt.Errorf("%w: %s can not be updated for %s", ErrInvalidUpdateMask, f, t)
+		}
+	}
+	return nil
 }
 
 // SaveFile saves the file.
@@ -222,12 +388,32 @@ func (k *Keeper) SaveFile(str passkeeperv1.PassKeeper_UploadFileServer) error {
 				return status.Errorf(codes.InvalidArgument, "failed to extract uid")
 			}
 			ownerID = uid
-			file := &models.File{
+			file := &models.Entity{
 				OwnerID:  uid,
-				FileName: req.Filename,
+				Type:     models.TypeFile,
+				Filename: req.Filename,
 				Metadata: req.Metadata,
+				FolderID: int(req.FolderId),
+				Labels:   req.Labels,
+				Custom:   CustomFieldsFromGRPC(req.CustomFields),
+				VaultID:  int(req.VaultId),
+			}
+			if err := labels.Validate(file.Labels); err != nil {
+				lg.Info("invalid file labels", sl.Err(err))
+				return status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			if err := validateCustom(file.Custom); err != nil {
+				lg.Info("invalid file custom fields", sl.Err(err))
+				return status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			for _, id := range req.TagIds {
+				file.TagIDs = append(file.TagIDs, int(id))
+			}
+			id, err := k.es.AddEntity(str.Context(), file)
+			if errors.Is(err, storage.ErrNotFound) {
+				lg.Info("file folder or tag not found", sl.Err(err))
+				return status.Errorf(codes.NotFound, "%v", err)
 			}
-			id, err := k.fs.AddFile(str.Context(), file)
 			if err != nil {
 				lg.Error("failed to save file", sl.Err(err))
 				return status.Errorf(codes.Internal, "failed to save file")
@@ -274,7 +460,7 @@ func (k *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_D
 	lg := sl.Log
 	lg.Info("handling download file request")
 
-	file, err := k.fs.GetFile(str.Context(), id, ownerID)
+	file, err := k.es.GetEntity(str.Context(), models.TypeFile, id, ownerID)
 	if err != nil {
 		if errors.Is(err, storage.ErrFileNotExist) {
 			lg.Info("file not found")
@@ -283,8 +469,16 @@ func (k *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_D
 		lg.Error("failed to download file", sl.Err(err))
 		return status.Errorf(codes.Internal, "failed to download file")
 	}
+	if err := k.approveRead(str.Context(), file, ownerID); err != nil {
+		if errors.Is(err, ErrApprovalRequired) {
+			lg.Info("file download is not approved")
+			return status.Errorf(codes.PermissionDenied, "file download has to be approved")
+		}
+		lg.Error("failed to download file", sl.Err(err))
+		return status.Errorf(codes.Internal, "failed to download file")
+	}
 
-	filename := fmt.Sprintf("%d_%s", file.ID, file.FileName)
+	filename := fmt.Sprintf("%d_%s", file.ID, file.Filename)
 	fileReader := filemanager.NewFileReader(chunkSize)
 	err = fileReader.SetFile(filename, k.fPath)
 	if err != nil {
@@ -294,7 +488,7 @@ func (k *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_D
 	defer fileReader.Close()
 	for fileReader.Next() {
 		resp := &passkeeperv1.DownloadFileResponse{
-			Filename: file.FileName,
+			Filename: file.Filename,
 			Chunk:    fileReader.Data(),
 		}
 		err = str.Send(resp)
@@ -307,7 +501,277 @@ func (k *Keeper) DownloadFile(id int, ownerID int, str passkeeperv1.PassKeeper_D
 	return nil
 }
 
+// CleanupOrphanedUploads deletes files which upload has not been finished within ttl.
+func (k *Keeper) CleanupOrphanedUploads(ctx context.Context, ttl time.Duration) error {
+
+	lg := sl.Log
+	lg.Info("cleaning up orphaned uploads")
+
+	files, err := k.fs.DeleteStaleUploads(ctx, time.Now().Add(-ttl))
+	if err != nil {
+		lg.Error("failed to delete stale uploads", sl.Err(err))
+		return err
+	}
+
+	deleter := filemanager.NewFileDeleter()
+	for _, file := range files {
+		deleter.SetFile(fmt.Sprintf("%d_%s", file.ID, file.Filename), k.fPath)
+		if err := deleter.Delete(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			lg.Error("failed to delete orphaned file", slog.Int("id", file.ID), sl.Err(err))
+		}
+	}
+
+	lg.Info("cleaned up orphaned uploads", slog.Int("count", len(files)))
+	return nil
+}
+
+// Versions returns the prior versions of the user`s entity (password, card or text), the latest first.
+func (k *Keeper) Versions(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Version, error) {
+	if err := versionedType(t); err != nil {
+		return nil, err
+	}
+	// the entity is checked, so the missing entity is not reported as the empty history
+	e, err := k.Get(ctx, id, ownerID, t)
+	if err != nil {
+		return nil, err
+	}
+	// the history is kept for the owner only
+	if e.Permission != "" {
+		return nil, ErrEntityNotFound
+	}
+	sl.Log.Info("getting entity versions", slog.Int("id", id))
+	return k.vs.ListVersions(ctx, t, id, ownerID)
+}
+
+// Restore makes the prior entity version with the provided revision the current one and returns the entity,
+// sealed if its read is not approved. The current version is archived as any other update does.
+// If expectedRevision is set, the entity is restored only if it has not been changed since that revision.
+func (k *Keeper) Restore(ctx context.Context, id int, ownerID int, t models.EntityType, revision int, expectedRevision int) (*models.Entity, error) {
+	if err := versionedType(t); err != nil {
+		return nil, err
+	}
+	sl.Log.Info("restoring entity version", slog.Int("id", id), slog.Int("revision", revision))
+	v, err := k.vs.GetVersion(ctx, t, id, ownerID, revision)
+	if err != nil {
+		return nil, err
+	}
+	e := v.Entity
+	e.ID = id
+	e.OwnerID = ownerID
+	e.Type = t
+	e.Revision = expectedRevision
+	if err := k.Update(ctx, e, nil); err != nil {
+		return nil, err
+	}
+	return k.getRestored(ctx, id, ownerID, t)
+}
+
+// getRestored returns the restored entity as Get does, but the entity requiring approval is sealed instead of
+// failing the unapproved read, so the applied restore is not reported as failed.
+func (k *Keeper) getRestored(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error) {
+	e, err := k.es.GetEntity(ctx, t, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.approveRead(ctx, e, ownerID); err != nil {
+		if !errors.Is(err, ErrApprovalRequired) {
+			return nil, err
+		}
+		e.Seal()
+	}
+	return e, nil
+}
+
+// PruneVersions deletes all but the keep latest versions of every entity.
+func (k *Keeper) PruneVersions(ctx context.Context, keep int) error {
+
+	lg := sl.Log
+	lg.Info("pruning entity versions", slog.Int("keep", keep))
+
+	n, err := k.vs.DeleteOldVersions(ctx, keep)
+	if err != nil {
+		lg.Error("failed to delete old versions", sl.Err(err))
+		return err
+	}
+
+	lg.Info("pruned entity versions", slog.Int64("count", n))
+	return nil
+}
+
+// versionedType checks that the entity type keeps the version history.
+func versionedType(t models.EntityType) error {
+	if t == models.TypeFile {
+		return ErrNotVersioned
+	}
+	if _, ok := models.UpdatableFields[t]; !ok {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return ErrUnknownEntity
+	}
+	return nil
+}
+
+// Trash returns the user`s entities in the trash, the latest deleted first.
+func (k *Keeper) Trash(ctx context.Context, ownerID int) ([]*models.Entity, error) {
+	sl.Log.Info("getting trash")
+	res, err := k.es.ListEntities(ctx, ownerID, models.ListOptions{
+		Trashed: true,
+		SortBy:  models.SortByDeletedAt,
+		Desc:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sealUnapproved(res...)
+	return res, nil
+}
+
+// RestoreFromTrash moves the entity back from the trash and returns it, sealed if its read is not approved.
+func (k *Keeper) RestoreFromTrash(ctx context.Context, id int, ownerID int, t models.EntityType) (*models.Entity, error) {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return nil, ErrUnknownEntity
+	}
+	sl.Log.Info("restoring entity from trash", slog.Int("id", id))
+	if err := k.tr.RestoreEntity(ctx, t, id, ownerID); err != nil {
+		return nil, err
+	}
+	return k.getRestored(ctx, id, ownerID, t)
+}
+
+// Purge permanently deletes the entity in the trash and the file content.
+func (k *Keeper) Purge(ctx context.Context, id int, ownerID int, t models.EntityType) error {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return ErrUnknownEntity
+	}
+	sl.Log.Info("purging entity", slog.Int("id", id))
+	e, err := k.tr.PurgeEntity(ctx, t, id, ownerID)
+	if err != nil {
+		return err
+	}
+	return k.deleteContent(e)
+}
+
+// PurgeTrash permanently deletes the entities which have been in the trash longer than retention.
+func (k *Keeper) PurgeTrash(ctx context.Context, retention time.Duration) error {
+
+	lg := sl.Log
+	lg.Info("purging trash")
+
+	purged, err := k.tr.PurgeTrash(ctx, time.Now().Add(-retention))
+	if err != nil {
+		lg.Error("failed to purge trash", sl.Err(err))
+		return err
+	}
+	for _, e := range purged {
+		if err := k.deleteContent(e); err != nil {
+			lg.Error("failed to delete purged file", slog.Int("id", e.ID), sl.Err(err))
+		}
+	}
+
+	lg.Info("purged trash", slog.Int("count", len(purged)))
+	return nil
+}
+
+// deleteContent deletes the content of the purged file entity, the missing content is not an error.
+func (k *Keeper) deleteContent(e *models.Entity) error {
+	if e.Type != models.TypeFile {
+		return nil
+	}
+	deleter := filemanager.NewFileDeleter()
+	deleter.SetFile(fmt.Sprintf("%d_%s", e.ID, e.Filename), k.fPath)
+	err := deleter.Delete()
+	if errors.Is(err, os.ErrNotExist) {
+		sl.Log.Info("file content does not exist", slog.Int("id", e.ID))
+		return nil
+	}
+	return err
+}
+
+// CreateFolder creates the user`s folder.
+func (k *Keeper) CreateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	if f.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("creating folder", slog.Int("parent", f.ParentID))
+	return k.fos.CreateFolder(ctx, f)
+}
+
+// UpdateFolder renames the user`s folder and moves it to the parent folder, to the top level if the parent is 0.
+func (k *Keeper) UpdateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	if f.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("updating folder", slog.Int("id", f.ID), slog.Int("parent", f.ParentID))
+	return k.fos.UpdateFolder(ctx, f)
+}
+
+// DeleteFolder deletes the user`s folder, the entities and subfolders are moved to its parent folder,
+// so no entity is deleted with the folder.
+func (k *Keeper) DeleteFolder(ctx context.Context, id int, ownerID int) error {
+	sl.Log.Info("deleting folder", slog.Int("id", id))
+	return k.fos.DeleteFolder(ctx, id, ownerID)
+}
+
+// Folders returns all user`s folders.
+func (k *Keeper) Folders(ctx context.Context, ownerID int) ([]*models.Folder, error) {
+	sl.Log.Info("getting folders")
+	return k.fos.ListFolders(ctx, ownerID)
+}
+
+// CreateTag creates the user`s tag.
+func (k *Keeper) CreateTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	if t.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("creating tag")
+	return k.ts.CreateTag(ctx, t)
+}
+
+// RenameTag renames the user`s tag.
+func (k *Keeper) RenameTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	if t.Name == "" {
+		return nil, ErrEmptyName
+	}
+	sl.Log.Info("renaming tag", slog.Int("id", t.ID))
+	return k.ts.RenameTag(ctx, t)
+}
+
+// DeleteTag deletes the user`s tag, the tagged entities are kept.
+func (k *Keeper) DeleteTag(ctx context.Context, id int, ownerID int) error {
+	sl.Log.Info("deleting tag", slog.Int("id", id))
+	return k.ts.DeleteTag(ctx, id, ownerID)
+}
+
+// Tags returns all user`s tags.
+func (k *Keeper) Tags(ctx context.Context, ownerID int) ([]*models.Tag, error) {
+	sl.Log.Info("getting tags")
+	return k.ts.ListTags(ctx, ownerID)
+}
+
+// Deps consists the storages and the feeds the Keeper works with.
+// The dependencies the called methods do not use can be left unset.
+type Deps struct {
+	Entities   EntityStorage
+	Files      FileStorage
+	Versions   VersionStorage
+	Trash      TrashStorage
+	Folders    FolderStorage
+	Tags       TagStorage
+	Changes    ChangeStorage
+	ChangeFeed ChangeFeed
+	Conflicts  ConflictStorage
+	Shares     ShareStorage
+	Orgs       OrgStorage
+	Sends      SendStorage
+	Emergency  EmergencyStorage
+	Access     AccessStorage
+	AccessFeed ChangeFeed // notifies the users of the access requests sent to or decided for them
+}
+
 // New creates a new Keeper instance
-func New(ps PasswordStorage, cs CardStorage, ts TextStorage, fs FileStorage, fPath string) *Keeper {
-	return &Keeper{ps: ps, cs: cs, ts: ts, fs: fs, fPath: fPath}
+func New(d Deps, fPath string) *Keeper {
+	return &Keeper{es: d.Entities, fs: d.Files, vs: d.Versions, tr: d.Trash, fos: d.Folders, ts: d.Tags,
+		cs: d.Changes, cf: d.ChangeFeed, cos: d.Conflicts, ss: d.Shares, ors: d.Orgs, sds: d.Sends,
+		ems: d.Emergency, acs: d.Access, af: d.AccessFeed, fPath: fPath}
 }
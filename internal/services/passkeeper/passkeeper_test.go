@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,104 +19,6 @@ import (
 	"github.com/vindosVP/go-pass/pkg/logger/sl"
 )
 
-func TestKeeper_SavePassword(t *testing.T) {
-
-	unexpected := errors.New("unexpected error")
-	type w struct {
-		id  int
-		err error
-	}
-	type m struct {
-		needed bool
-		id     int
-		err    error
-	}
-
-	tests := []struct {
-		name string
-		e    *models.Entity
-		m    m
-		w    w
-	}{
-		{
-			name: "ok",
-			e: &models.Entity{
-				ID:       1,
-				OwnerID:  1,
-				Type:     models.TypePassword,
-				Login:    "login",
-				Password: "password",
-				Metadata: "metadata",
-			},
-			m: m{
-				needed: true,
-				id:     1,
-				err:    nil,
-			},
-			w: w{
-				id:  1,
-				err: nil,
-			},
-		},
-		{
-			name: "unexpected",
-			e: &models.Entity{
-				ID:       1,
-				OwnerID:  1,
-				Type:     models.TypePassword,
-				Login:    "login",
-				Password: "password",
-				Metadata: "metadata",
-			},
-			m: m{
-				needed: true,
-				id:     0,
-				err:    unexpected,
-			},
-			w: w{
-				id:  0,
-				err: unexpected,
-			},
-		},
-		{
-			name: "unknown",
-			e: &models.Entity{
-				ID:       1,
-				OwnerID:  1,
-				Type:     "UNKNOWN",
-				Login:    "login",
-				Password: "password",
-				Metadata: "metadata",
-			},
-			m: m{
-				needed: false,
-			},
-			w: w{
-				id:  0,
-				err: ErrUnknownEntity,
-			},
-		},
-	}
-
-	sl.SetupLogger("test")
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			ps := mocks.NewPasswordStorage(t)
-			if tt.m.needed {
-				ps.On("AddPassword", mock.Anything, tt.e.ToPassword()).Return(tt.m.id, tt.m.err)
-			}
-			k := New(ps, nil, nil, nil, "")
-			id, err := k.Save(ctx, tt.e)
-			if tt.w.err == nil {
-				assert.Equal(t, tt.w.id, id)
-			}
-			assert.ErrorIs(t, tt.w.err, err)
-		})
-	}
-}
-
 func TestKeeper_Save(t *testing.T) {
 
 	unexpected := errors.New("unexpected error")
@@ -123,17 +26,7 @@ func TestKeeper_Save(t *testing.T) {
 		id  int
 		err error
 	}
-	type psM struct {
-		needed bool
-		id     int
-		err    error
-	}
-	type csM struct {
-		needed bool
-		id     int
-		err    error
-	}
-	type tsM struct {
+	type esM struct {
 		needed bool
 		id     int
 		err    error
@@ -141,9 +34,7 @@ func TestKeeper_Save(t *testing.T) {
 	type testCases []struct {
 		name string
 		e    models.Entity
-		psM  psM
-		csM  csM
-		tsM  tsM
+		esM  esM
 		w    w
 	}
 
@@ -162,15 +53,7 @@ func TestKeeper_Save(t *testing.T) {
 				Text:       "text",
 				Metadata:   "metadata",
 			},
-			csM: csM{
-				id:  1,
-				err: nil,
-			},
-			psM: psM{
-				id:  1,
-				err: nil,
-			},
-			tsM: tsM{
+			esM: esM{
 				id:  1,
 				err: nil,
 			},
@@ -193,15 +76,7 @@ func TestKeeper_Save(t *testing.T) {
 				Text:       "text",
 				Metadata:   "metadata",
 			},
-			csM: csM{
-				id:  0,
-				err: unexpected,
-			},
-			psM: psM{
-				id:  0,
-				err: unexpected,
-			},
-			tsM: tsM{
+			esM: esM{
 				id:  0,
 				err: unexpected,
 			},
@@ -212,20 +87,22 @@ func TestKeeper_Save(t *testing.T) {
 		},
 	}
 
-	cases := make(testCases, 0, len(template)*len(eTypes()))
+	cases := make(testCases, 0, len(template)*(len(eTypes())+1))
 
-	for _, et := range eTypes() {
+	for _, et := range append(eTypes(), "UNKNOWN") {
 		tc := make(testCases, len(template), len(template))
 		copy(tc, template)
 		for i, tt := range tc {
-			tc[i].psM.needed = et == models.TypePassword
-			tc[i].csM.needed = et == models.TypeCard
-			tc[i].tsM.needed = et == models.TypeText
+			tc[i].esM.needed = et != models.TypeFile && et.Known()
 			tc[i].name = fmt.Sprintf("%s_%s", tt.name, et)
 			tc[i].e.Type = et
-			if et == models.TypeFile {
+			switch {
+			case et == models.TypeFile:
 				tc[i].w.id = 0
 				tc[i].w.err = ErrUnableToSaveFile
+			case !et.Known():
+				tc[i].w.id = 0
+				tc[i].w.err = ErrUnknownEntity
 			}
 		}
 		cases = append(cases, tc...)
@@ -237,19 +114,11 @@ func TestKeeper_Save(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			ps := mocks.NewPasswordStorage(t)
-			cs := mocks.NewCardStorage(t)
-			ts := mocks.NewTextStorage(t)
-			if tt.psM.needed {
-				ps.On("AddPassword", mock.Anything, tt.e.ToPassword()).Return(tt.psM.id, tt.psM.err)
+			es := mocks.NewEntityStorage(t)
+			if tt.esM.needed {
+				es.On("AddEntity", mock.Anything, &tt.e).Return(tt.esM.id, tt.esM.err)
 			}
-			if tt.csM.needed {
-				cs.On("AddCard", mock.Anything, tt.e.ToCard()).Return(tt.csM.id, tt.csM.err)
-			}
-			if tt.tsM.needed {
-				ts.On("AddText", mock.Anything, tt.e.ToText()).Return(tt.tsM.id, tt.tsM.err)
-			}
-			k := New(ps, cs, ts, nil, "")
+			k := New(Deps{Entities: es}, "")
 			id, err := k.Save(ctx, &tt.e)
 			if tt.w.err == nil {
 				assert.Equal(t, tt.w.id, id)
@@ -259,30 +128,145 @@ func TestKeeper_Save(t *testing.T) {
 	}
 }
 
+func TestKeeper_SaveLabels(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	e := &models.Entity{OwnerID: 1, Type: models.TypeText, Labels: map[string]string{"env": "prod"}}
+	es.On("AddEntity", mock.Anything, e).Return(1, nil).Once()
+	_, err := k.Save(ctx, e)
+	assert.NoError(t, err)
+
+	_, err = k.Save(ctx, &models.Entity{OwnerID: 1, Type: models.TypeText, Labels: map[string]string{"env": "prod env"}})
+	assert.ErrorIs(t, err, ErrInvalidLabels)
+
+	err = k.Update(ctx, &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeFile, Labels: map[string]string{"-env": "prod"}},
+		[]models.EntityField{models.FieldLabels})
+	assert.ErrorIs(t, err, ErrInvalidLabels)
+}
+
+func TestKeeper_Search(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		opts   models.SearchOptions
+		stored *models.SearchOptions
+		err    error
+	}{
+		{
+			name:   "default limit",
+			opts:   models.SearchOptions{Query: " github "},
+			stored: &models.SearchOptions{Query: "github", Limit: defaultSearchLimit},
+		},
+		{
+			name:   "max limit",
+			opts:   models.SearchOptions{Query: "github", Types: []models.EntityType{models.TypeCard}, Limit: 1000},
+			stored: &models.SearchOptions{Query: "github", Types: []models.EntityType{models.TypeCard}, Limit: maxSearchLimit},
+		},
+		{
+			name: "empty query",
+			opts: models.SearchOptions{Query: "  "},
+			err:  ErrEmptyQuery,
+		},
+		{
+			name: "unknown type",
+			opts: models.SearchOptions{Query: "github", Types: []models.EntityType{"UNKNOWN"}},
+			err:  ErrUnknownEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEntityStorage(t)
+			if tt.stored != nil {
+				es.On("SearchEntities", mock.Anything, 1, *tt.stored).Return([]*models.SearchResult{}, nil)
+			}
+			k := New(Deps{Entities: es}, "")
+			_, err := k.Search(ctx, 1, tt.opts)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestKeeper_SaveCustomFields(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	e := &models.Entity{OwnerID: 1, Type: models.TypePassword, Custom: []models.CustomField{
+		{Name: "pin", Type: models.CustomHidden, Value: "1234"},
+		{Name: "site", Type: models.CustomURL, Value: "https://example.com/login"},
+	}}
+	es.On("AddEntity", mock.Anything, e).Return(1, nil).Once()
+	_, err := k.Save(ctx, e)
+	assert.NoError(t, err)
+
+	_, err = k.Save(ctx, &models.Entity{OwnerID: 1, Type: models.TypeText,
+		Custom: []models.CustomField{{Name: "site", Type: models.CustomURL, Value: "example"}}})
+	assert.ErrorIs(t, err, ErrInvalidCustomFields)
+
+	err = k.Update(ctx, &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText,
+		Custom: []models.CustomField{{Type: models.CustomText, Value: "no name"}}}, nil)
+	assert.ErrorIs(t, err, ErrInvalidCustomFields)
+}
+
+func Test_validateCustom(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		field models.CustomField
+		err   bool
+	}{
+		{"text", models.CustomField{Name: "a", Type: models.CustomText, Value: "any value"}, false},
+		{"empty value", models.CustomField{Name: "a", Type: models.CustomDate}, false},
+		{"empty name", models.CustomField{Name: " ", Type: models.CustomText, Value: "v"}, true},
+		{"unknown type", models.CustomField{Name: "a", Type: "PHONE", Value: "v"}, true},
+		{"url", models.CustomField{Name: "a", Type: models.CustomURL, Value: "https://example.com"}, false},
+		{"relative url", models.CustomField{Name: "a", Type: models.CustomURL, Value: "/login"}, true},
+		{"email", models.CustomField{Name: "a", Type: models.CustomEmail, Value: "user@example.com"}, false},
+		{"email with name", models.CustomField{Name: "a", Type: models.CustomEmail, Value: "User <user@example.com>"}, true},
+		{"date", models.CustomField{Name: "a", Type: models.CustomDate, Value: "2030-01-31"}, false},
+		{"invalid date", models.CustomField{Name: "a", Type: models.CustomDate, Value: "31.01.2030"}, true},
+		{"totp", models.CustomField{Name: "a", Type: models.CustomTOTP, Value: "jbsw y3dp ehpk 3pxp"}, false},
+		{"invalid totp", models.CustomField{Name: "a", Type: models.CustomTOTP, Value: "not base32!"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustom([]models.CustomField{tt.field})
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInvalidCustomFields)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestKeeper_Update(t *testing.T) {
 
 	unexpected := errors.New("unexpected error")
 	type w struct {
 		err error
 	}
-	type psM struct {
-		needed bool
-		err    error
-	}
-	type csM struct {
-		needed bool
-		err    error
-	}
-	type tsM struct {
+	type esM struct {
 		needed bool
 		err    error
 	}
 	type testCases []struct {
 		name string
 		e    models.Entity
-		psM  psM
-		csM  csM
-		tsM  tsM
+		esM  esM
 		w    w
 	}
 
@@ -301,13 +285,7 @@ func TestKeeper_Update(t *testing.T) {
 				Text:       "text",
 				Metadata:   "metadata",
 			},
-			csM: csM{
-				err: nil,
-			},
-			psM: psM{
-				err: nil,
-			},
-			tsM: tsM{
+			esM: esM{
 				err: nil,
 			},
 			w: w{
@@ -328,13 +306,7 @@ func TestKeeper_Update(t *testing.T) {
 				Text:       "text",
 				Metadata:   "metadata",
 			},
-			csM: csM{
-				err: unexpected,
-			},
-			psM: psM{
-				err: unexpected,
-			},
-			tsM: tsM{
+			esM: esM{
 				err: unexpected,
 			},
 			w: w{
@@ -349,9 +321,7 @@ func TestKeeper_Update(t *testing.T) {
 		tc := make(testCases, len(template), len(template))
 		copy(tc, template)
 		for i, tt := range tc {
-			tc[i].psM.needed = et == models.TypePassword
-			tc[i].csM.needed = et == models.TypeCard
-			tc[i].tsM.needed = et == models.TypeText
+			tc[i].esM.needed = et != models.TypeFile
 			tc[i].name = fmt.Sprintf("%s_%s", tt.name, et)
 			tc[i].e.Type = et
 			if et == models.TypeFile {
@@ -366,53 +336,111 @@ func TestKeeper_Update(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			ps := mocks.NewPasswordStorage(t)
-			cs := mocks.NewCardStorage(t)
-			ts := mocks.NewTextStorage(t)
-			if tt.psM.needed {
-				ps.On("UpdatePassword", mock.Anything, tt.e.ToPassword()).Return(tt.psM.err)
-			}
-			if tt.csM.needed {
-				cs.On("UpdateCard", mock.Anything, tt.e.ToCard()).Return(tt.csM.err)
+			es := mocks.NewEntityStorage(t)
+			if tt.esM.needed {
+				es.On("UpdateEntity", mock.Anything, &tt.e, []models.EntityField(nil)).Return(tt.esM.err)
 			}
-			if tt.tsM.needed {
-				ts.On("UpdateText", mock.Anything, tt.e.ToText()).Return(tt.tsM.err)
-			}
-			k := New(ps, cs, ts, nil, "")
-			err := k.Update(ctx, &tt.e)
+			k := New(Deps{Entities: es}, "")
+			err := k.Update(ctx, &tt.e, nil)
 			assert.ErrorIs(t, tt.w.err, err)
 		})
 	}
 }
 
+func TestKeeper_UpdateMask(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		et     models.EntityType
+		fields []models.EntityField
+		err    error
+	}{
+		{
+			name:   "password fields",
+			et:     models.TypePassword,
+			fields: []models.EntityField{models.FieldPassword, models.FieldMetadata},
+		},
+		{
+			name:   "card fields",
+			et:     models.TypeCard,
+			fields: []models.EntityField{models.FieldCardCVC},
+		},
+		{
+			name:   "text fields",
+			et:     models.TypeText,
+			fields: []models.EntityField{models.FieldText},
+		},
+		{
+			name:   "card field for password",
+			et:     models.TypePassword,
+			fields: []models.EntityField{models.FieldLogin, models.FieldCardNumber},
+			err:    ErrInvalidUpdateMask,
+		},
+		{
+			name:   "unknown field",
+			et:     models.TypeText,
+			fields: []models.EntityField{"filename"},
+			err:    ErrInvalidUpdateMask,
+		},
+		{
+			name:   "folder and tags",
+			et:     models.TypeCard,
+			fields: []models.EntityField{models.FieldFolder, models.FieldTags, models.FieldCardCVC},
+		},
+		{
+			name:   "file folder and tags",
+			et:     models.TypeFile,
+			fields: []models.EntityField{models.FieldFolder, models.FieldTags},
+		},
+		{
+			name:   "file metadata",
+			et:     models.TypeFile,
+			fields: []models.EntityField{models.FieldFolder, models.FieldMetadata},
+			err:    ErrUnableToUpdateFile,
+		},
+		{
+			name: "file all fields",
+			et:   models.TypeFile,
+			err:  ErrUnableToUpdateFile,
+		},
+	}
+
+	sl.SetupLogger("test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			es := mocks.NewEntityStorage(t)
+			e := &models.Entity{ID: 1, OwnerID: 1, Type: tt.et}
+			if tt.err == nil {
+				es.On("UpdateEntity", mock.Anything, e, tt.fields).Return(nil)
+			}
+			k := New(Deps{Entities: es}, "")
+			err := k.Update(ctx, e, tt.fields)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
 func TestKeeper_Delete(t *testing.T) {
 
 	unexpected := errors.New("unexpected error")
 	type w struct {
 		err error
 	}
-	type psM struct {
-		needed bool
-		err    error
-	}
-	type csM struct {
-		needed bool
-		err    error
-	}
-	type tsM struct {
+	type esM struct {
 		needed bool
 		err    error
 	}
 
 	type testCases []struct {
-		name    string
-		id      int
-		ownerId int
-		et      models.EntityType
-		psM     psM
-		csM     csM
-		tsM     tsM
-		w       w
+		name     string
+		id       int
+		ownerId  int
+		revision int
+		et       models.EntityType
+		esM      esM
+		w        w
 	}
 
 	template := testCases{
@@ -420,16 +448,9 @@ func TestKeeper_Delete(t *testing.T) {
 			name:    "ok",
 			id:      1,
 			ownerId: 1,
-			csM: csM{
-				err: nil,
-			},
-			psM: psM{
-				err: nil,
-			},
-			tsM: tsM{
+			esM: esM{
 				err: nil,
 			},
-
 			w: w{
 				err: nil,
 			},
@@ -438,35 +459,39 @@ func TestKeeper_Delete(t *testing.T) {
 			name:    "unexpected error",
 			id:      1,
 			ownerId: 1,
-			csM: csM{
+			esM: esM{
 				err: unexpected,
 			},
-			psM: psM{
+			w: w{
 				err: unexpected,
 			},
-			tsM: tsM{
-				err: unexpected,
+		},
+		{
+			name:     "revision mismatch",
+			id:       1,
+			ownerId:  1,
+			revision: 2,
+			esM: esM{
+				err: storage.ErrRevisionMismatch,
 			},
 			w: w{
-				err: unexpected,
+				err: ErrRevisionMismatch,
 			},
 		},
 	}
 
-	cases := make(testCases, 0, len(template)*len(eTypes()))
+	cases := make(testCases, 0, len(template)*(len(eTypes())+1))
 
-	for _, et := range eTypes() {
-		if et == models.TypeFile {
-			continue
-		}
+	for _, et := range append(eTypes(), "UNKNOWN") {
 		tc := make(testCases, len(template), len(template))
 		copy(tc, template)
 		for i, tt := range tc {
-			tc[i].psM.needed = et == models.TypePassword
-			tc[i].csM.needed = et == models.TypeCard
-			tc[i].tsM.needed = et == models.TypeText
+			tc[i].esM.needed = et.Known()
 			tc[i].et = et
 			tc[i].name = fmt.Sprintf("%s_%s", tt.name, et)
+			if !et.Known() {
+				tc[i].w.err = ErrUnknownEntity
+			}
 		}
 		cases = append(cases, tc...)
 	}
@@ -476,20 +501,12 @@ func TestKeeper_Delete(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			ps := mocks.NewPasswordStorage(t)
-			cs := mocks.NewCardStorage(t)
-			ts := mocks.NewTextStorage(t)
-			if tt.psM.needed {
-				ps.On("DeletePassword", mock.Anything, tt.id, tt.ownerId).Return(tt.psM.err)
+			es := mocks.NewEntityStorage(t)
+			if tt.esM.needed {
+				es.On("DeleteEntity", mock.Anything, tt.et, tt.id, tt.ownerId, tt.revision).Return(tt.esM.err)
 			}
-			if tt.csM.needed {
-				cs.On("DeleteCard", mock.Anything, tt.id, tt.ownerId).Return(tt.csM.err)
-			}
-			if tt.tsM.needed {
-				ts.On("DeleteText", mock.Anything, tt.id, tt.ownerId).Return(tt.tsM.err)
-			}
-			k := New(ps, cs, ts, nil, "")
-			err := k.Delete(ctx, tt.id, tt.ownerId, tt.et)
+			k := New(Deps{Entities: es}, "")
+			err := k.Delete(ctx, tt.id, tt.ownerId, tt.et, tt.revision)
 			assert.ErrorIs(t, tt.w.err, err)
 		})
 	}
@@ -501,40 +518,16 @@ func TestKeeper_List(t *testing.T) {
 	ownerID := 1
 	ctx := context.Background()
 
-	ps := mocks.NewPasswordStorage(t)
-	cs := mocks.NewCardStorage(t)
-	ts := mocks.NewTextStorage(t)
-	fs := mocks.NewFileStorage(t)
-
-	k := New(ps, cs, ts, fs, "")
+	es := mocks.NewEntityStorage(t)
 
-	ps.On("GetPasswords", mock.Anything, ownerID).Return(nil, unexpected).Once()
-	_, err := k.List(ctx, ownerID)
-	assert.ErrorIs(t, err, unexpected)
+	k := New(Deps{Entities: es}, "")
 
-	ps.On("GetPasswords", mock.Anything, ownerID).Return([]*models.Password{}, nil).Once()
-	cs.On("GetCards", mock.Anything, ownerID).Return(nil, unexpected).Once()
-	_, err = k.List(ctx, ownerID)
+	es.On("ListEntities", mock.Anything, ownerID, mock.Anything).Return(nil, unexpected).Once()
+	_, _, err := k.List(ctx, ownerID, models.ListOptions{})
 	assert.ErrorIs(t, err, unexpected)
 
-	ps.On("GetPasswords", mock.Anything, ownerID).Return([]*models.Password{}, nil).Once()
-	cs.On("GetCards", mock.Anything, ownerID).Return([]*models.Card{}, nil).Once()
-	ts.On("GetTexts", mock.Anything, ownerID).Return(nil, unexpected).Once()
-	_, err = k.List(ctx, ownerID)
-	assert.ErrorIs(t, err, unexpected)
-
-	ps.On("GetPasswords", mock.Anything, ownerID).Return([]*models.Password{}, nil).Once()
-	cs.On("GetCards", mock.Anything, ownerID).Return([]*models.Card{}, nil).Once()
-	ts.On("GetTexts", mock.Anything, ownerID).Return([]*models.Text{}, nil).Once()
-	fs.On("GetFiles", mock.Anything, ownerID).Return(nil, unexpected).Once()
-	_, err = k.List(ctx, ownerID)
-	assert.ErrorIs(t, err, unexpected)
-
-	ps.On("GetPasswords", mock.Anything, ownerID).Return([]*models.Password{}, nil).Once()
-	cs.On("GetCards", mock.Anything, ownerID).Return([]*models.Card{}, nil).Once()
-	ts.On("GetTexts", mock.Anything, ownerID).Return([]*models.Text{}, nil).Once()
-	fs.On("GetFiles", mock.Anything, ownerID).Return([]*models.File{}, nil).Once()
-	_, err = k.List(ctx, ownerID)
+	es.On("ListEntities", mock.Anything, ownerID, mock.Anything).Return([]*models.Entity{}, nil).Once()
+	_, _, err = k.List(ctx, ownerID, models.ListOptions{})
 	assert.NoError(t, err)
 }
 
@@ -546,43 +539,483 @@ func TestKeeper_DeleteFile(t *testing.T) {
 
 	sl.SetupLogger("test")
 	fType := models.TypeFile
-	unexpected := errors.New("unexpected error")
 	fileName := "file.txt"
-	fileLocation := "./files"
+	fileLocation := t.TempDir()
 	id := 1
 	ownerId := 1
 
 	ctx := context.Background()
+	es := mocks.NewEntityStorage(t)
+
+	filePath := path.Join(fileLocation, fmt.Sprintf("%d_%s", id, fileName))
+	err := os.WriteFile(filePath, []byte("text"), 0666)
+	require.NoError(t, err)
+
+	k := New(Deps{Entities: es}, fileLocation)
+
+	// the content is kept until the file is purged from the trash
+	es.On("DeleteEntity", mock.Anything, fType, id, ownerId, 0).Return(nil).Once()
+	err = k.Delete(ctx, id, ownerId, fType, 0)
+	assert.NoError(t, err)
+	assert.FileExists(t, filePath)
+
+	es.On("DeleteEntity", mock.Anything, fType, id, ownerId, 0).Return(storage.ErrFileNotExist).Once()
+	err = k.Delete(ctx, id, ownerId, fType, 0)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+func TestKeeper_CleanupOrphanedUploads(t *testing.T) {
+
+	sl.SetupLogger("test")
+	unexpected := errors.New("unexpected error")
+	fileLocation := t.TempDir()
+	ctx := context.Background()
+
+	orphan := &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeFile, Filename: "orphan.txt"}
+	missing := &models.Entity{ID: 2, OwnerID: 1, Type: models.TypeFile, Filename: "missing.txt"}
+	filePath := path.Join(fileLocation, fmt.Sprintf("%d_%s", orphan.ID, orphan.Filename))
+	err := os.WriteFile(filePath, []byte("text"), 0666)
+	require.NoError(t, err)
+
 	fs := mocks.NewFileStorage(t)
+	k := New(Deps{Files: fs}, fileLocation)
+
+	fs.On("DeleteStaleUploads", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, unexpected).Once()
+	err = k.CleanupOrphanedUploads(ctx, time.Hour)
+	assert.ErrorIs(t, err, unexpected)
+
+	fs.On("DeleteStaleUploads", mock.Anything, mock.AnythingOfType("time.Time")).Return([]*models.Entity{orphan, missing}, nil).Once()
+	err = k.CleanupOrphanedUploads(ctx, time.Hour)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filePath)
+}
+
+func TestKeeper_Get(t *testing.T) {
+
+	sl.SetupLogger("test")
+	unexpected := errors.New("unexpected error")
+	id := 1
+	ownerID := 1
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	entities := []*models.Entity{
+		{ID: id, OwnerID: ownerID, Type: models.TypePassword, Login: "login", Password: "password", Metadata: "md"},
+		{ID: id, OwnerID: ownerID, Type: models.TypeCard, CardNumber: "1234", CardCVC: "123", CardOwner: "OWNER", CardExp: "06/28"},
+		{ID: id, OwnerID: ownerID, Type: models.TypeText, Text: "text", Metadata: "md"},
+		{ID: id, OwnerID: ownerID, Type: models.TypeFile, Filename: "file.txt"},
+	}
+	for _, want := range entities {
+		es.On("GetEntity", mock.Anything, want.Type, id, ownerID).Return(want, nil).Once()
+		e, err := k.Get(ctx, id, ownerID, want.Type)
+		assert.NoError(t, err)
+		assert.Equal(t, want, e)
+	}
+
+	es.On("GetEntity", mock.Anything, models.TypeCard, id, ownerID).Return(nil, storage.ErrCardNotExist).Once()
+	_, err := k.Get(ctx, id, ownerID, models.TypeCard)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	es.On("GetEntity", mock.Anything, models.TypeText, id, ownerID).Return(nil, unexpected).Once()
+	_, err = k.Get(ctx, id, ownerID, models.TypeText)
+	assert.ErrorIs(t, err, unexpected)
+
+	_, err = k.Get(ctx, id, ownerID, "UNKNOWN")
+	assert.ErrorIs(t, err, ErrUnknownEntity)
+}
+
+func TestKeeper_Versions(t *testing.T) {
+
+	sl.SetupLogger("test")
+	id := 1
+	ownerID := 1
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	vs := mocks.NewVersionStorage(t)
+	k := New(Deps{Entities: es, Versions: vs}, "")
+
+	pwd := &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypePassword, Login: "login", Password: "new", Revision: 2}
+	versions := []*models.Version{
+		{Entity: &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypePassword, Password: "old", Revision: 1}},
+	}
+	es.On("GetEntity", mock.Anything, models.TypePassword, id, ownerID).Return(pwd, nil).Once()
+	vs.On("ListVersions", mock.Anything, models.TypePassword, id, ownerID).Return(versions, nil).Once()
+	res, err := k.Versions(ctx, id, ownerID, models.TypePassword)
+	assert.NoError(t, err)
+	assert.Equal(t, versions, res)
+
+	es.On("GetEntity", mock.Anything, models.TypePassword, id, ownerID).Return(nil, storage.ErrPasswordNotExist).Once()
+	_, err = k.Versions(ctx, id, ownerID, models.TypePassword)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	_, err = k.Versions(ctx, id, ownerID, models.TypeFile)
+	assert.ErrorIs(t, err, ErrNotVersioned)
+
+	_, err = k.Versions(ctx, id, ownerID, "UNKNOWN")
+	assert.ErrorIs(t, err, ErrUnknownEntity)
+}
+
+func TestKeeper_Restore(t *testing.T) {
+
+	sl.SetupLogger("test")
+	id := 1
+	ownerID := 1
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	vs := mocks.NewVersionStorage(t)
+	k := New(Deps{Entities: es, Versions: vs}, "")
+
+	old := &models.Version{
+		Entity: &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypeText, Text: "old", Metadata: "md", Revision: 1},
+	}
+	restored := &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypeText, Text: "old", Metadata: "md", Revision: 4}
+
+	vs.On("GetVersion", mock.Anything, models.TypeText, id, ownerID, 1).Return(old, nil).Once()
+	es.On("UpdateEntity", mock.Anything, &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypeText, Text: "old", Metadata: "md", Revision: 3},
+		[]models.EntityField(nil)).Return(nil).Once()
+	es.On("GetEntity", mock.Anything, models.TypeText, id, ownerID).Return(restored, nil).Once()
+	e, err := k.Restore(ctx, id, ownerID, models.TypeText, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, restored, e)
+
+	vs.On("GetVersion", mock.Anything, models.TypeText, id, ownerID, 1).Return(old, nil).Once()
+	es.On("UpdateEntity", mock.Anything, mock.Anything, []models.EntityField(nil)).Return(storage.ErrRevisionMismatch).Once()
+	_, err = k.Restore(ctx, id, ownerID, models.TypeText, 1, 2)
+	assert.ErrorIs(t, err, ErrRevisionMismatch)
+
+	vs.On("GetVersion", mock.Anything, models.TypeText, id, ownerID, 7).Return(nil, storage.ErrVersionNotExist).Once()
+	_, err = k.Restore(ctx, id, ownerID, models.TypeText, 7, 0)
+	assert.ErrorIs(t, err, storage.ErrVersionNotExist)
+
+	_, err = k.Restore(ctx, id, ownerID, models.TypeFile, 1, 0)
+	assert.ErrorIs(t, err, ErrNotVersioned)
+}
 
-	err := os.Mkdir("./files", 0777)
+func TestKeeper_RestoreSealsUnapproved(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	vs := mocks.NewVersionStorage(t)
+	acs := mocks.NewAccessStorage(t)
+	k := New(Deps{Entities: es, Versions: vs, Access: acs}, "")
+
+	// the writer restores the version of the entity requiring approval without the approved request
+	old := &models.Version{Entity: &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Text: "old", Revision: 1}}
+	restored := &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Text: "old", Revision: 3,
+		Permission: models.PermissionWrite, RequiresApproval: true}
+	vs.On("GetVersion", mock.Anything, models.TypeText, 1, 2, 1).Return(old, nil).Once()
+	es.On("UpdateEntity", mock.Anything, mock.Anything, []models.EntityField(nil)).Return(nil).Once()
+	es.On("GetEntity", mock.Anything, models.TypeText, 1, 2).Return(restored, nil).Once()
+	acs.On("ActiveAccessRequest", mock.Anything, models.TypeText, 1, 2, mock.Anything).
+		Return(nil, storage.ErrAccessRequestNotExist).Once()
+
+	e, err := k.Restore(ctx, 1, 2, models.TypeText, 1, 0)
 	require.NoError(t, err)
+	assert.True(t, e.Sealed)
+	assert.Empty(t, e.Text)
+}
+
+func TestKeeper_PruneVersions(t *testing.T) {
 
-	file, err := os.Create(path.Join(fileLocation, fmt.Sprintf("%d_%s", id, fileName)))
+	sl.SetupLogger("test")
+	unexpected := errors.New("unexpected error")
+	ctx := context.Background()
+
+	vs := mocks.NewVersionStorage(t)
+	k := New(Deps{Versions: vs}, "")
+
+	vs.On("DeleteOldVersions", mock.Anything, 10).Return(int64(3), nil).Once()
+	assert.NoError(t, k.PruneVersions(ctx, 10))
+
+	vs.On("DeleteOldVersions", mock.Anything, 10).Return(int64(0), unexpected).Once()
+	assert.ErrorIs(t, k.PruneVersions(ctx, 10), unexpected)
+}
+
+func TestKeeper_ListPage(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ownerID := 1
+	ctx := context.Background()
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	entities := []*models.Entity{
+		{ID: 1, OwnerID: ownerID, Type: models.TypeCard, CreatedAt: base},
+		{ID: 1, OwnerID: ownerID, Type: models.TypePassword, CreatedAt: base},
+		{ID: 1, OwnerID: ownerID, Type: models.TypeText, CreatedAt: base.Add(time.Minute)},
+		{ID: 2, OwnerID: ownerID, Type: models.TypePassword, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: 2, OwnerID: ownerID, Type: models.TypeCard, CreatedAt: base.Add(3 * time.Minute)},
+	}
+
+	first := mock.MatchedBy(func(opts models.ListOptions) bool {
+		return opts.PageSize == 4 && opts.After == nil && opts.SortBy == models.SortByCreatedAt
+	})
+	es.On("ListEntities", mock.Anything, ownerID, first).Return(entities[:4], nil).Once()
+
+	res, next, err := k.List(ctx, ownerID, models.ListOptions{PageSize: 3})
 	require.NoError(t, err)
-	_, err = file.Write([]byte("text"))
+	assert.Equal(t, entities[:3], res)
+	require.NotEmpty(t, next)
+
+	after := mock.MatchedBy(func(opts models.ListOptions) bool {
+		return opts.After != nil && *opts.After == models.Cursor{Time: base.Add(time.Minute), Type: models.TypeText, ID: 1}
+	})
+	es.On("ListEntities", mock.Anything, ownerID, after).Return(entities[3:], nil).Once()
+
+	res, next, err = k.List(ctx, ownerID, models.ListOptions{PageSize: 3, PageToken: next})
 	require.NoError(t, err)
-	err = file.Close()
+	assert.Equal(t, entities[3:], res)
+	assert.Empty(t, next)
+
+	maxPage := mock.MatchedBy(func(opts models.ListOptions) bool { return opts.PageSize == maxPageSize+1 })
+	es.On("ListEntities", mock.Anything, ownerID, maxPage).Return([]*models.Entity{}, nil).Once()
+	_, _, err = k.List(ctx, ownerID, models.ListOptions{PageSize: maxPageSize * 2})
+	require.NoError(t, err)
+
+	_, _, err = k.List(ctx, ownerID, models.ListOptions{PageToken: "invalid"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, _, err = k.List(ctx, ownerID, models.ListOptions{SortBy: "login"})
+	assert.ErrorIs(t, err, ErrInvalidSortField)
+}
+
+func TestPageToken(t *testing.T) {
+	c := &models.Cursor{Time: time.Date(2024, time.January, 1, 0, 0, 0, 123000, time.UTC), Type: models.TypeCard, ID: 42}
+	res, err := decodePageToken(encodePageToken(c))
 	require.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	_, err = decodePageToken(encodePageToken(&models.Cursor{Type: "UNKNOWN"}))
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestKeeper_Stream(t *testing.T) {
+
+	sl.SetupLogger("test")
+	stop := errors.New("stop")
+	ownerID := 1
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	entities := []*models.Entity{
+		{ID: 1, OwnerID: ownerID, Type: models.TypePassword},
+		{ID: 2, OwnerID: ownerID, Type: models.TypeCard},
+		{ID: 3, OwnerID: ownerID, Type: models.TypeText},
+		{ID: 4, OwnerID: ownerID, Type: models.TypeFile},
+	}
+
+	noPage := mock.MatchedBy(func(opts models.ListOptions) bool {
+		return opts.PageSize == 0 && opts.After == nil && opts.PageToken == ""
+	})
+	es.On("IterateEntities", mock.Anything, ownerID, noPage, mock.Anything).Run(func(args mock.Arguments) {
+		for _, e := range entities {
+			_ = args.Get(3).(func(*models.Entity) error)(e)
+		}
+	}).Return(nil).Once()
 
-	k := New(nil, nil, nil, fs, fileLocation)
-	fs.On("GetFile", mock.Anything, id, ownerId).Return(&models.File{FileName: fileName, ID: id}, nil).Once()
-	fs.On("DeleteFile", mock.Anything, id, ownerId).Return(nil).Once()
-	err = k.Delete(ctx, id, ownerId, fType)
+	res := make([]*models.Entity, 0)
+	err := k.Stream(ctx, ownerID, models.ListOptions{PageSize: 10, PageToken: "token"}, func(e *models.Entity) error {
+		res = append(res, e)
+		return nil
+	})
 	assert.NoError(t, err)
+	assert.Equal(t, entities, res)
+
+	es.On("IterateEntities", mock.Anything, ownerID, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		_ = args.Get(3).(func(*models.Entity) error)(entities[1])
+	}).Return(stop).Once()
+	err = k.Stream(ctx, ownerID, models.ListOptions{Types: []models.EntityType{models.TypeCard, models.TypeFile}}, func(e *models.Entity) error {
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+}
 
-	fs.On("GetFile", mock.Anything, id, ownerId).Return(&models.File{FileName: fileName, ID: id}, nil).Once()
-	err = k.Delete(ctx, id, ownerId, fType)
-	assert.Error(t, err)
+func TestKeeper_Trash(t *testing.T) {
 
-	fs.On("GetFile", mock.Anything, id, ownerId).Return(nil, storage.ErrFileNotExist).Once()
-	err = k.Delete(ctx, id, ownerId, fType)
-	assert.ErrorIs(t, err, storage.ErrFileNotExist)
+	sl.SetupLogger("test")
+	ownerID := 1
+	ctx := context.Background()
 
-	fs.On("GetFile", mock.Anything, id, ownerId).Return(nil, unexpected).Once()
-	err = k.Delete(ctx, id, ownerId, fType)
+	es := mocks.NewEntityStorage(t)
+	k := New(Deps{Entities: es}, "")
+
+	trashed := []*models.Entity{{ID: 1, OwnerID: ownerID, Type: models.TypeCard, DeletedAt: time.Now()}}
+	es.On("ListEntities", mock.Anything, ownerID, models.ListOptions{
+		Trashed: true,
+		SortBy:  models.SortByDeletedAt,
+		Desc:    true,
+	}).Return(trashed, nil).Once()
+	res, err := k.Trash(ctx, ownerID)
+	assert.NoError(t, err)
+	assert.Equal(t, trashed, res)
+}
+
+func TestKeeper_RestoreFromTrash(t *testing.T) {
+
+	sl.SetupLogger("test")
+	id := 1
+	ownerID := 1
+	ctx := context.Background()
+
+	es := mocks.NewEntityStorage(t)
+	tr := mocks.NewTrashStorage(t)
+	k := New(Deps{Entities: es, Trash: tr}, "")
+
+	card := &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypeCard, CardNumber: "1234", Revision: 2}
+	tr.On("RestoreEntity", mock.Anything, models.TypeCard, id, ownerID).Return(nil).Once()
+	es.On("GetEntity", mock.Anything, models.TypeCard, id, ownerID).Return(card, nil).Once()
+	e, err := k.RestoreFromTrash(ctx, id, ownerID, models.TypeCard)
+	assert.NoError(t, err)
+	assert.Equal(t, card, e)
+
+	tr.On("RestoreEntity", mock.Anything, models.TypeCard, id, ownerID).Return(storage.ErrCardNotExist).Once()
+	_, err = k.RestoreFromTrash(ctx, id, ownerID, models.TypeCard)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	_, err = k.RestoreFromTrash(ctx, id, ownerID, "UNKNOWN")
+	assert.ErrorIs(t, err, ErrUnknownEntity)
+}
+
+func TestKeeper_Purge(t *testing.T) {
+
+	sl.SetupLogger("test")
+	id := 1
+	ownerID := 1
+	fileName := "file.txt"
+	fileLocation := t.TempDir()
+	ctx := context.Background()
+
+	filePath := path.Join(fileLocation, fmt.Sprintf("%d_%s", id, fileName))
+	err := os.WriteFile(filePath, []byte("text"), 0666)
+	require.NoError(t, err)
+
+	tr := mocks.NewTrashStorage(t)
+	k := New(Deps{Trash: tr}, fileLocation)
+
+	tr.On("PurgeEntity", mock.Anything, models.TypeFile, id, ownerID).Return(nil, storage.ErrFileNotExist).Once()
+	err = k.Purge(ctx, id, ownerID, models.TypeFile)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+	assert.FileExists(t, filePath)
+
+	file := &models.Entity{ID: id, OwnerID: ownerID, Type: models.TypeFile, Filename: fileName}
+	tr.On("PurgeEntity", mock.Anything, models.TypeFile, id, ownerID).Return(file, nil).Once()
+	err = k.Purge(ctx, id, ownerID, models.TypeFile)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filePath)
+
+	// the content is already removed
+	tr.On("PurgeEntity", mock.Anything, models.TypeFile, id, ownerID).Return(file, nil).Once()
+	err = k.Purge(ctx, id, ownerID, models.TypeFile)
+	assert.NoError(t, err)
+
+	tr.On("PurgeEntity", mock.Anything, models.TypeText, id, ownerID).Return(&models.Entity{ID: id, Type: models.TypeText}, nil).Once()
+	err = k.Purge(ctx, id, ownerID, models.TypeText)
+	assert.NoError(t, err)
+
+	err = k.Purge(ctx, id, ownerID, "UNKNOWN")
+	assert.ErrorIs(t, err, ErrUnknownEntity)
+}
+
+func TestKeeper_PurgeTrash(t *testing.T) {
+
+	sl.SetupLogger("test")
+	unexpected := errors.New("unexpected error")
+	fileLocation := t.TempDir()
+	ctx := context.Background()
+
+	file := &models.Entity{ID: 1, OwnerID: 1, Type: models.TypeFile, Filename: "file.txt"}
+	filePath := path.Join(fileLocation, fmt.Sprintf("%d_%s", file.ID, file.Filename))
+	err := os.WriteFile(filePath, []byte("text"), 0666)
+	require.NoError(t, err)
+
+	tr := mocks.NewTrashStorage(t)
+	k := New(Deps{Trash: tr}, fileLocation)
+
+	tr.On("PurgeTrash", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, unexpected).Once()
+	err = k.PurgeTrash(ctx, time.Hour)
 	assert.ErrorIs(t, err, unexpected)
 
-	err = os.Remove("./files")
+	purged := []*models.Entity{file, {ID: 2, OwnerID: 1, Type: models.TypePassword}}
+	tr.On("PurgeTrash", mock.Anything, mock.AnythingOfType("time.Time")).Return(purged, nil).Once()
+	err = k.PurgeTrash(ctx, time.Hour)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filePath)
+}
+
+func TestKeeper_Folders(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	fos := mocks.NewFolderStorage(t)
+	k := New(Deps{Folders: fos}, "")
+
+	_, err := k.CreateFolder(ctx, &models.Folder{OwnerID: 1})
+	assert.ErrorIs(t, err, ErrEmptyName)
+	_, err = k.UpdateFolder(ctx, &models.Folder{ID: 1, OwnerID: 1})
+	assert.ErrorIs(t, err, ErrEmptyName)
+
+	f := &models.Folder{OwnerID: 1, ParentID: 2, Name: "work"}
+	fos.On("CreateFolder", mock.Anything, f).Return(&models.Folder{ID: 3, OwnerID: 1, ParentID: 2, Name: "work"}, nil).Once()
+	res, err := k.CreateFolder(ctx, f)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.ID)
+
+	f = &models.Folder{ID: 2, OwnerID: 1, ParentID: 3, Name: "home"}
+	fos.On("UpdateFolder", mock.Anything, f).Return(nil, storage.ErrFolderCycle).Once()
+	_, err = k.UpdateFolder(ctx, f)
+	assert.ErrorIs(t, err, ErrFolderCycle)
+
+	fos.On("DeleteFolder", mock.Anything, 3, 1).Return(storage.ErrFolderNotExist).Once()
+	err = k.DeleteFolder(ctx, 3, 1)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	fos.On("ListFolders", mock.Anything, 1).Return([]*models.Folder{{ID: 2}, {ID: 3}}, nil).Once()
+	folders, err := k.Folders(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, folders, 2)
+}
+
+func TestKeeper_Tags(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ts := mocks.NewTagStorage(t)
+	k := New(Deps{Tags: ts}, "")
+
+	_, err := k.CreateTag(ctx, &models.Tag{OwnerID: 1})
+	assert.ErrorIs(t, err, ErrEmptyName)
+	_, err = k.RenameTag(ctx, &models.Tag{ID: 1, OwnerID: 1})
+	assert.ErrorIs(t, err, ErrEmptyName)
+
+	tag := &models.Tag{OwnerID: 1, Name: "prod"}
+	ts.On("CreateTag", mock.Anything, tag).Return(nil, storage.ErrTagAlreadyExists).Once()
+	_, err = k.CreateTag(ctx, tag)
+	assert.ErrorIs(t, err, storage.ErrTagAlreadyExists)
+
+	tag = &models.Tag{ID: 1, OwnerID: 1, Name: "dev"}
+	ts.On("RenameTag", mock.Anything, tag).Return(tag, nil).Once()
+	res, err := k.RenameTag(ctx, tag)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", res.Name)
+
+	ts.On("DeleteTag", mock.Anything, 1, 1).Return(nil).Once()
+	err = k.DeleteTag(ctx, 1, 1)
+	assert.NoError(t, err)
+
+	ts.On("ListTags", mock.Anything, 1).Return([]*models.Tag{tag}, nil).Once()
+	tags, err := k.Tags(ctx, 1)
 	require.NoError(t, err)
+	assert.Equal(t, []*models.Tag{tag}, tags)
 }
@@ -0,0 +1,196 @@
+package passkeeper
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const (
+	// maxSendSize is the maximum send payload size in bytes.
+	maxSendSize = 1 << 20
+
+	// maxSendViews is the maximum number of the send views.
+	maxSendViews = 100
+
+	// maxSendTTL is the maximum send lifetime.
+	maxSendTTL = 30 * 24 * time.Hour
+
+	// maxSendPasswordLength is the maximum access password length in bytes supported by bcrypt.
+	maxSendPasswordLength = 72
+
+	// sendTokenSize is the size of the random send token in bytes.
+	sendTokenSize = 32
+
+	// maxSendAttempts is the number of the failed password attempts the send is destroyed after.
+	maxSendAttempts = 5
+)
+
+var (
+	// ErrInvalidSend - error if the send kind, payload, views, lifetime or password is invalid
+	ErrInvalidSend = errors.New("invalid send")
+
+	// ErrSendNotFound - matches the storage error if the send does not exist, has expired or has been viewed
+	ErrSendNotFound = storage.ErrSendNotExist
+
+	// ErrSendPassword - matches the storage error if the send access password is missing or invalid
+	ErrSendPassword = storage.ErrSendPasswordMismatch
+)
+
+// SendStorage is a storage API for the one-time sends
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=SendStorage
+type SendStorage interface {
+	AddSend(ctx context.Context, s *models.Send, tokenHash []byte) (*models.Send, error)
+	SendByToken(ctx context.Context, tokenHash []byte) (*models.Send, error)
+	ViewSend(ctx context.Context, id int) (*models.Send, error)
+	FailSendAttempt(ctx context.Context, id int, limit int) (bool, error)
+	ListSends(ctx context.Context, ownerID int) ([]*models.Send, error)
+	DeleteSend(ctx context.Context, id int, ownerID int) error
+	DeleteExpiredSends(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CreateSend stores the payload encrypted on the client for ttl and max views and returns the send
+// with the token that redeems it. The send is protected by the access password unless it is empty.
+// Only the token hash is stored, the token can not be recovered.
+func (k *Keeper) CreateSend(ctx context.Context, s *models.Send, password string, ttl time.Duration) (*models.Send, string, error) {
+
+	lg := sl.Log
+	lg.Info("creating send", slog.String("kind", string(s.Kind)))
+
+	if err := validateSend(s, password, ttl); err != nil {
+		lg.Info("invalid send", sl.Err(err))
+		return nil, "", err
+	}
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			lg.Error("failed to generate password hash", sl.Err(err))
+			return nil, "", fmt.Errorf("failed to generate password hash: %w", err)
+		}
+		s.PasswordHash = hash
+	}
+	token, err := newSendToken()
+	if err != nil {
+		lg.Error("failed to generate token", sl.Err(err))
+		return nil, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	s.ExpiresAt = time.Now().Add(ttl)
+	res, err := k.sds.AddSend(ctx, s, sendTokenHash(token))
+	if err != nil {
+		lg.Error("failed to add send", sl.Err(err))
+		return nil, "", err
+	}
+
+	lg.Info("created send", slog.Int("id", res.ID))
+	return res, token, nil
+}
+
+// OpenSend redeems the send token and returns the send with the payload, the view is counted
+// and the send is destroyed after the last view. The send is destroyed after maxSendAttempts
+// failed password attempts.
+func (k *Keeper) OpenSend(ctx context.Context, token string, password string) (*models.Send, error) {
+
+	lg := sl.Log
+	lg.Info("opening send")
+
+	s, err := k.sds.SendByToken(ctx, sendTokenHash(token))
+	if err != nil {
+		return nil, err
+	}
+	if len(s.PasswordHash) > 0 {
+		if err := bcrypt.CompareHashAndPassword(s.PasswordHash, []byte(password)); err != nil {
+			lg.Info("invalid send password", slog.Int("id", s.ID))
+			deleted, err := k.sds.FailSendAttempt(ctx, s.ID, maxSendAttempts)
+			if err != nil && !errors.Is(err, ErrSendNotFound) {
+				lg.Error("failed to count send attempt", sl.Err(err))
+				return nil, err
+			}
+			if deleted {
+				lg.Info("send destroyed after failed password attempts", slog.Int("id", s.ID))
+			}
+			return nil, ErrSendPassword
+		}
+	}
+	res, err := k.sds.ViewSend(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Info("opened send", slog.Int("id", res.ID), slog.Int("views", res.Views))
+	return res, nil
+}
+
+// Sends returns the owner`s active sends without the payloads.
+func (k *Keeper) Sends(ctx context.Context, ownerID int) ([]*models.Send, error) {
+	sl.Log.Info("getting sends")
+	return k.sds.ListSends(ctx, ownerID)
+}
+
+// DeleteSend destroys the owner`s send before it expires.
+func (k *Keeper) DeleteSend(ctx context.Context, id int, ownerID int) error {
+	sl.Log.Info("deleting send", slog.Int("id", id))
+	return k.sds.DeleteSend(ctx, id, ownerID)
+}
+
+// PurgeSends destroys the expired sends.
+func (k *Keeper) PurgeSends(ctx context.Context) error {
+
+	lg := sl.Log
+	lg.Info("purging expired sends")
+
+	n, err := k.sds.DeleteExpiredSends(ctx, time.Now())
+	if err != nil {
+		lg.Error("failed to delete expired sends", sl.Err(err))
+		return err
+	}
+
+	lg.Info("purged expired sends", slog.Int64("count", n))
+	return nil
+}
+
+func validateSend(s *models.Send, password string, ttl time.Duration) error {
+	if !s.Kind.Known() {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidSend, s.Kind)
+	}
+	if s.Kind == models.SendFile && s.Filename == "" {
+		return fmt.Errorf("%w: file name is required", ErrInvalidSend)
+	}
+	if len(s.Payload) == 0 || len(s.Payload) > maxSendSize {
+		return fmt.Errorf("%w: payload must be 1 to %d bytes long", ErrInvalidSend, maxSendSize)
+	}
+	if s.MaxViews < 1 || s.MaxViews > maxSendViews {
+		return fmt.Errorf("%w: max views must be 1 to %d", ErrInvalidSend, maxSendViews)
+	}
+	if ttl <= 0 || ttl > maxSendTTL {
+		return fmt.Errorf("%w: lifetime must be up to %s", ErrInvalidSend, maxSendTTL)
+	}
+	if len(password) > maxSendPasswordLength {
+		return fmt.Errorf("%w: password must be up to %d bytes long", ErrInvalidSend, maxSendPasswordLength)
+	}
+	return nil
+}
+
+func newSendToken() (string, error) {
+	b := make([]byte, sendTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func sendTokenHash(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
@@ -0,0 +1,173 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_CreateSend(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		send     *models.Send
+		password string
+		ttl      time.Duration
+		wantErr  error
+	}{
+		{
+			name: "text",
+			send: &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret"), MaxViews: 1},
+			ttl:  time.Hour,
+		},
+		{
+			name:     "protected file",
+			send:     &models.Send{OwnerID: 1, Kind: models.SendFile, Filename: "key.pem", Payload: []byte("secret"), MaxViews: 3},
+			password: "pass",
+			ttl:      time.Hour,
+		},
+		{
+			name:    "unknown kind",
+			send:    &models.Send{OwnerID: 1, Kind: "LINK", Payload: []byte("secret"), MaxViews: 1},
+			ttl:     time.Hour,
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:    "file without name",
+			send:    &models.Send{OwnerID: 1, Kind: models.SendFile, Payload: []byte("secret"), MaxViews: 1},
+			ttl:     time.Hour,
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:    "empty payload",
+			send:    &models.Send{OwnerID: 1, Kind: models.SendText, MaxViews: 1},
+			ttl:     time.Hour,
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:    "too large payload",
+			send:    &models.Send{OwnerID: 1, Kind: models.SendText, Payload: make([]byte, maxSendSize+1), MaxViews: 1},
+			ttl:     time.Hour,
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:    "no views",
+			send:    &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret")},
+			ttl:     time.Hour,
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:    "no lifetime",
+			send:    &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret"), MaxViews: 1},
+			wantErr: ErrInvalidSend,
+		},
+		{
+			name:     "long password",
+			send:     &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret"), MaxViews: 1},
+			password: strings.Repeat("p", maxSendPasswordLength+1),
+			ttl:      time.Hour,
+			wantErr:  ErrInvalidSend,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sds := mocks.NewSendStorage(t)
+			var tokenHash []byte
+			if tt.wantErr == nil {
+				sds.On("AddSend", mock.Anything, tt.send, mock.AnythingOfType("[]uint8")).
+					Return(func(_ context.Context, s *models.Send, h []byte) (*models.Send, error) {
+						tokenHash = h
+						res := *s
+						res.ID = 5
+						return &res, nil
+					}).Once()
+			}
+			k := New(Deps{Sends: sds}, "")
+			res, token, err := k.CreateSend(context.Background(), tt.send, tt.password, tt.ttl)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5, res.ID)
+			assert.Equal(t, sendTokenHash(token), tokenHash)
+			assert.WithinDuration(t, time.Now().Add(tt.ttl), res.ExpiresAt, time.Minute)
+			if tt.password == "" {
+				assert.Empty(t, res.PasswordHash)
+				return
+			}
+			assert.NoError(t, bcrypt.CompareHashAndPassword(res.PasswordHash, []byte(tt.password)))
+		})
+	}
+}
+
+func TestKeeper_OpenSend(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	sds := mocks.NewSendStorage(t)
+	sds.On("SendByToken", mock.Anything, sendTokenHash("open")).Return(&models.Send{ID: 1, MaxViews: 2}, nil)
+	sds.On("SendByToken", mock.Anything, sendTokenHash("protected")).Return(&models.Send{ID: 2, MaxViews: 1, PasswordHash: hash}, nil)
+	sds.On("SendByToken", mock.Anything, sendTokenHash("gone")).Return(nil, storage.ErrSendNotExist)
+	sds.On("ViewSend", mock.Anything, 1).Return(&models.Send{ID: 1, MaxViews: 2, Views: 1, Payload: []byte("secret")}, nil).Once()
+	sds.On("ViewSend", mock.Anything, 2).Return(&models.Send{ID: 2, MaxViews: 1, Views: 1, Payload: []byte("secret")}, nil).Once()
+	sds.On("FailSendAttempt", mock.Anything, 2, maxSendAttempts).Return(false, nil).Twice()
+
+	k := New(Deps{Sends: sds}, "")
+
+	s, err := k.OpenSend(ctx, "open", "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), s.Payload)
+
+	_, err = k.OpenSend(ctx, "protected", "")
+	assert.ErrorIs(t, err, ErrSendPassword)
+	_, err = k.OpenSend(ctx, "protected", "wrong")
+	assert.ErrorIs(t, err, ErrSendPassword)
+	s, err = k.OpenSend(ctx, "protected", "pass")
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.ID)
+
+	_, err = k.OpenSend(ctx, "gone", "")
+	assert.ErrorIs(t, err, ErrSendNotFound)
+}
+
+func TestKeeper_OpenSendAttempts(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+	unexpected := errors.New("unexpected")
+
+	sds := mocks.NewSendStorage(t)
+	sds.On("SendByToken", mock.Anything, sendTokenHash("protected")).Return(&models.Send{ID: 2, MaxViews: 1, PasswordHash: hash}, nil)
+	sds.On("FailSendAttempt", mock.Anything, 2, maxSendAttempts).Return(true, nil).Once()
+	sds.On("FailSendAttempt", mock.Anything, 2, maxSendAttempts).Return(false, unexpected).Once()
+
+	k := New(Deps{Sends: sds}, "")
+
+	// the last failed attempt destroys the send
+	_, err = k.OpenSend(ctx, "protected", "wrong")
+	assert.ErrorIs(t, err, ErrSendPassword)
+	_, err = k.OpenSend(ctx, "protected", "wrong")
+	assert.ErrorIs(t, err, unexpected)
+}
@@ -0,0 +1,78 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+var (
+	// ErrInvalidPermission - error if the share permission is unknown
+	ErrInvalidPermission = errors.New("invalid permission")
+
+	// ErrEmptyEmail - error if the email of the user to share the entity with is empty
+	ErrEmptyEmail = errors.New("email must not be empty")
+
+	// ErrShareWithOwner - matches the storage error if the entity is shared with its owner
+	ErrShareWithOwner = storage.ErrShareWithOwner
+
+	// ErrShareNotFound - matches the storage error if the entity is not shared with the user
+	ErrShareNotFound = storage.ErrShareNotExist
+
+	// ErrUserNotFound - matches the storage error if the user to share the entity with is not registered
+	ErrUserNotFound = storage.ErrUserNotExist
+)
+
+// ShareStorage is a storage API for the entities shared with other users
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=ShareStorage
+type ShareStorage interface {
+	ShareEntity(ctx context.Context, sh *models.Share) (*models.Share, error)
+	RevokeShare(ctx context.Context, t models.EntityType, id int, ownerID int, email string) error
+	ListShares(ctx context.Context, t models.EntityType, id int, ownerID int) ([]*models.Share, error)
+}
+
+// Share grants the registered user the permission to the owner`s entity, the permission granted before is replaced.
+// The user with the read permission gets the entity listed, the write permission allows updating its content,
+// the entity is organized, versioned and deleted by the owner only.
+func (k *Keeper) Share(ctx context.Context, sh *models.Share) (*models.Share, error) {
+	if !sh.Type.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(sh.Type)))
+		return nil, ErrUnknownEntity
+	}
+	if !sh.Permission.Known() {
+		return nil, ErrInvalidPermission
+	}
+	sh.Email = strings.TrimSpace(sh.Email)
+	if sh.Email == "" {
+		return nil, ErrEmptyEmail
+	}
+	sl.Log.Info("sharing entity", slog.String("type", string(sh.Type)), slog.Int("id", sh.EntityID),
+		slog.String("permission", string(sh.Permission)))
+	return k.ss.ShareEntity(ctx, sh)
+}
+
+// Unshare revokes the access to the owner`s entity from the user with the email.
+func (k *Keeper) Unshare(ctx context.Context, id int, ownerID int, t models.EntityType, email string) error {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return ErrUnknownEntity
+	}
+	sl.Log.Info("unsharing entity", slog.String("type", string(t)), slog.Int("id", id))
+	return k.ss.RevokeShare(ctx, t, id, ownerID, strings.TrimSpace(email))
+}
+
+// Shares returns the shares of the owner`s entity.
+func (k *Keeper) Shares(ctx context.Context, id int, ownerID int, t models.EntityType) ([]*models.Share, error) {
+	if !t.Known() {
+		sl.Log.Error("unknown entity type", slog.String("type", string(t)))
+		return nil, ErrUnknownEntity
+	}
+	sl.Log.Info("getting entity shares", slog.String("type", string(t)), slog.Int("id", id))
+	return k.ss.ListShares(ctx, t, id, ownerID)
+}
@@ -0,0 +1,113 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/internal/storage"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_Share(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		share      *models.Share
+		storageErr error
+		wantErr    error
+	}{
+		{
+			name:  "shared",
+			share: &models.Share{Type: models.TypeText, EntityID: 1, OwnerID: 1, Email: " friend@gmail.com ", Permission: models.PermissionRead},
+		},
+		{
+			name:    "unknown type",
+			share:   &models.Share{Type: "UNKNOWN", EntityID: 1, OwnerID: 1, Email: "friend@gmail.com", Permission: models.PermissionRead},
+			wantErr: ErrUnknownEntity,
+		},
+		{
+			name:    "unknown permission",
+			share:   &models.Share{Type: models.TypeText, EntityID: 1, OwnerID: 1, Email: "friend@gmail.com", Permission: "ADMIN"},
+			wantErr: ErrInvalidPermission,
+		},
+		{
+			name:    "empty email",
+			share:   &models.Share{Type: models.TypeText, EntityID: 1, OwnerID: 1, Email: " ", Permission: models.PermissionWrite},
+			wantErr: ErrEmptyEmail,
+		},
+		{
+			name:       "user not found",
+			share:      &models.Share{Type: models.TypeText, EntityID: 1, OwnerID: 1, Email: "unknown@gmail.com", Permission: models.PermissionRead},
+			storageErr: storage.ErrUserNotExist,
+			wantErr:    ErrUserNotFound,
+		},
+		{
+			name:       "shared with owner",
+			share:      &models.Share{Type: models.TypeText, EntityID: 1, OwnerID: 1, Email: "me@gmail.com", Permission: models.PermissionRead},
+			storageErr: storage.ErrShareWithOwner,
+			wantErr:    ErrShareWithOwner,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewShareStorage(t)
+			if tt.wantErr == nil || tt.storageErr != nil {
+				ss.On("ShareEntity", mock.Anything, mock.AnythingOfType("*models.Share")).
+					Return(func(_ context.Context, sh *models.Share) (*models.Share, error) {
+						if tt.storageErr != nil {
+							return nil, tt.storageErr
+						}
+						res := *sh
+						res.UserID, res.CreatedAt = 2, time.Now()
+						return &res, nil
+					})
+			}
+			k := New(Deps{Shares: ss}, "")
+			sh, err := k.Share(context.Background(), tt.share)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "friend@gmail.com", sh.Email)
+			assert.Equal(t, 2, sh.UserID)
+		})
+	}
+}
+
+func TestKeeper_Unshare(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	ss := mocks.NewShareStorage(t)
+	ss.On("RevokeShare", mock.Anything, models.TypeText, 1, 1, "friend@gmail.com").Return(nil).Once()
+	ss.On("RevokeShare", mock.Anything, models.TypeText, 1, 1, "friend@gmail.com").Return(storage.ErrShareNotExist).Once()
+
+	k := New(Deps{Shares: ss}, "")
+	assert.NoError(t, k.Unshare(ctx, 1, 1, models.TypeText, "friend@gmail.com "))
+	assert.ErrorIs(t, k.Unshare(ctx, 1, 1, models.TypeText, "friend@gmail.com"), ErrShareNotFound)
+	assert.ErrorIs(t, k.Unshare(ctx, 1, 1, "UNKNOWN", "friend@gmail.com"), ErrUnknownEntity)
+}
+
+func TestKeeper_VersionsShared(t *testing.T) {
+
+	sl.SetupLogger("test")
+
+	es := mocks.NewEntityStorage(t)
+	es.On("GetEntity", mock.Anything, models.TypeText, 1, 2).
+		Return(&models.Entity{ID: 1, OwnerID: 1, Type: models.TypeText, Permission: models.PermissionWrite}, nil)
+
+	k := New(Deps{Entities: es}, "")
+	_, err := k.Versions(context.Background(), 1, 2, models.TypeText)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
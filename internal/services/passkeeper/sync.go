@@ -0,0 +1,82 @@
+package passkeeper
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const syncPageSize = 1000
+
+// SyncSince returns the changes of the user`s entities since the cursor, only the last change of every entity.
+//
+// The resync is required if the cursor is older than the change log retention or ahead of the change log,
+// then the client lists all entities and syncs from the returned cursor next time.
+func (k *Keeper) SyncSince(ctx context.Context, ownerID int, cursor int64) (*models.SyncResult, error) {
+
+	sl.Log.Info("syncing entities", slog.Int64("cursor", cursor))
+
+	log, err := k.cs.ChangeLog(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if cursor < log.Pruned || cursor > log.Last {
+		sl.Log.Info("resync required", slog.Int64("pruned", log.Pruned), slog.Int64("last", log.Last))
+		return &models.SyncResult{Cursor: log.Last, ResyncRequired: true}, nil
+	}
+
+	// one extra change is requested to find out if there are more changes
+	changes, err := k.cs.Changes(ctx, ownerID, cursor, syncPageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.SyncResult{Cursor: cursor}
+	if len(changes) > syncPageSize {
+		changes = changes[:syncPageSize]
+		res.HasMore = true
+	}
+	if len(changes) > 0 {
+		res.Cursor = changes[len(changes)-1].Seq
+	}
+	res.Changes = lastChanges(changes)
+	sealChanges(res.Changes)
+	return res, nil
+}
+
+// lastChanges keeps only the last change of every entity.
+func lastChanges(changes []*models.Change) []*models.Change {
+	type key struct {
+		t  models.EntityType
+		id int
+	}
+	last := make(map[key]int64, len(changes))
+	for _, c := range changes {
+		last[key{c.Type, c.ID}] = c.Seq
+	}
+	res := make([]*models.Change, 0, len(last))
+	for _, c := range changes {
+		if last[key{c.Type, c.ID}] == c.Seq {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// PruneChanges deletes the changes older than retention from the change logs.
+func (k *Keeper) PruneChanges(ctx context.Context, retention time.Duration) error {
+
+	lg := sl.Log
+	lg.Info("pruning change logs", slog.Duration("retention", retention))
+
+	n, err := k.cs.DeleteOldChanges(ctx, retention)
+	if err != nil {
+		lg.Error("failed to delete old changes", sl.Err(err))
+		return err
+	}
+
+	lg.Info("pruned change logs", slog.Int64("count", n))
+	return nil
+}
@@ -0,0 +1,105 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_SyncSince(t *testing.T) {
+
+	change := func(seq int64, id int, kind models.ChangeKind) *models.Change {
+		return &models.Change{Seq: seq, Kind: kind, Type: models.TypeText, ID: id}
+	}
+	page := make([]*models.Change, 0, syncPageSize+1)
+	for i := 1; i <= syncPageSize+1; i++ {
+		page = append(page, change(int64(i), i, models.ChangeCreated))
+	}
+
+	tests := []struct {
+		name    string
+		cursor  int64
+		log     models.ChangeLog
+		changes []*models.Change
+		want    *models.SyncResult
+	}{
+		{
+			name:   "last change of every entity",
+			cursor: 2,
+			log:    models.ChangeLog{Last: 6},
+			changes: []*models.Change{
+				change(3, 1, models.ChangeUpdated),
+				change(4, 2, models.ChangeCreated),
+				change(5, 1, models.ChangeTrashed),
+				change(6, 3, models.ChangeDeleted),
+			},
+			want: &models.SyncResult{
+				Changes: []*models.Change{change(4, 2, models.ChangeCreated), change(5, 1, models.ChangeTrashed), change(6, 3, models.ChangeDeleted)},
+				Cursor:  6,
+			},
+		},
+		{
+			name:    "no changes",
+			cursor:  6,
+			log:     models.ChangeLog{Last: 6},
+			changes: []*models.Change{},
+			want:    &models.SyncResult{Changes: []*models.Change{}, Cursor: 6},
+		},
+		{
+			name:    "more changes",
+			log:     models.ChangeLog{Last: syncPageSize + 1},
+			changes: page,
+			want:    &models.SyncResult{Changes: page[:syncPageSize], Cursor: syncPageSize, HasMore: true},
+		},
+		{
+			name:   "pruned cursor",
+			cursor: 2,
+			log:    models.ChangeLog{Last: 10, Pruned: 4},
+			want:   &models.SyncResult{Cursor: 10, ResyncRequired: true},
+		},
+		{
+			name:   "cursor ahead of the change log",
+			cursor: 20,
+			log:    models.ChangeLog{Last: 10},
+			want:   &models.SyncResult{Cursor: 10, ResyncRequired: true},
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mocks.NewChangeStorage(t)
+			cs.On("ChangeLog", mock.Anything, 1).Return(tt.log, nil)
+			if tt.changes != nil {
+				cs.On("Changes", mock.Anything, 1, tt.cursor, syncPageSize+1).Return(tt.changes, nil)
+			}
+			k := New(Deps{Changes: cs}, "")
+			res, err := k.SyncSince(context.Background(), 1, tt.cursor)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestKeeper_PruneChanges(t *testing.T) {
+
+	sl.SetupLogger("test")
+	ctx := context.Background()
+
+	cs := mocks.NewChangeStorage(t)
+	cs.On("DeleteOldChanges", mock.Anything, 24*time.Hour).Return(int64(3), nil).Once()
+	cs.On("DeleteOldChanges", mock.Anything, time.Hour).Return(int64(0), errors.New("unexpected error")).Once()
+
+	k := New(Deps{Changes: cs}, "")
+	assert.NoError(t, k.PruneChanges(ctx, 24*time.Hour))
+	assert.Error(t, k.PruneChanges(ctx, time.Hour))
+}
@@ -0,0 +1,105 @@
+package passkeeper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+var (
+	// ErrInvalidCursor - error if the change log cursor is ahead of the user`s change log
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrResyncRequired - error if the changes since the cursor are pruned from the change log
+	ErrResyncRequired = errors.New("changes since the cursor are pruned, resync required")
+
+	// ErrWatchStopped - error if the change feed is stopped, e.g. when the server shuts down
+	ErrWatchStopped = errors.New("watch stopped")
+)
+
+// ChangeStorage is a storage API for the user`s entity change log
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=ChangeStorage
+type ChangeStorage interface {
+	ChangeLog(ctx context.Context, ownerID int) (models.ChangeLog, error)
+	Changes(ctx context.Context, ownerID int, after int64, limit int) ([]*models.Change, error)
+	DeleteOldChanges(ctx context.Context, age time.Duration) (int64, error)
+}
+
+// ChangeFeed signals the user`s watchers about the entity changes made on any replica
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=ChangeFeed
+type ChangeFeed interface {
+	Subscribe(ownerID int) (<-chan struct{}, func())
+}
+
+const (
+	changesBatchSize = 100
+
+	// watchPollInterval is the change log poll interval in case the notifications are lost
+	watchPollInterval = 30 * time.Second
+)
+
+// Watch sends the user`s entity changes after the cursor as they happen until ctx is done.
+// Only the changes made after the call are sent if the cursor is 0, the cursor must not be older than
+// the change log retention.
+//
+// The first send has no changes and the cursor the watch starts at, every next one has the changes
+// and the cursor of the last change, which is used to resume the watch.
+func (k *Keeper) Watch(ctx context.Context, ownerID int, cursor int64, send func(changes []*models.Change, cursor int64) error) error {
+
+	sl.Log.Info("watching entities", slog.Int64("cursor", cursor))
+
+	// the feed is subscribed before the change log is read to not miss the changes in between
+	signals, unsubscribe := k.cf.Subscribe(ownerID)
+	defer unsubscribe()
+
+	log, err := k.cs.ChangeLog(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if cursor > log.Last {
+		return ErrInvalidCursor
+	}
+	if cursor == 0 {
+		cursor = log.Last
+	}
+	if cursor < log.Pruned {
+		return ErrResyncRequired
+	}
+	if err := send(nil, cursor); err != nil {
+		return err
+	}
+
+	poll := time.NewTicker(watchPollInterval)
+	defer poll.Stop()
+	for {
+		changes, err := k.cs.Changes(ctx, ownerID, cursor, changesBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			cursor = changes[len(changes)-1].Seq
+			sealChanges(changes)
+			if err := send(changes, cursor); err != nil {
+				return err
+			}
+			if len(changes) == changesBatchSize {
+				continue
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-signals:
+			if !ok {
+				return ErrWatchStopped
+			}
+		case <-poll.C:
+		}
+	}
+}
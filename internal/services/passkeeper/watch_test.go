@@ -0,0 +1,101 @@
+package passkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/services/passkeeper/mocks"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+func TestKeeper_Watch(t *testing.T) {
+
+	type sent struct {
+		changes int
+		cursor  int64
+	}
+
+	tests := []struct {
+		name    string
+		cursor  int64
+		last    int64
+		pruned  int64
+		changes map[int64][]*models.Change
+		sent    []sent
+		err     error
+	}{
+		{
+			name: "new changes only",
+			last: 5,
+			changes: map[int64][]*models.Change{
+				5: {{Seq: 6, Kind: models.ChangeUpdated}},
+			},
+			sent: []sent{{0, 5}, {1, 6}},
+			err:  ErrWatchStopped,
+		},
+		{
+			name:   "resume",
+			cursor: 3,
+			last:   5,
+			changes: map[int64][]*models.Change{
+				3: {{Seq: 4, Kind: models.ChangeCreated}, {Seq: 5, Kind: models.ChangeDeleted}},
+			},
+			sent: []sent{{0, 3}, {2, 5}},
+			err:  ErrWatchStopped,
+		},
+		{
+			name:   "cursor ahead of the change log",
+			cursor: 10,
+			last:   5,
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "pruned cursor",
+			cursor: 3,
+			last:   10,
+			pruned: 5,
+			err:    ErrResyncRequired,
+		},
+		{
+			name:    "new changes only after pruning",
+			last:    10,
+			pruned:  10,
+			changes: map[int64][]*models.Change{10: {}},
+			sent:    []sent{{0, 10}},
+			err:     ErrWatchStopped,
+		},
+	}
+
+	sl.SetupLogger("test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cs := mocks.NewChangeStorage(t)
+			cf := mocks.NewChangeFeed(t)
+
+			// the closed channel stops the watch once all changes are sent
+			signals := make(chan struct{})
+			close(signals)
+			cf.On("Subscribe", 1).Return((<-chan struct{})(signals), func() {})
+			cs.On("ChangeLog", mock.Anything, 1).Return(models.ChangeLog{Last: tt.last, Pruned: tt.pruned}, nil)
+			for after, changes := range tt.changes {
+				cs.On("Changes", mock.Anything, 1, after, changesBatchSize).Return(changes, nil)
+			}
+
+			k := New(Deps{Changes: cs, ChangeFeed: cf}, "")
+			got := make([]sent, 0)
+			err := k.Watch(ctx, 1, tt.cursor, func(changes []*models.Change, cursor int64) error {
+				got = append(got, sent{len(changes), cursor})
+				return nil
+			})
+			assert.ErrorIs(t, err, tt.err)
+			if tt.sent != nil {
+				assert.Equal(t, tt.sent, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// accessRequestColumns are the columns of access_requests r joined with the requester u scanned by scanAccessRequest.
+const accessRequestColumns = `r.id, r.entity_type, r.entity_id, r.requester_id, u.email, r.reason, r.status,
+							  coalesce(r.approver_id, 0), r.expires_at, r.created_at, r.decided_at`
+
+// accessRequestsFrom joins the access requests with the requesters.
+const accessRequestsFrom = `access_requests r join users u on u.id = r.requester_id`
+
+// approversQuery selects the ids of the users approving the reads of the entity with the type $1 and the id $2.
+const approversQuery = `select owner_id from entities where type = $1 and id = $2 and vault_id is null
+						union
+						select m.user_id from entities e join vaults v on v.id = e.vault_id join org_members m on m.org_id = v.org_id
+						where e.type = $1 and e.id = $2 and m.role in ('` + string(models.RoleOwner) + `', '` + string(models.RoleAdmin) + `')`
+
+func scanAccessRequest(row pgx.Row) (*models.AccessRequest, error) {
+	r := &models.AccessRequest{}
+	var expiresAt, decidedAt *time.Time
+	err := row.Scan(&r.ID, &r.Type, &r.EntityID, &r.RequesterID, &r.RequesterEmail, &r.Reason, &r.Status, &r.ApproverID,
+		&expiresAt, &r.CreatedAt, &decidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt != nil {
+		r.ExpiresAt = *expiresAt
+	}
+	if decidedAt != nil {
+		r.DecidedAt = *decidedAt
+	}
+	return r, nil
+}
+
+func getAccessRequest(ctx context.Context, q querier, id int) (*models.AccessRequest, error) {
+	r, err := scanAccessRequest(q.QueryRow(ctx, "select "+accessRequestColumns+" from "+accessRequestsFrom+" where r.id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrAccessRequestNotExist
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// addAccessEvent audits the action on the entity requiring approval.
+func addAccessEvent(ctx context.Context, q querier, ev *models.AccessEvent) error {
+	query := `insert into access_events (entity_type, entity_id, request_id, actor_id, action, created_at)
+			  values ($1, $2, nullif($3, 0), $4, $5, $6)`
+	_, err := q.Exec(ctx, query, ev.Type, ev.EntityID, ev.RequestID, ev.ActorID, ev.Action, ev.CreatedAt)
+	return err
+}
+
+// notifyApprovers notifies the approvers of the entity, the notifications are delivered on commit.
+func notifyApprovers(ctx context.Context, q querier, t models.EntityType, id int) error {
+	_, err := q.Exec(ctx, "select pg_notify('"+accessRequestsChannel+"', a.owner_id::text) from ("+approversQuery+") a", t, id)
+	return err
+}
+
+// SetApprovalRequired sets whether the reads of the entity have to be approved and audits the change by the actor.
+// The entity revision is bumped, so the change is recorded in the change logs.
+func (s *Storage) SetApprovalRequired(ctx context.Context, t models.EntityType, id int, actorID int, required bool) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			var cur bool
+			query := "select requires_approval from entities where type = $1 and id = $2 and deleted_at is null for update"
+			if err := tx.QueryRow(ctx, query, t, id).Scan(&cur); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return notFound(t)
+				}
+				return err
+			}
+			if cur == required {
+				return nil
+			}
+			now := time.Now()
+			query = "update entities set requires_approval = $3, revision = revision + 1, updated_at = $4 where type = $1 and id = $2"
+			if _, err := tx.Exec(ctx, query, t, id, required, now); err != nil {
+				return err
+			}
+			ev := &models.AccessEvent{Type: t, EntityID: id, ActorID: actorID, Action: models.AccessWaived, CreatedAt: now}
+			if required {
+				ev.Action = models.AccessRequired
+			}
+			return addAccessEvent(ctx, tx, ev)
+		})
+	}, retryOpts()...)
+}
+
+// AddAccessRequest stores the pending request, audits it and notifies the approvers of the entity.
+func (s *Storage) AddAccessRequest(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error) {
+	return retry.DoWithData(func() (*models.AccessRequest, error) {
+		var res *models.AccessRequest
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			now := time.Now()
+			var id int
+			query := `insert into access_requests (entity_type, entity_id, requester_id, reason, status, created_at)
+					  values ($1, $2, $3, $4, $5, $6) returning id`
+			err := tx.QueryRow(ctx, query, r.Type, r.EntityID, r.RequesterID, r.Reason, models.AccessPending, now).Scan(&id)
+			if err != nil {
+				if pgErrCode(err) == pgerrcode.UniqueViolation {
+					return storage.ErrAccessRequestAlreadyExists
+				}
+				return err
+			}
+			ev := &models.AccessEvent{Type: r.Type, EntityID: r.EntityID, RequestID: id, ActorID: r.RequesterID,
+				Action: models.AccessRequested, CreatedAt: now}
+			if err := addAccessEvent(ctx, tx, ev); err != nil {
+				return err
+			}
+			if err := notifyApprovers(ctx, tx, r.Type, r.EntityID); err != nil {
+				return err
+			}
+			res, err = getAccessRequest(ctx, tx, id)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// GetAccessRequest returns the access request.
+func (s *Storage) GetAccessRequest(ctx context.Context, id int) (*models.AccessRequest, error) {
+	return retry.DoWithData(func() (*models.AccessRequest, error) {
+		return getAccessRequest(ctx, s.db, id)
+	}, retryOpts()...)
+}
+
+// DecideAccessRequest approves or denies the pending request by r.ApproverID with r.Status, the approved request
+// grants the read access until r.ExpiresAt. The decision is audited, the approvers and the requester are notified.
+func (s *Storage) DecideAccessRequest(ctx context.Context, r *models.AccessRequest) (*models.AccessRequest, error) {
+	return retry.DoWithData(func() (*models.AccessRequest, error) {
+		var res *models.AccessRequest
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			now := time.Now()
+			var expiresAt *time.Time
+			if r.Status == models.AccessApproved {
+				expiresAt = &r.ExpiresAt
+			}
+			query := `update access_requests set status = $2, approver_id = $3, expires_at = $4, decided_at = $5
+					  where id = $1 and status = $6`
+			tag, err := tx.Exec(ctx, query, r.ID, r.Status, r.ApproverID, expiresAt, now, models.AccessPending)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return storage.ErrAccessRequestDecided
+			}
+			res, err = getAccessRequest(ctx, tx, r.ID)
+			if err != nil {
+				return err
+			}
+			action := models.AccessRejected
+			if r.Status == models.AccessApproved {
+				action = models.AccessGranted
+			}
+			ev := &models.AccessEvent{Type: res.Type, EntityID: res.EntityID, RequestID: r.ID, ActorID: r.ApproverID,
+				Action: action, CreatedAt: now}
+			if err := addAccessEvent(ctx, tx, ev); err != nil {
+				return err
+			}
+			if err := notifyApprovers(ctx, tx, res.Type, res.EntityID); err != nil {
+				return err
+			}
+			_, err = tx.Exec(ctx, "select pg_notify('"+accessRequestsChannel+"', $1::text)", res.RequesterID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// ListAccessRequests returns the pending requests to the entities the user approves and the user`s own requests
+// but the expired ones, the latest first.
+func (s *Storage) ListAccessRequests(ctx context.Context, userID int, now time.Time) ([]*models.AccessRequest, error) {
+	return retry.DoWithData(func() ([]*models.AccessRequest, error) {
+		query := `select ` + accessRequestColumns + ` from ` + accessRequestsFrom + `
+				  where (r.requester_id = $1 and (r.status <> $3 or r.expires_at > $2))
+				     or (r.status = $4 and exists(select 1 from entities
+				           where type = r.entity_type and id = r.entity_id and ` + approverCondition("$1") + `))
+				  order by r.id desc`
+		rows, err := s.db.Query(ctx, query, userID, now, models.AccessApproved, models.AccessPending)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		requests := make([]*models.AccessRequest, 0)
+		for rows.Next() {
+			r, err := scanAccessRequest(rows)
+			if err != nil {
+				return nil, err
+			}
+			requests = append(requests, r)
+		}
+		return requests, rows.Err()
+	}, retryOpts()...)
+}
+
+// ActiveAccessRequest returns the user`s approved request to the entity which view window is open at now.
+func (s *Storage) ActiveAccessRequest(ctx context.Context, t models.EntityType, id int, userID int, now time.Time) (*models.AccessRequest, error) {
+	return retry.DoWithData(func() (*models.AccessRequest, error) {
+		query := `select ` + accessRequestColumns + ` from ` + accessRequestsFrom + `
+				  where r.entity_type = $1 and r.entity_id = $2 and r.requester_id = $3 and r.status = $4 and r.expires_at > $5
+				  order by r.expires_at desc limit 1`
+		r, err := scanAccessRequest(s.db.QueryRow(ctx, query, t, id, userID, models.AccessApproved, now))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrAccessRequestNotExist
+			}
+			return nil, err
+		}
+		return r, nil
+	}, retryOpts()...)
+}
+
+// AddAccessEvent audits the view of the entity requiring approval.
+func (s *Storage) AddAccessEvent(ctx context.Context, ev *models.AccessEvent) error {
+	return retry.Do(func() error {
+		audited := *ev
+		audited.CreatedAt = time.Now()
+		return addAccessEvent(ctx, s.db, &audited)
+	}, retryOpts()...)
+}
+
+// ListAccessEvents returns the audit trail of the entity in the order the events happened.
+func (s *Storage) ListAccessEvents(ctx context.Context, t models.EntityType, id int) ([]*models.AccessEvent, error) {
+	return retry.DoWithData(func() ([]*models.AccessEvent, error) {
+		query := `select id, entity_type, entity_id, coalesce(request_id, 0), actor_id, action, created_at
+				  from access_events where entity_type = $1 and entity_id = $2 order by id`
+		rows, err := s.db.Query(ctx, query, t, id)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		events := make([]*models.AccessEvent, 0)
+		for rows.Next() {
+			ev := &models.AccessEvent{}
+			if err := rows.Scan(&ev.ID, &ev.Type, &ev.EntityID, &ev.RequestID, &ev.ActorID, &ev.Action, &ev.CreatedAt); err != nil {
+				return nil, err
+			}
+			events = append(events, ev)
+		}
+		return events, rows.Err()
+	}, retryOpts()...)
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/pkg/logger/sl"
+)
+
+const (
+	// changesChannel is the notification channel of the entity changes, the payload is the id of the user
+	// the change is recorded for.
+	changesChannel = "entity_changes"
+
+	// accessRequestsChannel is the notification channel of the access requests, the payload is the id
+	// of the user the request is sent to or decided for.
+	accessRequestsChannel = "access_requests"
+)
+
+// ChangeLog returns the state of the user`s change log.
+func (s *Storage) ChangeLog(ctx context.Context, ownerID int) (models.ChangeLog, error) {
+	return retry.DoWithData(func() (models.ChangeLog, error) {
+		var log models.ChangeLog
+		query := "select seq, pruned_seq from change_cursors where owner_id = $1"
+		err := s.db.QueryRow(ctx, query, ownerID).Scan(&log.Last, &log.Pruned)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return models.ChangeLog{}, err
+		}
+		return log, nil
+	}, retryOpts()...)
+}
+
+// DeleteOldChanges deletes the changes older than the provided age from the change logs of all users
+// and returns the number of deleted changes. The age is compared with the database clock,
+// which sets the change time.
+func (s *Storage) DeleteOldChanges(ctx context.Context, age time.Duration) (int64, error) {
+	return retry.DoWithData(func() (int64, error) {
+		query := `with deleted as (
+    				delete from entity_changes where changed_at < localtimestamp - $1::interval returning owner_id, seq
+    			  ), pruned as (
+    				update change_cursors c set
+    				  pruned_seq = greatest(c.pruned_seq, d.seq)
+    				from
+    				  (select owner_id, max(seq) as seq from deleted group by owner_id) d
+    				where
+    				  c.owner_id = d.owner_id
+    			  )
+    			  select count(*) from deleted`
+		var n int64
+		if err := s.db.QueryRow(ctx, query, age).Scan(&n); err != nil {
+			return 0, err
+		}
+		return n, nil
+	}, retryOpts()...)
+}
+
+// Changes returns up to limit changes of the user`s change log after the provided position
+// with the current state of the changed entities. The changes of the entities the user reads are recorded
+// in the user`s change log, including the entities the user gains or loses the access to.
+func (s *Storage) Changes(ctx context.Context, ownerID int, after int64, limit int) ([]*models.Change, error) {
+	return retry.DoWithData(func() ([]*models.Change, error) {
+		query := `select
+    				seq, kind, entity_type, entity_id, revision, changed_at
+    			  from
+    				entity_changes
+    			  where
+    				owner_id = $1 and seq > $2
+    			  order by seq
+    			  limit $3`
+		rows, err := s.db.Query(ctx, query, ownerID, after, limit)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		changes := make([]*models.Change, 0)
+		for rows.Next() {
+			c := &models.Change{}
+			if err := rows.Scan(&c.Seq, &c.Kind, &c.Type, &c.ID, &c.Revision, &c.ChangedAt); err != nil {
+				return nil, err
+			}
+			changes = append(changes, c)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if err := s.changedEntities(ctx, ownerID, changes); err != nil {
+			return nil, err
+		}
+		return changes, nil
+	}, retryOpts()...)
+}
+
+// changedEntities sets the current entity of the changes with the user`s permission, purged entities
+// and the entities the user no longer reads are left nil.
+func (s *Storage) changedEntities(ctx context.Context, userID int, changes []*models.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	types := make([]string, 0, len(changes))
+	ids := make([]int, 0, len(changes))
+	for _, c := range changes {
+		types = append(types, string(c.Type))
+		ids = append(ids, c.ID)
+	}
+	query := `select
+    			` + entityColumns + `, ` + sharedColumns("$1") + `
+    		  from
+    			entities
+    		  where
+    			` + accessCondition("$1", models.PermissionRead) + ` and (type, id) in (select * from unnest($2::text[], $3::integer[]))`
+	rows, err := s.db.Query(ctx, query, userID, types, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type key struct {
+		t  models.EntityType
+		id int
+	}
+	entities := make(map[key]*models.Entity)
+	for rows.Next() {
+		e, err := scanSharedEntity(rows)
+		if err != nil {
+			return err
+		}
+		entities[key{e.Type, e.ID}] = e
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		c.Entity = entities[key{c.Type, c.ID}]
+	}
+	return nil
+}
+
+// ListenChanges listens to the entity change notifications of all replicas on a dedicated connection
+// until ctx is done or the connection fails. listening is called once the notifications are listened to,
+// notify is called with the owner id of every notification.
+func (s *Storage) ListenChanges(ctx context.Context, listening func(), notify func(ownerID int)) error {
+	return s.listen(ctx, changesChannel, listening, notify)
+}
+
+// ListenAccessRequests listens to the access request notifications of all replicas the same way
+// ListenChanges does, notify is called with the id of the notified user.
+func (s *Storage) ListenAccessRequests(ctx context.Context, listening func(), notify func(userID int)) error {
+	return s.listen(ctx, accessRequestsChannel, listening, notify)
+}
+
+// listen listens to the channel notifications with the user id payload.
+func (s *Storage) listen(ctx context.Context, channel string, listening func(), notify func(userID int)) error {
+	pc, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection is not returned to the pool to not leak the listen state
+	conn := pc.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "listen "+channel); err != nil {
+		return err
+	}
+	listening()
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		userID, err := strconv.Atoi(n.Payload)
+		if err != nil {
+			sl.Log.Error("malformed notification", slog.String("channel", channel), slog.String("payload", n.Payload), sl.Err(err))
+			continue
+		}
+		notify(userID)
+	}
+}
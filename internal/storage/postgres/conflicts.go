@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// conflictColumns are the columns scanned by scanConflict.
+const conflictColumns = `id, owner_id, entity_type, entity_id, base_revision, server_revision, fields, conflicting_fields,
+    				data, created_at`
+
+// organizeData is the part of the conflict data placing the client edit into the folder, tagging and labeling it.
+type organizeData struct {
+	FolderID int               `json:"folder_id"`
+	TagIDs   []int             `json:"tag_ids"`
+	Labels   map[string]string `json:"labels"`
+}
+
+// encodeConflictData returns the client edit kept in the data column: the typed payload with the metadata
+// and the organize fields.
+func encodeConflictData(e *models.Entity) (map[string]any, error) {
+	data, err := encodePayload(e)
+	if err != nil {
+		return nil, err
+	}
+	data[payloadKeys[models.FieldMetadata]] = e.Metadata
+	data["folder_id"] = e.FolderID
+	data["tag_ids"] = uniqueIDs(e.TagIDs)
+	data["labels"] = labelsArg(e.Labels)
+	return data, nil
+}
+
+func decodeConflictData(data []byte, e *models.Entity) error {
+	if err := decodePayload(data, e); err != nil {
+		return err
+	}
+	var o organizeData
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	e.FolderID = o.FolderID
+	e.TagIDs = o.TagIDs
+	if len(e.TagIDs) == 0 {
+		e.TagIDs = nil
+	}
+	e.Labels = o.Labels
+	if len(e.Labels) == 0 {
+		e.Labels = nil
+	}
+	return nil
+}
+
+func fieldsArg(fields []models.EntityField) []string {
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, string(f))
+	}
+	return res
+}
+
+func scanFields(fields []string) []models.EntityField {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]models.EntityField, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, models.EntityField(f))
+	}
+	return res
+}
+
+func scanConflict(row pgx.Row) (*models.Conflict, error) {
+	c := &models.Conflict{}
+	var fields, conflicting []string
+	var data []byte
+	err := row.Scan(&c.ID, &c.OwnerID, &c.Type, &c.EntityID, &c.BaseRevision, &c.ServerRevision, &fields, &conflicting,
+		&data, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.Fields = scanFields(fields)
+	c.ConflictingFields = scanFields(conflicting)
+	c.Client = &models.Entity{ID: c.EntityID, OwnerID: c.OwnerID, Type: c.Type, Revision: c.BaseRevision}
+	if err := decodeConflictData(data, c.Client); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddConflict stores the conflicting client edit of the entity the user can update.
+func (s *Storage) AddConflict(ctx context.Context, c *models.Conflict) (int, error) {
+	data, err := encodeConflictData(c.Client)
+	if err != nil {
+		return 0, err
+	}
+	return retry.DoWithData(func() (int, error) {
+		query := `insert into entity_conflicts
+    				(owner_id, entity_type, entity_id, base_revision, server_revision, fields, conflicting_fields, data, created_at)
+    			  select
+    				$1, $2, $3, $4, $5, $6, $7, $8, $9
+    			  where
+    				exists(select 1 from entities where type = $2 and id = $3 and ` + accessCondition("$1", models.PermissionWrite) + `
+    				  and deleted_at is null)
+    			  returning id`
+		var id int
+		err := s.db.QueryRow(ctx, query, c.OwnerID, c.Type, c.EntityID, c.BaseRevision, c.ServerRevision,
+			fieldsArg(c.Fields), fieldsArg(c.ConflictingFields), data, time.Now()).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return 0, notFound(c.Type)
+			}
+			return 0, err
+		}
+		return id, nil
+	}, retryOpts()...)
+}
+
+// GetConflict returns the user`s entity conflict.
+func (s *Storage) GetConflict(ctx context.Context, id int, ownerID int) (*models.Conflict, error) {
+	return retry.DoWithData(func() (*models.Conflict, error) {
+		query := "select " + conflictColumns + " from entity_conflicts where id = $1 and owner_id = $2"
+		c, err := scanConflict(s.db.QueryRow(ctx, query, id, ownerID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrConflictNotExist
+			}
+			return nil, err
+		}
+		return c, nil
+	}, retryOpts()...)
+}
+
+// ListConflicts returns all user`s entity conflicts, the oldest first.
+func (s *Storage) ListConflicts(ctx context.Context, ownerID int) ([]*models.Conflict, error) {
+	return retry.DoWithData(func() ([]*models.Conflict, error) {
+		query := "select " + conflictColumns + " from entity_conflicts where owner_id = $1 order by id"
+		rows, err := s.db.Query(ctx, query, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		conflicts := make([]*models.Conflict, 0)
+		for rows.Next() {
+			c, err := scanConflict(rows)
+			if err != nil {
+				return nil, err
+			}
+			conflicts = append(conflicts, c)
+		}
+		return conflicts, rows.Err()
+	}, retryOpts()...)
+}
+
+// DeleteConflict deletes the user`s entity conflict.
+func (s *Storage) DeleteConflict(ctx context.Context, id int, ownerID int) error {
+	return retry.Do(func() error {
+		tag, err := s.db.Exec(ctx, "delete from entity_conflicts where id = $1 and owner_id = $2", id, ownerID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrConflictNotExist
+		}
+		return nil
+	}, retryOpts()...)
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// deviceColumns are the columns scanned by scanDevice.
+const deviceColumns = "id, user_id, name, public_key, created_at, last_login_at"
+
+func scanDevice(row pgx.Row) (*models.Device, error) {
+	d := &models.Device{}
+	if err := row.Scan(&d.ID, &d.UserID, &d.Name, &d.PublicKey, &d.CreatedAt, &d.LastLoginAt); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// AddDevice registers the user`s device.
+func (s *Storage) AddDevice(ctx context.Context, d *models.Device) (*models.Device, error) {
+	return retry.DoWithData(func() (*models.Device, error) {
+		query := `insert into devices (user_id, name, public_key, created_at, last_login_at)
+					values ($1, $2, $3, $4, $4) returning ` + deviceColumns
+		return scanDevice(s.db.QueryRow(ctx, query, d.UserID, d.Name, d.PublicKey, time.Now()))
+	}, retryOpts()...)
+}
+
+// GetDevice returns the user`s device.
+func (s *Storage) GetDevice(ctx context.Context, id int, userID int) (*models.Device, error) {
+	return retry.DoWithData(func() (*models.Device, error) {
+		query := "select " + deviceColumns + " from devices where id = $1 and user_id = $2"
+		d, err := scanDevice(s.db.QueryRow(ctx, query, id, userID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrDeviceNotExist
+			}
+			return nil, err
+		}
+		return d, nil
+	}, retryOpts()...)
+}
+
+// LoginDevice updates the last login time of the user`s device.
+func (s *Storage) LoginDevice(ctx context.Context, id int, userID int) (*models.Device, error) {
+	return retry.DoWithData(func() (*models.Device, error) {
+		query := "update devices set last_login_at = $3 where id = $1 and user_id = $2 returning " + deviceColumns
+		d, err := scanDevice(s.db.QueryRow(ctx, query, id, userID, time.Now()))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrDeviceNotExist
+			}
+			return nil, err
+		}
+		return d, nil
+	}, retryOpts()...)
+}
+
+// ListDevices returns all user`s devices in the registration order.
+func (s *Storage) ListDevices(ctx context.Context, userID int) ([]*models.Device, error) {
+	return retry.DoWithData(func() ([]*models.Device, error) {
+		rows, err := s.db.Query(ctx, "select "+deviceColumns+" from devices where user_id = $1 order by id", userID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		devices := make([]*models.Device, 0)
+		for rows.Next() {
+			d, err := scanDevice(rows)
+			if err != nil {
+				return nil, err
+			}
+			devices = append(devices, d)
+		}
+		return devices, rows.Err()
+	}, retryOpts()...)
+}
+
+// DeleteDevice deletes the user`s device.
+func (s *Storage) DeleteDevice(ctx context.Context, id int, userID int) error {
+	return retry.Do(func() error {
+		tag, err := s.db.Exec(ctx, "delete from devices where id = $1 and user_id = $2", id, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrDeviceNotExist
+		}
+		return nil
+	}, retryOpts()...)
+}
+
+// DeviceExists reports whether the user`s device is registered.
+func (s *Storage) DeviceExists(ctx context.Context, id int, userID int) (bool, error) {
+	return retry.DoWithData(func() (bool, error) {
+		var exists bool
+		query := "select exists(select 1 from devices where id = $1 and user_id = $2)"
+		if err := s.db.QueryRow(ctx, query, id, userID).Scan(&exists); err != nil {
+			return false, err
+		}
+		return exists, nil
+	}, retryOpts()...)
+}
+
+// AddChallenge saves the login challenge nonce, the expired challenges are deleted.
+func (s *Storage) AddChallenge(ctx context.Context, nonce []byte, expiresAt time.Time) error {
+	return retry.Do(func() error {
+		query := `with expired as (delete from device_challenges where expires_at < $3)
+				  insert into device_challenges (nonce, expires_at) values ($1, $2)`
+		_, err := s.db.Exec(ctx, query, nonce, expiresAt, time.Now())
+		return err
+	}, retryOpts()...)
+}
+
+// UseChallenge deletes the login challenge, the challenge can be used once before it expires.
+func (s *Storage) UseChallenge(ctx context.Context, nonce []byte) error {
+	return retry.Do(func() error {
+		query := "delete from device_challenges where nonce = $1 and expires_at > $2"
+		tag, err := s.db.Exec(ctx, query, nonce, time.Now())
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrChallengeNotExist
+		}
+		return nil
+	}, retryOpts()...)
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// contactColumns are the columns of emergency_contacts c joined with the grantor gr and the grantee ge users
+// scanned by scanContact.
+const contactColumns = `c.id, c.grantor_id, gr.email, c.grantee_id, ge.email, c.status, c.wait_seconds, c.requested_at,
+						c.created_at, c.updated_at`
+
+// contactsFrom joins the emergency contacts with the grantor and the grantee users.
+const contactsFrom = `emergency_contacts c join users gr on gr.id = c.grantor_id join users ge on ge.id = c.grantee_id`
+
+func scanContact(row pgx.Row) (*models.EmergencyContact, error) {
+	c := &models.EmergencyContact{}
+	var wait int64
+	var requestedAt *time.Time
+	err := row.Scan(&c.ID, &c.GrantorID, &c.GrantorEmail, &c.GranteeID, &c.GranteeEmail, &c.Status, &wait, &requestedAt,
+		&c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.WaitPeriod = time.Duration(wait) * time.Second
+	if requestedAt != nil {
+		c.RequestedAt = *requestedAt
+	}
+	return c, nil
+}
+
+// addEmergencyEvent audits the emergency access transition, actor 0 is the system.
+func addEmergencyEvent(ctx context.Context, q querier, ev *models.EmergencyEvent) error {
+	query := `insert into emergency_events (contact_id, actor_id, action, from_status, to_status, created_at)
+			  values ($1, nullif($2, 0), $3, $4, $5, $6)`
+	_, err := q.Exec(ctx, query, ev.ContactID, ev.ActorID, ev.Action, ev.From, ev.To, ev.CreatedAt)
+	return err
+}
+
+func getContact(ctx context.Context, q querier, id int) (*models.EmergencyContact, error) {
+	return scanContact(q.QueryRow(ctx, "select "+contactColumns+" from "+contactsFrom+" where c.id = $1", id))
+}
+
+// InviteEmergencyContact invites the registered user with the grantee email as the grantor`s emergency contact,
+// the revoked contact is invited again.
+func (s *Storage) InviteEmergencyContact(ctx context.Context, c *models.EmergencyContact) (*models.EmergencyContact, error) {
+	return retry.DoWithData(func() (*models.EmergencyContact, error) {
+		var res *models.EmergencyContact
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			var granteeID int
+			err := tx.QueryRow(ctx, "select id from users where email = $1", c.GranteeEmail).Scan(&granteeID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrUserNotExist
+				}
+				return err
+			}
+			if granteeID == c.GrantorID {
+				return storage.ErrEmergencyContactSelf
+			}
+			var id int
+			var prev models.EmergencyStatus
+			query := "select id, status from emergency_contacts where grantor_id = $1 and grantee_id = $2 for update"
+			err = tx.QueryRow(ctx, query, c.GrantorID, granteeID).Scan(&id, &prev)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			next, ok := prev.Next(models.EmergencyInvite)
+			if !ok {
+				return storage.ErrEmergencyContactAlreadyExists
+			}
+			now := time.Now()
+			wait := int64(c.WaitPeriod / time.Second)
+			if id == 0 {
+				query = `insert into emergency_contacts (grantor_id, grantee_id, status, wait_seconds, created_at, updated_at)
+						 values ($1, $2, $3, $4, $5, $5) returning id`
+				err = tx.QueryRow(ctx, query, c.GrantorID, granteeID, next, wait, now).Scan(&id)
+			} else {
+				query = `update emergency_contacts set status = $2, wait_seconds = $3, requested_at = null, updated_at = $4
+						 where id = $1`
+				_, err = tx.Exec(ctx, query, id, next, wait, now)
+			}
+			if err != nil {
+				if pgErrCode(err) == pgerrcode.UniqueViolation {
+					return storage.ErrEmergencyContactAlreadyExists
+				}
+				return err
+			}
+			ev := &models.EmergencyEvent{ContactID: id, ActorID: c.GrantorID, Action: models.EmergencyInvite, From: prev, To: next, CreatedAt: now}
+			if err := addEmergencyEvent(ctx, tx, ev); err != nil {
+				return err
+			}
+			res, err = getContact(ctx, tx, id)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// TransitionEmergencyContact moves the emergency contact from the event from status to its to status
+// and audits the event. The error is reported if the status has changed since it was read.
+func (s *Storage) TransitionEmergencyContact(ctx context.Context, ev *models.EmergencyEvent) (*models.EmergencyContact, error) {
+	return retry.DoWithData(func() (*models.EmergencyContact, error) {
+		var res *models.EmergencyContact
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			now := time.Now()
+			query := `update emergency_contacts set
+						status = $3,
+						requested_at = case when $3 = $5 then $4 else requested_at end,
+						updated_at = $4
+					  where id = $1 and status = $2`
+			tag, err := tx.Exec(ctx, query, ev.ContactID, ev.From, ev.To, now, models.EmergencyRequested)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return storage.ErrEmergencyTransition
+			}
+			audited := *ev
+			audited.CreatedAt = now
+			if err := addEmergencyEvent(ctx, tx, &audited); err != nil {
+				return err
+			}
+			res, err = getContact(ctx, tx, ev.ContactID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// GetEmergencyContact returns the emergency contact the user is the grantor or the grantee of.
+func (s *Storage) GetEmergencyContact(ctx context.Context, id int, userID int) (*models.EmergencyContact, error) {
+	return retry.DoWithData(func() (*models.EmergencyContact, error) {
+		query := "select " + contactColumns + " from " + contactsFrom + " where c.id = $1 and (c.grantor_id = $2 or c.grantee_id = $2)"
+		c, err := scanContact(s.db.QueryRow(ctx, query, id, userID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrEmergencyContactNotExist
+			}
+			return nil, err
+		}
+		return c, nil
+	}, retryOpts()...)
+}
+
+// ListEmergencyContacts returns the emergency contacts the user is the grantor or the grantee of.
+func (s *Storage) ListEmergencyContacts(ctx context.Context, userID int) ([]*models.EmergencyContact, error) {
+	return retry.DoWithData(func() ([]*models.EmergencyContact, error) {
+		query := "select " + contactColumns + " from " + contactsFrom + " where c.grantor_id = $1 or c.grantee_id = $1 order by c.id"
+		rows, err := s.db.Query(ctx, query, userID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		contacts := make([]*models.EmergencyContact, 0)
+		for rows.Next() {
+			c, err := scanContact(rows)
+			if err != nil {
+				return nil, err
+			}
+			contacts = append(contacts, c)
+		}
+		return contacts, rows.Err()
+	}, retryOpts()...)
+}
+
+// ListEmergencyEvents returns the audited transitions of the emergency contact in the order they happened.
+func (s *Storage) ListEmergencyEvents(ctx context.Context, contactID int) ([]*models.EmergencyEvent, error) {
+	return retry.DoWithData(func() ([]*models.EmergencyEvent, error) {
+		query := `select id, contact_id, coalesce(actor_id, 0), action, from_status, to_status, created_at
+				  from emergency_events where contact_id = $1 order by id`
+		rows, err := s.db.Query(ctx, query, contactID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		events := make([]*models.EmergencyEvent, 0)
+		for rows.Next() {
+			ev := &models.EmergencyEvent{}
+			if err := rows.Scan(&ev.ID, &ev.ContactID, &ev.ActorID, &ev.Action, &ev.From, &ev.To, &ev.CreatedAt); err != nil {
+				return nil, err
+			}
+			events = append(events, ev)
+		}
+		return events, rows.Err()
+	}, retryOpts()...)
+}
+
+// GrantEmergencyRequests grants the emergency access requested the wait period before the provided time,
+// the grants are audited as the system transitions. The number of granted requests is returned.
+func (s *Storage) GrantEmergencyRequests(ctx context.Context, now time.Time) (int64, error) {
+	return retry.DoWithData(func() (int64, error) {
+		query := `with granted as (
+					update emergency_contacts set status = $3, updated_at = $1
+					where status = $2 and requested_at + wait_seconds * interval '1 second' <= $1
+					returning id
+				  )
+				  insert into emergency_events (contact_id, actor_id, action, from_status, to_status, created_at)
+				  select id, null::integer, $4, $2, $3, $1 from granted`
+		tag, err := s.db.Exec(ctx, query, now, models.EmergencyRequested, models.EmergencyGranted, models.EmergencyGrant)
+		if err != nil {
+			return 0, err
+		}
+		return tag.RowsAffected(), nil
+	}, retryOpts()...)
+}
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// folderColumns are the columns scanned by scanFolder.
+const folderColumns = "id, owner_id, coalesce(parent_id, 0), name, created_at, updated_at"
+
+func scanFolder(row pgx.Row) (*models.Folder, error) {
+	f := &models.Folder{}
+	if err := row.Scan(&f.ID, &f.OwnerID, &f.ParentID, &f.Name, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// CreateFolder creates the folder in the parent folder, at the top level if the parent id is 0.
+func (s *Storage) CreateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	return retry.DoWithData(func() (*models.Folder, error) {
+		var res *models.Folder
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if err := checkFolder(ctx, tx, f.ParentID, f.OwnerID); err != nil {
+				return err
+			}
+			query := `insert into folders (owner_id, parent_id, name, created_at, updated_at)
+						values ($1, nullif($2, 0), $3, $4, $4) returning ` + folderColumns
+			var err error
+			res, err = scanFolder(tx.QueryRow(ctx, query, f.OwnerID, f.ParentID, f.Name, time.Now()))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// UpdateFolder renames the folder and moves it to the parent folder.
+// The folder can not be moved into itself or its subfolder.
+func (s *Storage) UpdateFolder(ctx context.Context, f *models.Folder) (*models.Folder, error) {
+	return retry.DoWithData(func() (*models.Folder, error) {
+		var res *models.Folder
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if err := checkFolder(ctx, tx, f.ParentID, f.OwnerID); err != nil {
+				return err
+			}
+			if f.ParentID != 0 {
+				var cycle bool
+				query := `with recursive ancestors as (
+							select id, parent_id from folders where id = $1
+							union all
+							select f.id, f.parent_id from folders f join ancestors a on f.id = a.parent_id
+						  )
+						  select exists(select 1 from ancestors where id = $2)`
+				if err := tx.QueryRow(ctx, query, f.ParentID, f.ID).Scan(&cycle); err != nil {
+					return err
+				}
+				if cycle {
+					return storage.ErrFolderCycle
+				}
+			}
+			query := `update folders set
+						name=$3, parent_id=nullif($4, 0), updated_at=$5
+					  where
+						id = $1 and owner_id = $2
+					  returning ` + folderColumns
+			var err error
+			res, err = scanFolder(tx.QueryRow(ctx, query, f.ID, f.OwnerID, f.Name, f.ParentID, time.Now()))
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrFolderNotExist
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// DeleteFolder deletes the folder, its subfolders and entities, including the ones in the trash,
+// are moved to the parent folder.
+func (s *Storage) DeleteFolder(ctx context.Context, id int, ownerID int) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			var parentID *int
+			query := "select parent_id from folders where id = $1 and owner_id = $2 for update"
+			if err := tx.QueryRow(ctx, query, id, ownerID).Scan(&parentID); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrFolderNotExist
+				}
+				return err
+			}
+			now := time.Now()
+			if _, err := tx.Exec(ctx, "update folders set parent_id=$2, updated_at=$3 where parent_id = $1", id, parentID, now); err != nil {
+				return err
+			}
+			query = `update entities set
+						folder_id=$2, updated_at=$3, revision=revision+1
+					  where
+						folder_id = $1`
+			if _, err := tx.Exec(ctx, query, id, parentID, now); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "delete from folders where id = $1", id)
+			return err
+		})
+	}, retryOpts()...)
+}
+
+// ListFolders returns all user`s folders.
+func (s *Storage) ListFolders(ctx context.Context, ownerID int) ([]*models.Folder, error) {
+	return retry.DoWithData(func() ([]*models.Folder, error) {
+		query := "select " + folderColumns + " from folders where owner_id = $1 order by id"
+		rows, err := s.db.Query(ctx, query, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		folders := make([]*models.Folder, 0)
+		for rows.Next() {
+			f, err := scanFolder(rows)
+			if err != nil {
+				return nil, err
+			}
+			folders = append(folders, f)
+		}
+		return folders, rows.Err()
+	}, retryOpts()...)
+}
+
+func scanTag(row pgx.Row) (*models.Tag, error) {
+	t := &models.Tag{}
+	if err := row.Scan(&t.ID, &t.OwnerID, &t.Name, &t.CreatedAt); err != nil {
+		if pgErrCode(err) == pgerrcode.UniqueViolation {
+			return nil, storage.ErrTagAlreadyExists
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateTag creates the tag.
+func (s *Storage) CreateTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	return retry.DoWithData(func() (*models.Tag, error) {
+		query := `insert into tags (owner_id, name, created_at) values ($1, $2, $3)
+					returning id, owner_id, name, created_at`
+		return scanTag(s.db.QueryRow(ctx, query, t.OwnerID, t.Name, time.Now()))
+	}, retryOpts()...)
+}
+
+// RenameTag renames the tag.
+func (s *Storage) RenameTag(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	return retry.DoWithData(func() (*models.Tag, error) {
+		query := `update tags set name=$3 where id = $1 and owner_id = $2
+					returning id, owner_id, name, created_at`
+		res, err := scanTag(s.db.QueryRow(ctx, query, t.ID, t.OwnerID, t.Name))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrTagNotExist
+		}
+		return res, err
+	}, retryOpts()...)
+}
+
+// DeleteTag deletes the tag and untags the entities, the revision of the untagged entities is incremented.
+func (s *Storage) DeleteTag(ctx context.Context, id int, ownerID int) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			query := `update entities set
+						updated_at=$3, revision=revision+1
+					  where
+						owner_id = $2 and (type, id) in (select entity_type, entity_id from entity_tags where tag_id = $1)`
+			if _, err := tx.Exec(ctx, query, id, ownerID, time.Now()); err != nil {
+				return err
+			}
+			tag, err := tx.Exec(ctx, "delete from tags where id = $1 and owner_id = $2", id, ownerID)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return storage.ErrTagNotExist
+			}
+			return nil
+		})
+	}, retryOpts()...)
+}
+
+// ListTags returns all user`s tags sorted by name.
+func (s *Storage) ListTags(ctx context.Context, ownerID int) ([]*models.Tag, error) {
+	return retry.DoWithData(func() ([]*models.Tag, error) {
+		query := "select id, owner_id, name, created_at from tags where owner_id = $1 order by name"
+		rows, err := s.db.Query(ctx, query, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		tags := make([]*models.Tag, 0)
+		for rows.Next() {
+			t, err := scanTag(rows)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, t)
+		}
+		return tags, rows.Err()
+	}, retryOpts()...)
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// userKeysColumns are the columns of user_keys k joined with users u scanned by scanUserKeys.
+const userKeysColumns = "k.user_id, u.email, k.public_key, k.wrapped_private_key, k.created_at, k.updated_at"
+
+// vaultKeyColumns are the columns of vault_keys k joined with users u scanned by scanVaultKey.
+const vaultKeyColumns = "k.vault_id, k.user_id, u.email, k.version, k.encrypted_key, k.created_at"
+
+func scanUserKeys(row pgx.Row) (*models.UserKeys, error) {
+	k := &models.UserKeys{}
+	if err := row.Scan(&k.UserID, &k.Email, &k.PublicKey, &k.WrappedPrivateKey, &k.CreatedAt, &k.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func scanVaultKey(row pgx.Row) (*models.VaultKey, error) {
+	k := &models.VaultKey{}
+	if err := row.Scan(&k.VaultID, &k.UserID, &k.Email, &k.Version, &k.EncryptedKey, &k.CreatedAt); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// SetUserKeys registers the user`s key pair or replaces the wrapped private key of the registered one,
+// the public key of the registered key pair can not be changed.
+func (s *Storage) SetUserKeys(ctx context.Context, k *models.UserKeys) (*models.UserKeys, error) {
+	return retry.DoWithData(func() (*models.UserKeys, error) {
+		query := `with k as (
+					insert into user_keys (user_id, public_key, wrapped_private_key, created_at, updated_at)
+					values ($1, $2, $3, $4, $4)
+					on conflict (user_id) do update set
+					  wrapped_private_key = excluded.wrapped_private_key,
+					  updated_at = excluded.updated_at
+					where user_keys.public_key = excluded.public_key
+					returning *
+				  )
+				  select ` + userKeysColumns + ` from k join users u on u.id = k.user_id`
+		res, err := scanUserKeys(s.db.QueryRow(ctx, query, k.UserID, k.PublicKey, k.WrappedPrivateKey, time.Now()))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrPublicKeyMismatch
+			}
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// UserKeys returns the user`s key pair.
+func (s *Storage) UserKeys(ctx context.Context, userID int) (*models.UserKeys, error) {
+	return retry.DoWithData(func() (*models.UserKeys, error) {
+		query := "select " + userKeysColumns + " from user_keys k join users u on u.id = k.user_id where k.user_id = $1"
+		k, err := scanUserKeys(s.db.QueryRow(ctx, query, userID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrUserKeysNotExist
+			}
+			return nil, err
+		}
+		return k, nil
+	}, retryOpts()...)
+}
+
+// PublicKey returns the public key of the user with the email, the wrapped private key is not set.
+func (s *Storage) PublicKey(ctx context.Context, email string) (*models.UserKeys, error) {
+	return retry.DoWithData(func() (*models.UserKeys, error) {
+		query := "select " + userKeysColumns + " from user_keys k join users u on u.id = k.user_id where u.email = $1"
+		k, err := scanUserKeys(s.db.QueryRow(ctx, query, email))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrUserKeysNotExist
+			}
+			return nil, err
+		}
+		k.WrappedPrivateKey = nil
+		return k, nil
+	}, retryOpts()...)
+}
+
+// SetVaultKeys stores the vault key version encrypted to the members with the keys emails.
+// The current version is given to the members missing it unless the vault has to be re-keyed, the next version
+// rotates the vault key, it has to be encrypted to every member and clears the re-key flag.
+// The user has to be an admin or an owner.
+func (s *Storage) SetVaultKeys(ctx context.Context, vaultID int, orgID int, version int, keys []*models.VaultKey, userID int) (*models.Vault, error) {
+	return retry.DoWithData(func() (*models.Vault, error) {
+		var res *models.Vault
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, orgID, userID, models.RoleAdmin); err != nil {
+				return err
+			}
+			var current int
+			var rekey bool
+			query := "select key_version, rekey_required from vaults where id = $1 and org_id = $2 for update"
+			if err := tx.QueryRow(ctx, query, vaultID, orgID).Scan(&current, &rekey); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrVaultNotExist
+				}
+				return err
+			}
+			rotate := version == current+1
+			if version < 1 || (version != current && !rotate) {
+				return storage.ErrVaultKeyVersion
+			}
+			// the removed member knows the current key, so it is not given to anyone else
+			if rekey && !rotate {
+				return storage.ErrVaultRekeyRequired
+			}
+			members, err := memberIDs(ctx, tx, orgID)
+			if err != nil {
+				return err
+			}
+			given := make(map[int]bool, len(keys))
+			now := time.Now()
+			for _, k := range keys {
+				userID, ok := members[k.Email]
+				if !ok {
+					return storage.ErrMemberNotExist
+				}
+				given[userID] = true
+				query := `insert into vault_keys (vault_id, user_id, version, encrypted_key, created_at) values ($1, $2, $3, $4, $5)
+						  on conflict (vault_id, user_id, version) do update set
+						    encrypted_key = excluded.encrypted_key, created_at = excluded.created_at`
+				if _, err := tx.Exec(ctx, query, vaultID, userID, version, k.EncryptedKey, now); err != nil {
+					return err
+				}
+			}
+			if rotate && len(given) != len(members) {
+				return storage.ErrVaultKeysIncomplete
+			}
+			query = "select " + vaultColumns + " from vaults where id = $1"
+			if rotate {
+				query = "update vaults set key_version = $2, rekey_required = false, updated_at = $3 where id = $1 returning " + vaultColumns
+				res, err = scanVault(tx.QueryRow(ctx, query, vaultID, version, now))
+				return err
+			}
+			res, err = scanVault(tx.QueryRow(ctx, query, vaultID))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// ListVaultKeys returns the vault key versions encrypted to the user in the version order.
+func (s *Storage) ListVaultKeys(ctx context.Context, vaultID int, orgID int, userID int) ([]*models.VaultKey, error) {
+	return retry.DoWithData(func() ([]*models.VaultKey, error) {
+		query := `select ` + vaultKeyColumns + `
+				  from vault_keys k join users u on u.id = k.user_id join vaults v on v.id = k.vault_id
+				  where k.vault_id = $1 and v.org_id = $2 and k.user_id = $3
+				  order by k.version`
+		rows, err := s.db.Query(ctx, query, vaultID, orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		keys := make([]*models.VaultKey, 0)
+		for rows.Next() {
+			k, err := scanVaultKey(rows)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+		}
+		return keys, rows.Err()
+	}, retryOpts()...)
+}
+
+// memberIDs returns the organization members ids by their emails.
+func memberIDs(ctx context.Context, q querier, orgID int) (map[string]int, error) {
+	rows, err := q.Query(ctx, "select u.email, m.user_id from org_members m join users u on u.id = m.user_id where m.org_id = $1", orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := make(map[string]int)
+	for rows.Next() {
+		var email string
+		var id int
+		if err := rows.Scan(&email, &id); err != nil {
+			return nil, err
+		}
+		members[email] = id
+	}
+	return members, rows.Err()
+}
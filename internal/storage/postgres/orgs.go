@@ -0,0 +1,412 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// vaultColumns are the columns scanned by scanVault.
+const vaultColumns = "id, org_id, name, key_version, rekey_required, created_at, updated_at"
+
+// memberColumns are the columns of org_members m joined with users u scanned by scanMember.
+const memberColumns = "m.org_id, m.user_id, u.email, m.role, m.created_at"
+
+func scanVault(row pgx.Row) (*models.Vault, error) {
+	v := &models.Vault{}
+	if err := row.Scan(&v.ID, &v.OrgID, &v.Name, &v.KeyVersion, &v.RekeyRequired, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		if pgErrCode(err) == pgerrcode.UniqueViolation {
+			return nil, storage.ErrVaultAlreadyExists
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func scanMember(row pgx.Row) (*models.Member, error) {
+	m := &models.Member{}
+	if err := row.Scan(&m.OrgID, &m.UserID, &m.Email, &m.Role, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// checkVault reports the not found error if the user is not a member of the vault organization
+// and the forbidden error if the user`s role does not allow adding the vault entities, 0 is no vault.
+func checkVault(ctx context.Context, q querier, vaultID int, userID int) error {
+	if vaultID == 0 {
+		return nil
+	}
+	var role models.Role
+	query := "select m.role from vaults v join org_members m on m.org_id = v.org_id where v.id = $1 and m.user_id = $2"
+	if err := q.QueryRow(ctx, query, vaultID, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrVaultNotExist
+		}
+		return err
+	}
+	if !role.AtLeast(models.RoleEditor) {
+		return storage.ErrForbidden
+	}
+	return nil
+}
+
+// lockRole returns the user`s role in the organization and locks the membership until the transaction ends,
+// so the role can not be changed or revoked while the change it allows is made. It reports the not found error
+// if the user is not a member and the forbidden error if the user`s role is lower than the role.
+func lockRole(ctx context.Context, q querier, orgID int, userID int, role models.Role) (models.Role, error) {
+	var r models.Role
+	query := "select role from org_members where org_id = $1 and user_id = $2 for share"
+	if err := q.QueryRow(ctx, query, orgID, userID).Scan(&r); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrOrgNotExist
+		}
+		return "", err
+	}
+	if !r.AtLeast(role) {
+		return "", storage.ErrForbidden
+	}
+	return r, nil
+}
+
+// lockMember returns the role of the organization member with the email and locks the membership
+// until the transaction ends.
+func lockMember(ctx context.Context, q querier, orgID int, email string) (models.Role, error) {
+	var r models.Role
+	query := "select m.role from org_members m join users u on u.id = m.user_id where m.org_id = $1 and u.email = $2 for update of m"
+	if err := q.QueryRow(ctx, query, orgID, email).Scan(&r); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrMemberNotExist
+		}
+		return "", err
+	}
+	return r, nil
+}
+
+// checkOwners reports the error if the organization is left without an owner.
+func checkOwners(ctx context.Context, q querier, orgID int) error {
+	var exists bool
+	query := "select exists(select 1 from org_members where org_id = $1 and role = $2)"
+	if err := q.QueryRow(ctx, query, orgID, models.RoleOwner).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return storage.ErrLastOwner
+	}
+	return nil
+}
+
+// CreateOrg creates the organization, the user becomes its owner.
+func (s *Storage) CreateOrg(ctx context.Context, o *models.Org, userID int) (*models.Org, error) {
+	return retry.DoWithData(func() (*models.Org, error) {
+		res := &models.Org{Name: o.Name, Role: models.RoleOwner}
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			query := "insert into organizations (name, created_at) values ($1, $2) returning id, created_at"
+			if err := tx.QueryRow(ctx, query, o.Name, time.Now()).Scan(&res.ID, &res.CreatedAt); err != nil {
+				return err
+			}
+			query = "insert into org_members (org_id, user_id, role, created_at) values ($1, $2, $3, $4)"
+			_, err := tx.Exec(ctx, query, res.ID, userID, models.RoleOwner, res.CreatedAt)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// ListOrgs returns the organizations the user is a member of with the user`s role.
+func (s *Storage) ListOrgs(ctx context.Context, userID int) ([]*models.Org, error) {
+	return retry.DoWithData(func() ([]*models.Org, error) {
+		query := `select
+    				o.id, o.name, m.role, o.created_at
+    			  from
+    				organizations o join org_members m on m.org_id = o.id
+				  where
+				    m.user_id = $1
+				  order by o.id`
+		rows, err := s.db.Query(ctx, query, userID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		orgs := make([]*models.Org, 0)
+		for rows.Next() {
+			o := &models.Org{}
+			if err := rows.Scan(&o.ID, &o.Name, &o.Role, &o.CreatedAt); err != nil {
+				return nil, err
+			}
+			orgs = append(orgs, o)
+		}
+		return orgs, rows.Err()
+	}, retryOpts()...)
+}
+
+// DeleteOrg deletes the organization with its members and vaults, the vaults must have no entities.
+// The user has to be the owner.
+func (s *Storage) DeleteOrg(ctx context.Context, id int, userID int) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, id, userID, models.RoleOwner); err != nil {
+				return err
+			}
+			var exists bool
+			query := "select exists(select 1 from entities where vault_id in (select id from vaults where org_id = $1))"
+			if err := tx.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+				return err
+			}
+			if exists {
+				return storage.ErrOrgNotEmpty
+			}
+			tag, err := tx.Exec(ctx, "delete from organizations where id = $1", id)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return storage.ErrOrgNotExist
+			}
+			return nil
+		})
+	}, retryOpts()...)
+}
+
+// MemberRole returns the user`s role in the organization.
+func (s *Storage) MemberRole(ctx context.Context, orgID int, userID int) (models.Role, error) {
+	return retry.DoWithData(func() (models.Role, error) {
+		var role models.Role
+		query := "select role from org_members where org_id = $1 and user_id = $2"
+		if err := s.db.QueryRow(ctx, query, orgID, userID).Scan(&role); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", storage.ErrOrgNotExist
+			}
+			return "", err
+		}
+		return role, nil
+	}, retryOpts()...)
+}
+
+// AddMember adds the registered user with the member email to the organization,
+// the user has to manage the members of the role.
+func (s *Storage) AddMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	return retry.DoWithData(func() (*models.Member, error) {
+		res := *m
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, m.OrgID, userID, m.Role.ManagedBy()); err != nil {
+				return err
+			}
+			err := tx.QueryRow(ctx, "select id from users where email = $1", m.Email).Scan(&res.UserID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrUserNotExist
+				}
+				return err
+			}
+			query := `insert into org_members (org_id, user_id, role, created_at) values ($1, $2, $3, $4)
+					  on conflict (org_id, user_id) do nothing
+					  returning created_at`
+			err = tx.QueryRow(ctx, query, m.OrgID, res.UserID, m.Role, time.Now()).Scan(&res.CreatedAt)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrMemberAlreadyExists
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &res, nil
+	}, retryOpts()...)
+}
+
+// UpdateMember changes the role of the organization member with the email, the organization keeps an owner.
+// The user has to manage the members of both the current and the new role.
+func (s *Storage) UpdateMember(ctx context.Context, m *models.Member, userID int) (*models.Member, error) {
+	return retry.DoWithData(func() (*models.Member, error) {
+		var res *models.Member
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			role, err := lockRole(ctx, tx, m.OrgID, userID, models.RoleAdmin)
+			if err != nil {
+				return err
+			}
+			cur, err := lockMember(ctx, tx, m.OrgID, m.Email)
+			if err != nil {
+				return err
+			}
+			if !role.AtLeast(cur.ManagedBy()) || !role.AtLeast(m.Role.ManagedBy()) {
+				return storage.ErrForbidden
+			}
+			query := `update org_members m set role = $3 from users u
+					  where u.id = m.user_id and m.org_id = $1 and u.email = $2
+					  returning ` + memberColumns
+			res, err = scanMember(tx.QueryRow(ctx, query, m.OrgID, m.Email, m.Role))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrMemberNotExist
+				}
+				return err
+			}
+			return checkOwners(ctx, tx, m.OrgID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// RemoveMember removes the member with the email from the organization, the organization keeps an owner.
+// The user has to manage the members of the member`s role.
+// The member`s vault keys are deleted and the vaults with distributed keys are flagged for re-keying.
+func (s *Storage) RemoveMember(ctx context.Context, orgID int, email string, userID int) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			role, err := lockRole(ctx, tx, orgID, userID, models.RoleAdmin)
+			if err != nil {
+				return err
+			}
+			cur, err := lockMember(ctx, tx, orgID, email)
+			if err != nil {
+				return err
+			}
+			if !role.AtLeast(cur.ManagedBy()) {
+				return storage.ErrForbidden
+			}
+			var memberID int
+			query := "delete from org_members where org_id = $1 and user_id = (select id from users where email = $2) returning user_id"
+			if err := tx.QueryRow(ctx, query, orgID, email).Scan(&memberID); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrMemberNotExist
+				}
+				return err
+			}
+			if err := checkOwners(ctx, tx, orgID); err != nil {
+				return err
+			}
+			query = "delete from vault_keys where user_id = $1 and vault_id in (select id from vaults where org_id = $2)"
+			if _, err := tx.Exec(ctx, query, memberID, orgID); err != nil {
+				return err
+			}
+			_, err = tx.Exec(ctx, "update vaults set rekey_required = true where org_id = $1 and key_version > 0", orgID)
+			return err
+		})
+	}, retryOpts()...)
+}
+
+// ListMembers returns the organization members in the order they joined.
+func (s *Storage) ListMembers(ctx context.Context, orgID int) ([]*models.Member, error) {
+	return retry.DoWithData(func() ([]*models.Member, error) {
+		query := "select " + memberColumns + " from org_members m join users u on u.id = m.user_id where m.org_id = $1 order by m.created_at, u.email"
+		rows, err := s.db.Query(ctx, query, orgID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		members := make([]*models.Member, 0)
+		for rows.Next() {
+			m, err := scanMember(rows)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, m)
+		}
+		return members, rows.Err()
+	}, retryOpts()...)
+}
+
+// CreateVault creates the organization vault, the vault name is unique in the organization.
+// The user has to be an admin or an owner.
+func (s *Storage) CreateVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	return retry.DoWithData(func() (*models.Vault, error) {
+		var res *models.Vault
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, v.OrgID, userID, models.RoleAdmin); err != nil {
+				return err
+			}
+			query := "insert into vaults (org_id, name, created_at, updated_at) values ($1, $2, $3, $3) returning " + vaultColumns
+			var err error
+			res, err = scanVault(tx.QueryRow(ctx, query, v.OrgID, v.Name, time.Now()))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// RenameVault renames the organization vault, the user has to be an admin or an owner.
+func (s *Storage) RenameVault(ctx context.Context, v *models.Vault, userID int) (*models.Vault, error) {
+	return retry.DoWithData(func() (*models.Vault, error) {
+		var res *models.Vault
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, v.OrgID, userID, models.RoleAdmin); err != nil {
+				return err
+			}
+			query := "update vaults set name = $3, updated_at = $4 where id = $1 and org_id = $2 returning " + vaultColumns
+			var err error
+			res, err = scanVault(tx.QueryRow(ctx, query, v.ID, v.OrgID, v.Name, time.Now()))
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrVaultNotExist
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// DeleteVault deletes the organization vault, the vault must have no entities including the trashed ones.
+// The user has to be an admin or an owner.
+func (s *Storage) DeleteVault(ctx context.Context, id int, orgID int, userID int) error {
+	return retry.Do(func() error {
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if _, err := lockRole(ctx, tx, orgID, userID, models.RoleAdmin); err != nil {
+				return err
+			}
+			var exists bool
+			if err := tx.QueryRow(ctx, "select exists(select 1 from entities where vault_id = $1)", id).Scan(&exists); err != nil {
+				return err
+			}
+			if exists {
+				return storage.ErrVaultNotEmpty
+			}
+			tag, err := tx.Exec(ctx, "delete from vaults where id = $1 and org_id = $2", id, orgID)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return storage.ErrVaultNotExist
+			}
+			return nil
+		})
+	}, retryOpts()...)
+}
+
+// ListVaults returns the organization vaults sorted by name.
+func (s *Storage) ListVaults(ctx context.Context, orgID int) ([]*models.Vault, error) {
+	return retry.DoWithData(func() ([]*models.Vault, error) {
+		rows, err := s.db.Query(ctx, "select "+vaultColumns+" from vaults where org_id = $1 order by name", orgID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		vaults := make([]*models.Vault, 0)
+		for rows.Next() {
+			v, err := scanVault(rows)
+			if err != nil {
+				return nil, err
+			}
+			vaults = append(vaults, v)
+		}
+		return vaults, rows.Err()
+	}, retryOpts()...)
+}
@@ -3,8 +3,12 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/vindosVP/go-pass/internal/labels"
 	"github.com/vindosVP/go-pass/internal/models"
 	"github.com/vindosVP/go-pass/internal/storage"
 	"github.com/vindosVP/go-pass/pkg/logger/sl"
@@ -67,44 +72,141 @@ func (s *Storage) UserByEmail(ctx context.Context, email string) (*models.User,
 	}, retryOpts()...)
 }
 
-// AddPassword adds a new login-password pair
-func (s *Storage) AddPassword(ctx context.Context, pwd *models.Password) (int, error) {
-	return retry.DoWithData(func() (int, error) {
-		query := `insert into passwords (owner_id, login, password, metadata, created_at) 
-					values ($1, $2, $3, $4, $5) returning id`
-		row := s.db.QueryRow(ctx, query, pwd.OwnerID, pwd.Login, pwd.Password, pwd.Metadata, time.Now())
-		var id int
-		err := row.Scan(&id)
-		if err != nil {
-			return 0, err
+// payloadKeys binds the entity fields to the keys of the typed payload kept in the data column.
+// The archived versions keep the metadata in the payload as well.
+var payloadKeys = map[models.EntityField]string{
+	models.FieldLogin:      "login",
+	models.FieldPassword:   "password",
+	models.FieldCardNumber: "number",
+	models.FieldCardOwner:  "owner",
+	models.FieldCardCVC:    "cvc",
+	models.FieldCardExp:    "date",
+	models.FieldText:       "text",
+	models.FieldFilename:   "filename",
+	models.FieldMetadata:   "metadata",
+	models.FieldCustom:     "custom_fields",
+}
+
+// entityColumns are the columns scanned by scanEntity.
+const entityColumns = `type, id, owner_id, data, metadata, created_at, updated_at, revision, deleted_at, coalesce(folder_id, 0), labels,
+    				array(select tag_id from entity_tags where entity_type = entities.type and entity_id = entities.id order by tag_id),
+    				coalesce(vault_id, 0), requires_approval, pruned_revision`
+
+// notFound returns the not found error of the entity type.
+func notFound(t models.EntityType) *storage.Error {
+	return &storage.Error{Kind: storage.KindNotFound, Resource: strings.ToLower(string(t))}
+}
+
+// encodePayload returns the typed payload of the entity.
+func encodePayload(e *models.Entity) (map[string]any, error) {
+	fields, ok := models.PayloadFields[e.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %s", e.Type)
+	}
+	data := make(map[string]any, len(fields)+1)
+	for _, f := range fields {
+		if f == models.FieldCustom {
+			data[payloadKeys[f]] = customArg(e.Custom)
+			continue
 		}
-		return id, nil
-	}, retryOpts()...)
+		data[payloadKeys[f]] = e.Field(f)
+	}
+	return data, nil
 }
 
-// AddCard adds a new bank card
-func (s *Storage) AddCard(ctx context.Context, card *models.Card) (int, error) {
-	return retry.DoWithData(func() (int, error) {
-		query := `insert into cards (owner_id, number, cvc, owner, date, metadata, created_at) 
-					values ($1, $2, $3, $4, $5, $6, $7) returning id`
-		row := s.db.QueryRow(ctx, query, card.OwnerID, card.Number, card.CVC, card.Owner, card.Date, card.Metadata, time.Now())
-		var id int
-		err := row.Scan(&id)
+// decodePayload sets the entity fields from the typed payload, the keys of other types are ignored.
+func decodePayload(data []byte, e *models.Entity) error {
+	payload := make(map[string]any)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	for _, f := range append([]models.EntityField{models.FieldMetadata}, models.PayloadFields[e.Type]...) {
+		if v, ok := payload[payloadKeys[f]].(string); ok {
+			e.SetField(f, v)
+		}
+	}
+	if custom, ok := payload[payloadKeys[models.FieldCustom]]; ok && slices.Contains(models.PayloadFields[e.Type], models.FieldCustom) {
+		// re-decode the raw value to keep the field order and types
+		raw, err := json.Marshal(custom)
 		if err != nil {
-			return 0, err
+			return err
 		}
-		return id, nil
-	}, retryOpts()...)
+		e.Custom = nil
+		if err := json.Unmarshal(raw, &e.Custom); err != nil {
+			return err
+		}
+		if len(e.Custom) == 0 {
+			e.Custom = nil
+		}
+	}
+	return nil
+}
+
+// customArg returns the custom fields stored in the payload, the empty list instead of nil.
+func customArg(fields []models.CustomField) []models.CustomField {
+	if fields == nil {
+		return []models.CustomField{}
+	}
+	return fields
 }
 
-// AddText adds a new text
-func (s *Storage) AddText(ctx context.Context, t *models.Text) (int, error) {
+// scanEntity scans the entity row selected as entityColumns.
+// The extra destinations are scanned from the columns selected after the entity columns.
+func scanEntity(row pgx.Row, extra ...any) (*models.Entity, error) {
+	e := &models.Entity{}
+	var data []byte
+	var deletedAt *time.Time
+	var tagIDs []int64
+	dest := []any{&e.Type, &e.ID, &e.OwnerID, &data, &e.Metadata, &e.CreatedAt, &e.UpdatedAt, &e.Revision, &deletedAt,
+		&e.FolderID, &e.Labels, &tagIDs, &e.VaultID, &e.RequiresApproval, &e.PrunedRevision}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	if deletedAt != nil {
+		e.DeletedAt = *deletedAt
+	}
+	for _, id := range tagIDs {
+		e.TagIDs = append(e.TagIDs, int(id))
+	}
+	if len(e.Labels) == 0 {
+		e.Labels = nil
+	}
+	if err := decodePayload(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// AddEntity adds a new entity of any type into the entity folder with the entity tags.
+// The entity is added into the organization vault if its vault is set, the owner has to be the vault editor.
+// The file is not listed until it is marked as uploaded.
+func (s *Storage) AddEntity(ctx context.Context, e *models.Entity) (int, error) {
+	data, err := encodePayload(e)
+	if err != nil {
+		return 0, err
+	}
+	if e.Type == models.TypeFile {
+		data["uploaded"] = false
+	}
 	return retry.DoWithData(func() (int, error) {
-		query := `insert into texts (owner_id, text, metadata, created_at) 
-					values ($1, $2, $3, $4) returning id`
-		row := s.db.QueryRow(ctx, query, t.OwnerID, t.Text, t.Metadata, time.Now())
 		var id int
-		err := row.Scan(&id)
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if err := checkFolder(ctx, tx, e.FolderID, e.OwnerID); err != nil {
+				return err
+			}
+			if err := checkVault(ctx, tx, e.VaultID, e.OwnerID); err != nil {
+				return err
+			}
+			query := `insert into entities (type, owner_id, data, metadata, created_at, updated_at, folder_id, labels, vault_id) 
+						values ($1, $2, $3, $4, $5, $5, nullif($6, 0), $7, nullif($8, 0)) returning id`
+			row := tx.QueryRow(ctx, query, e.Type, e.OwnerID, data, e.Metadata, time.Now(), e.FolderID, labelsArg(e.Labels),
+				e.VaultID)
+			if err := row.Scan(&id); err != nil {
+				return err
+			}
+			return setEntityTags(ctx, tx, e.Type, id, e.OwnerID, e.TagIDs)
+		})
 		if err != nil {
 			return 0, err
 		}
@@ -112,277 +214,570 @@ func (s *Storage) AddText(ctx context.Context, t *models.Text) (int, error) {
 	}, retryOpts()...)
 }
 
-// AddFile adds a new file
-func (s *Storage) AddFile(ctx context.Context, f *models.File) (int, error) {
-	return retry.DoWithData(func() (int, error) {
-		query := `insert into files (owner_id, filename, metadata, created_at) 
-					values ($1, $2, $3, $4) returning id`
-		row := s.db.QueryRow(ctx, query, f.OwnerID, f.FileName, f.Metadata, time.Now())
-		var id int
-		err := row.Scan(&id)
+// GetEntity returns the entity owned by the user or shared with them.
+func (s *Storage) GetEntity(ctx context.Context, t models.EntityType, id int, ownerID int) (*models.Entity, error) {
+	return retry.DoWithData(func() (*models.Entity, error) {
+		query := `select
+    				` + entityColumns + `, ` + sharedColumns("$3") + `
+    			  from  
+    				entities 
+				  where
+				    type = $1 and id = $2 and ` + accessCondition("$3", models.PermissionRead) + ` and deleted_at is null`
+		e, err := scanSharedEntity(s.db.QueryRow(ctx, query, t, id, ownerID))
 		if err != nil {
-			return 0, err
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, notFound(t)
+			}
+			return nil, err
 		}
-		return id, nil
+		return e, nil
 	}, retryOpts()...)
 }
 
-// UpdatePassword updates the password
-func (s *Storage) UpdatePassword(ctx context.Context, pwd *models.Password) error {
+// UpdateEntity updates the entity fields, all payload fields and the metadata if none provided,
+// and archives the prior version in the same transaction unless only the folder and the tags are updated.
+// If e.Revision is set, the entity is updated only if it has not been changed since that revision.
+// The content of the entity shared with the write permission is updated by e.OwnerID as well,
+// the folder, the tags and the labels are updated by the owner and the vault editors only.
+func (s *Storage) UpdateEntity(ctx context.Context, e *models.Entity, fields []models.EntityField) error {
+	query, args, err := updateQuery(e, fields)
+	if err != nil {
+		return err
+	}
+	organizeOnly := len(fields) > 0
+	for _, f := range fields {
+		organizeOnly = organizeOnly && slices.Contains(models.OrganizeFields, f)
+	}
 	return retry.Do(func() error {
-		query := `update 
-    				passwords 
-				  set 
-					login=$1, 
-					password=$2, 
-					metadata=$3
-				  where
-				    id = $4 and owner_id = $5`
-		_, err := s.db.Exec(ctx, query, pwd.Login, pwd.Password, pwd.Metadata, pwd.ID, pwd.OwnerID)
-		if err != nil {
-			return err
+		return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			if slices.Contains(fields, models.FieldFolder) {
+				if err := checkFolder(ctx, tx, e.FolderID, e.OwnerID); err != nil {
+					return err
+				}
+			}
+			if !organizeOnly {
+				if err := archiveEntity(ctx, tx, e, updateAccess(fields)); err != nil {
+					return err
+				}
+			}
+			return execUpdate(ctx, tx, e, fields, query, args)
+		})
+	}, retryOpts()...)
+}
+
+// archiveEntity copies the current entity version to entity_versions and locks the entity row.
+func archiveEntity(ctx context.Context, tx pgx.Tx, e *models.Entity, p models.Permission) error {
+	archive := `insert into entity_versions 
+    				(type, entity_id, owner_id, revision, data, updated_at, archived_at)
+				  select 
+				    type, id, owner_id, revision, data || jsonb_build_object('metadata', metadata), updated_at, $4 
+				  from 
+				    entities 
+				  where 
+				    type = $1 and id = $2 and ` + accessCondition("$3", p) + ` and deleted_at is null
+				  for update`
+	_, err := tx.Exec(ctx, archive, e.Type, e.ID, e.OwnerID, time.Now())
+	return err
+}
+
+// execUpdate runs the entity update statement and replaces the entity tags if they are updated.
+func execUpdate(ctx context.Context, tx pgx.Tx, e *models.Entity, fields []models.EntityField, query string, args []any) error {
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(ctx, tx, tag, e.Type, e.ID, e.OwnerID, e.Revision, updateAccess(fields)); err != nil {
+		return err
+	}
+	if !slices.Contains(fields, models.FieldTags) {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, "delete from entity_tags where entity_type = $1 and entity_id = $2", e.Type, e.ID); err != nil {
+		return err
+	}
+	return setEntityTags(ctx, tx, e.Type, e.ID, e.OwnerID, e.TagIDs)
+}
+
+// checkFolder reports the not found error if the folder does not belong to the owner, 0 is the top level.
+func checkFolder(ctx context.Context, q querier, folderID int, ownerID int) error {
+	if folderID == 0 {
+		return nil
+	}
+	var exists bool
+	query := "select exists(select 1 from folders where id = $1 and owner_id = $2)"
+	if err := q.QueryRow(ctx, query, folderID, ownerID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return storage.ErrFolderNotExist
+	}
+	return nil
+}
+
+// labelsArg returns the labels to store, the missing labels are stored as the empty object.
+func labelsArg(l map[string]string) map[string]string {
+	if l == nil {
+		return map[string]string{}
+	}
+	return l
+}
+
+// labelCondition translates the label selector requirement to the condition on the labels column.
+// As in the selector semantics != and notin match the entities without the label.
+func labelCondition(r labels.Requirement, arg func(v any) string) string {
+	switch r.Op {
+	case labels.Equals:
+		return "labels @> jsonb_build_object(" + arg(r.Key) + "::text, " + arg(r.Values[0]) + "::text)"
+	case labels.NotEquals:
+		return "not labels @> jsonb_build_object(" + arg(r.Key) + "::text, " + arg(r.Values[0]) + "::text)"
+	case labels.In:
+		return "labels->>" + arg(r.Key) + "::text = any(" + arg(r.Values) + ")"
+	case labels.NotIn:
+		return "coalesce(labels->>" + arg(r.Key) + "::text <> all(" + arg(r.Values) + "), true)"
+	case labels.DoesNotExist:
+		return "not labels ? " + arg(r.Key)
+	default:
+		return "labels ? " + arg(r.Key)
+	}
+}
+
+// uniqueIDs returns the sorted ids without duplicates.
+func uniqueIDs(ids []int) []int {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// setEntityTags tags the entity with the owner`s tags, the not found error is reported
+// if any of the tags does not belong to the owner.
+func setEntityTags(ctx context.Context, q querier, t models.EntityType, id int, ownerID int, tagIDs []int) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	query := `insert into entity_tags (entity_type, entity_id, tag_id)
+				select $1, $2, id from tags where owner_id = $3 and id = any($4)`
+	tag, err := q.Exec(ctx, query, t, id, ownerID, tagIDs)
+	if err != nil {
+		return err
+	}
+	unique := uniqueIDs(tagIDs)
+	if tag.RowsAffected() != int64(len(unique)) {
+		return storage.ErrTagNotExist
+	}
+	return nil
+}
+
+// updateQuery builds the update statement merging only the payload keys of the provided fields
+// into the entity data, all fields of the entity type if none provided, and incrementing the revision.
+// The statement checks the current revision if it is provided.
+func updateQuery(e *models.Entity, fields []models.EntityField) (string, []any, error) {
+	typeFields, ok := models.PayloadFields[e.Type]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown entity type %s", e.Type)
+	}
+	if len(fields) == 0 {
+		fields = append(slices.Clone(typeFields), models.FieldMetadata)
+	}
+
+	set := make([]string, 0, 4)
+	payload := make([]string, 0, len(fields))
+	args := make([]any, 0, len(fields)+5)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	for _, f := range fields {
+		switch {
+		case f == models.FieldMetadata:
+			set = append(set, "metadata="+arg(e.Metadata))
+		case f == models.FieldFolder:
+			set = append(set, "folder_id=nullif("+arg(e.FolderID)+"::integer, 0)")
+		case f == models.FieldLabels:
+			set = append(set, "labels="+arg(labelsArg(e.Labels)))
+		case f == models.FieldTags:
+			// the tags are kept in entity_tags and replaced by UpdateEntity
+			continue
+		case f == models.FieldCustom && slices.Contains(typeFields, f):
+			raw, err := json.Marshal(customArg(e.Custom))
+			if err != nil {
+				return "", nil, err
+			}
+			payload = append(payload, fmt.Sprintf("'%s', %s::jsonb", payloadKeys[f], arg(string(raw))))
+		case slices.Contains(typeFields, f):
+			payload = append(payload, fmt.Sprintf("'%s', %s::text", payloadKeys[f], arg(e.Field(f))))
+		default:
+			return "", nil, fmt.Errorf("unknown %s field %q", e.Type, f)
 		}
+	}
+	if len(payload) > 0 {
+		set = append(set, "data=data || jsonb_build_object("+strings.Join(payload, ", ")+")")
+	}
+	set = append(set, "updated_at="+arg(time.Now()), "revision=revision+1")
+
+	query := fmt.Sprintf("update entities set %s where type = %s and id = %s and %s and deleted_at is null",
+		strings.Join(set, ", "), arg(e.Type), arg(e.ID), accessCondition(arg(e.OwnerID), updateAccess(fields)))
+	if e.Revision > 0 {
+		query += " and revision = " + arg(e.Revision)
+	}
+	return query, args, nil
+}
+
+// updateAccess returns the permission required to update the fields, only the owner organizes the entity.
+func updateAccess(fields []models.EntityField) models.Permission {
+	for _, f := range fields {
+		if slices.Contains(models.OrganizeFields, f) {
+			return ""
+		}
+	}
+	return models.PermissionWrite
+}
+
+// querier is the statements API common for the pool and the transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// checkAffected reports the not found error if the mutating statement has not affected any rows,
+// or the revision mismatch error if the entity the user has the permission to exists but its revision differs
+// from the expected one.
+func checkAffected(ctx context.Context, q querier, tag pgconn.CommandTag, t models.EntityType, id int, ownerID int,
+	revision int, p models.Permission) error {
+	if tag.RowsAffected() > 0 {
 		return nil
-	}, retryOpts()...)
+	}
+	nf := notFound(t)
+	if revision > 0 {
+		var exists bool
+		query := "select exists(select 1 from entities where type = $1 and id = $2 and " + accessCondition("$3", p) +
+			" and deleted_at is null)"
+		if err := q.QueryRow(ctx, query, t, id, ownerID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return &storage.Error{Kind: storage.KindConflict, Resource: nf.Resource, Reason: storage.ErrRevisionMismatch.Reason}
+		}
+	}
+	return nf
 }
 
-// UpdateCard updates the bank card
-func (s *Storage) UpdateCard(ctx context.Context, card *models.Card) error {
-	return retry.Do(func() error {
-		query := `update 
-    				cards 
-				  set 
-					number=$1, 
-					cvc=$2, 
-					owner=$3,
-					date=$4,
-					metadata=$5
+// versionAccess is the condition selecting the versions of the entity the user can restore.
+var versionAccess = "exists(select 1 from entities where type = $1 and id = $2 and " + accessCondition("$3", "") + ")"
+
+// ListVersions returns the archived versions of the entity, the latest first.
+func (s *Storage) ListVersions(ctx context.Context, t models.EntityType, id int, ownerID int) ([]*models.Version, error) {
+	return retry.DoWithData(func() ([]*models.Version, error) {
+		query := `select
+    				revision, data, updated_at, archived_at
+    			  from 
+    				entity_versions 
 				  where
-				    id = $6 and owner_id = $7`
-		_, err := s.db.Exec(ctx, query, card.Number, card.CVC, card.Owner, card.Date, card.Metadata, card.ID, card.OwnerID)
+				    type = $1 and entity_id = $2 and ` + versionAccess + `
+				  order by revision desc`
+		rows, err := s.db.Query(ctx, query, t, id, ownerID)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		return nil
+		defer rows.Close()
+		versions := make([]*models.Version, 0)
+		for rows.Next() {
+			v, err := scanVersion(rows, t, id, ownerID)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, v)
+		}
+		return versions, rows.Err()
 	}, retryOpts()...)
 }
 
-// UpdateText updates the text
-func (s *Storage) UpdateText(ctx context.Context, t *models.Text) error {
-	return retry.Do(func() error {
-		query := `update 
-    				texts 
-				  set 
-					text=$1, 
-					metadata=$2
+// GetVersion returns the archived entity version with the provided revision.
+func (s *Storage) GetVersion(ctx context.Context, t models.EntityType, id int, ownerID int, revision int) (*models.Version, error) {
+	return retry.DoWithData(func() (*models.Version, error) {
+		query := `select
+    				revision, data, updated_at, archived_at
+    			  from 
+    				entity_versions 
 				  where
-				    id = $3 and owner_id = $4`
-		_, err := s.db.Exec(ctx, query, t.Text, t.Metadata, t.ID, t.OwnerID)
+				    type = $1 and entity_id = $2 and ` + versionAccess + ` and revision = $4`
+		v, err := scanVersion(s.db.QueryRow(ctx, query, t, id, ownerID, revision), t, id, ownerID)
 		if err != nil {
-			return err
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrVersionNotExist
+			}
+			return nil, err
 		}
-		return nil
+		return v, nil
 	}, retryOpts()...)
 }
 
-// DeletePassword deletes the password
-func (s *Storage) DeletePassword(ctx context.Context, id int, ownerID int) error {
-	return retry.Do(func() error {
-		query := `delete from  
-    				passwords 
-				  where
-				    id = $1 and owner_id = $2`
-		_, err := s.db.Exec(ctx, query, id, ownerID)
-		if err != nil {
-			return err
+// DeleteOldVersions deletes all but the keep latest versions of every entity
+// and returns the number of deleted versions. The latest pruned revision is saved with the entity.
+func (s *Storage) DeleteOldVersions(ctx context.Context, keep int) (int64, error) {
+	return retry.DoWithData(func() (int64, error) {
+		query := `with deleted as (
+				    delete from 
+    				  entity_versions 
+				    where id in (
+				      select id from (
+				        select id, row_number() over (partition by type, entity_id order by revision desc) as n
+				        from entity_versions
+				      ) v 
+				      where n > $1
+				    )
+				    returning type, entity_id, revision
+				  ), pruned as (
+				    update entities e
+				    set pruned_revision = greatest(e.pruned_revision, d.revision)
+				    from (select type, entity_id, max(revision) as revision from deleted group by type, entity_id) d
+				    where e.type = d.type and e.id = d.entity_id
+				  )
+				  select count(*) from deleted`
+		var n int64
+		if err := s.db.QueryRow(ctx, query, keep).Scan(&n); err != nil {
+			return 0, err
 		}
-		return nil
+		return n, nil
 	}, retryOpts()...)
 }
 
-// DeleteCard deletes the bank card
-func (s *Storage) DeleteCard(ctx context.Context, id int, ownerID int) error {
+// scanVersion scans the version row and decodes the archived entity data.
+func scanVersion(row pgx.Row, t models.EntityType, id int, ownerID int) (*models.Version, error) {
+	var data []byte
+	v := &models.Version{}
+	e := &models.Entity{ID: id, OwnerID: ownerID, Type: t}
+	if err := row.Scan(&e.Revision, &data, &e.UpdatedAt, &v.ArchivedAt); err != nil {
+		return nil, err
+	}
+	if err := decodePayload(data, e); err != nil {
+		return nil, err
+	}
+	v.Entity = e
+	return v, nil
+}
+
+// DeleteEntity moves the entity to the trash.
+// If revision is set, the entity is deleted only if it has not been changed since that revision.
+func (s *Storage) DeleteEntity(ctx context.Context, t models.EntityType, id int, ownerID int, revision int) error {
 	return retry.Do(func() error {
-		query := `delete from  
-    				cards 
+		query := `update entities set 
+    				deleted_at=$5
 				  where
-				    id = $1 and owner_id = $2`
-		_, err := s.db.Exec(ctx, query, id, ownerID)
+				    type = $1 and id = $2 and ` + accessCondition("$3", "") + ` and deleted_at is null and ($4 = 0 or revision = $4)`
+		tag, err := s.db.Exec(ctx, query, t, id, ownerID, revision, time.Now())
 		if err != nil {
 			return err
 		}
-		return nil
+		return checkAffected(ctx, s.db, tag, t, id, ownerID, revision, "")
 	}, retryOpts()...)
 }
 
-// DeleteText deletes the text
-func (s *Storage) DeleteText(ctx context.Context, id int, ownerID int) error {
+// RestoreEntity moves the entity back from the trash.
+func (s *Storage) RestoreEntity(ctx context.Context, t models.EntityType, id int, ownerID int) error {
 	return retry.Do(func() error {
-		query := `delete from  
-    				texts 
+		query := `update entities set 
+    				deleted_at=null
 				  where
-				    id = $1 and owner_id = $2`
-		_, err := s.db.Exec(ctx, query, id, ownerID)
+				    type = $1 and id = $2 and ` + accessCondition("$3", "") + ` and deleted_at is not null`
+		tag, err := s.db.Exec(ctx, query, t, id, ownerID)
 		if err != nil {
 			return err
 		}
+		if tag.RowsAffected() == 0 {
+			return notFound(t)
+		}
 		return nil
 	}, retryOpts()...)
 }
 
-// DeleteFile deletes the file
-func (s *Storage) DeleteFile(ctx context.Context, id int, ownerID int) error {
-	return retry.Do(func() error {
-		query := `delete from  
-    				files 
-				  where
-				    id = $1 and owner_id = $2`
-		_, err := s.db.Exec(ctx, query, id, ownerID)
+// purgeQuery deletes the entities matching the condition together with their versions
+// and selects the id, owner id, type and the file name of the deleted entities.
+const purgeQuery = `with purged as (
+					  delete from entities where %s returning type, id, owner_id, data
+					), versions as (
+					  delete from entity_versions v using purged p where v.type = p.type and v.entity_id = p.id
+					)
+					select type, id, owner_id, coalesce(data->>'filename', '') from purged`
+
+// PurgeEntity permanently deletes the entity in the trash and its versions.
+// The returned entity has only the id, owner id, type and the file name set.
+func (s *Storage) PurgeEntity(ctx context.Context, t models.EntityType, id int, ownerID int) (*models.Entity, error) {
+	return retry.DoWithData(func() (*models.Entity, error) {
+		query := fmt.Sprintf(purgeQuery, "type = $1 and id = $2 and "+accessCondition("$3", "")+" and deleted_at is not null")
+		e := &models.Entity{}
+		err := s.db.QueryRow(ctx, query, t, id, ownerID).Scan(&e.Type, &e.ID, &e.OwnerID, &e.Filename)
 		if err != nil {
-			return err
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, notFound(t)
+			}
+			return nil, err
 		}
-		return nil
+		return e, nil
 	}, retryOpts()...)
 }
 
-// GetPasswords returns all passwords
-func (s *Storage) GetPasswords(ctx context.Context, ownerID int) ([]*models.Password, error) {
-	return retry.DoWithData(func() ([]*models.Password, error) {
-		query := `select
-    				id, owner_id, login, password, metadata, created_at
-    			  from  
-    				passwords 
-				  where
-				    owner_id = $1`
-		rows, err := s.db.Query(ctx, query, ownerID)
+// PurgeTrash permanently deletes the entities moved to the trash before the provided time and their versions.
+// The returned entities have only the id, owner id, type and the file name set.
+func (s *Storage) PurgeTrash(ctx context.Context, before time.Time) ([]*models.Entity, error) {
+	return retry.DoWithData(func() ([]*models.Entity, error) {
+		rows, err := s.db.Query(ctx, fmt.Sprintf(purgeQuery, "deleted_at < $1"), before)
 		if err != nil {
 			return nil, err
 		}
 		defer rows.Close()
-		pwds := make([]*models.Password, 0)
+		purged := make([]*models.Entity, 0)
 		for rows.Next() {
-			pwd := &models.Password{}
-			err = rows.Scan(&pwd.ID, &pwd.OwnerID, &pwd.Login, &pwd.Password, &pwd.Metadata, &pwd.CreatedAt)
-			if err != nil {
+			e := &models.Entity{}
+			if err := rows.Scan(&e.Type, &e.ID, &e.OwnerID, &e.Filename); err != nil {
 				return nil, err
 			}
-			pwds = append(pwds, pwd)
+			purged = append(purged, e)
 		}
-		return pwds, nil
+		return purged, rows.Err()
 	}, retryOpts()...)
 }
 
-// GetCards returns all bank cards
-func (s *Storage) GetCards(ctx context.Context, ownerID int) ([]*models.Card, error) {
-	return retry.DoWithData(func() ([]*models.Card, error) {
-		query := `select
-    				id, owner_id, number, cvc, owner, date, metadata, created_at
-    			  from  
-    				cards 
-				  where
-				    owner_id = $1`
-		rows, err := s.db.Query(ctx, query, ownerID)
+// ListEntities returns entities of all requested types matching the list options.
+func (s *Storage) ListEntities(ctx context.Context, ownerID int, opts models.ListOptions) ([]*models.Entity, error) {
+	return retry.DoWithData(func() ([]*models.Entity, error) {
+		entities := make([]*models.Entity, 0)
+		err := s.IterateEntities(ctx, ownerID, opts, func(e *models.Entity) error {
+			entities = append(entities, e)
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
-		defer rows.Close()
-		cards := make([]*models.Card, 0)
-		for rows.Next() {
-			c := &models.Card{}
-			err = rows.Scan(&c.ID, &c.OwnerID, &c.Number, &c.CVC, &c.Owner, &c.Date, &c.Metadata, &c.CreatedAt)
-			if err != nil {
-				return nil, err
-			}
-			cards = append(cards, c)
-		}
-		return cards, nil
+		return entities, nil
 	}, retryOpts()...)
 }
 
-// GetTexts returns all texts
-func (s *Storage) GetTexts(ctx context.Context, ownerID int) ([]*models.Text, error) {
-	return retry.DoWithData(func() ([]*models.Text, error) {
-		query := `select
-    				id, owner_id, text, metadata, created_at
-    			  from  
-    				texts 
-				  where
-				    owner_id = $1`
-		rows, err := s.db.Query(ctx, query, ownerID)
+// IterateEntities calls fn for each of entities matching the list options as the rows are read.
+// Iteration stops on the first fn error, which is returned.
+func (s *Storage) IterateEntities(ctx context.Context, ownerID int, opts models.ListOptions, fn func(e *models.Entity) error) error {
+	query, args := entitiesQuery(ownerID, opts)
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e, err := scanSharedEntity(rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		defer rows.Close()
-		texts := make([]*models.Text, 0)
-		for rows.Next() {
-			t := &models.Text{}
-			err = rows.Scan(&t.ID, &t.OwnerID, &t.Text, &t.Metadata, &t.CreatedAt)
-			if err != nil {
-				return nil, err
-			}
-			texts = append(texts, t)
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// MarkFileAsUploaded marks the file as uploaded.
+func (s *Storage) MarkFileAsUploaded(ctx context.Context, id int, ownerID int) error {
+	return retry.Do(func() error {
+		query := `update entities set 
+    				data=data || '{"uploaded": true}',
+    				updated_at=$1
+				  where
+				    type = $2 and id = $3 and ` + accessCondition("$4", "") + ` and deleted_at is null`
+		tag, err := s.db.Exec(ctx, query, time.Now(), models.TypeFile, id, ownerID)
+		if err != nil {
+			return err
 		}
-		return texts, nil
+		return checkAffected(ctx, s.db, tag, models.TypeFile, id, ownerID, 0, "")
 	}, retryOpts()...)
 }
 
-// GetFiles returns all files
-func (s *Storage) GetFiles(ctx context.Context, ownerID int) ([]*models.File, error) {
-	return retry.DoWithData(func() ([]*models.File, error) {
-		query := `select
-    				id, owner_id, filename, metadata, created_at
-    			  from  
-    				files 
+// DeleteStaleUploads deletes files which upload has not been finished before the provided time.
+func (s *Storage) DeleteStaleUploads(ctx context.Context, before time.Time) ([]*models.Entity, error) {
+	return retry.DoWithData(func() ([]*models.Entity, error) {
+		query := `delete from 
+    				entities 
 				  where
-				    owner_id = $1 and uploaded`
-		rows, err := s.db.Query(ctx, query, ownerID)
+				    type = $1 and data @> '{"uploaded": false}' and created_at < $2
+				  returning ` + entityColumns
+		rows, err := s.db.Query(ctx, query, models.TypeFile, before)
 		if err != nil {
 			return nil, err
 		}
 		defer rows.Close()
-		files := make([]*models.File, 0)
+		files := make([]*models.Entity, 0)
 		for rows.Next() {
-			f := &models.File{}
-			err = rows.Scan(&f.ID, &f.OwnerID, &f.FileName, &f.Metadata, &f.CreatedAt)
+			f, err := scanEntity(rows)
 			if err != nil {
 				return nil, err
 			}
 			files = append(files, f)
 		}
-		return files, nil
+		return files, rows.Err()
 	}, retryOpts()...)
 }
 
-// GetFile returns a file.
-func (s *Storage) GetFile(ctx context.Context, id int, ownerID int) (*models.File, error) {
-	return retry.DoWithData(func() (*models.File, error) {
+// TryLock tries to acquire the session level advisory lock with the provided name.
+// The lock is held on a dedicated connection until the returned unlock function is called.
+func (s *Storage) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	var locked bool
+	err = conn.QueryRow(ctx, "select pg_try_advisory_lock(hashtext($1))", name).Scan(&locked)
+	if err != nil {
+		conn.Release()
+		return nil, false, err
+	}
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+	unlock := func() {
+		_, err := conn.Exec(context.Background(), "select pg_advisory_unlock(hashtext($1))", name)
+		if err != nil {
+			sl.Log.Error("failed to release advisory lock", slog.String("name", name), sl.Err(err))
+			// the connection is closed to release the lock held by the session
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+	return unlock, true, nil
+}
+
+// LastJobRun returns the last run of the job.
+func (s *Storage) LastJobRun(ctx context.Context, name string) (*models.JobRun, error) {
+	return retry.DoWithData(func() (*models.JobRun, error) {
 		query := `select
-    				id, owner_id, filename, metadata, created_at
+    				name, scheduled_at, started_at, finished_at, error
     			  from  
-    				files 
+    				job_runs 
 				  where
-				    id = $1 and owner_id = $2`
-		row := s.db.QueryRow(ctx, query, id, ownerID)
-		file := &models.File{}
-		err := row.Scan(&file.ID, &file.OwnerID, &file.FileName, &file.Metadata, &file.CreatedAt)
+				    name = $1`
+		row := s.db.QueryRow(ctx, query, name)
+		run := &models.JobRun{}
+		err := row.Scan(&run.Name, &run.ScheduledAt, &run.StartedAt, &run.FinishedAt, &run.Error)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, storage.ErrFileNotExist
+				return nil, storage.ErrJobRunNotExist
 			}
 			return nil, err
 		}
-		return file, nil
+		return run, nil
 	}, retryOpts()...)
 }
 
-// MarkFileAsUploaded marks the file as uploaded.
-func (s *Storage) MarkFileAsUploaded(ctx context.Context, id int, ownerID int) error {
+// SaveJobRun saves the last run of the job.
+func (s *Storage) SaveJobRun(ctx context.Context, run *models.JobRun) error {
 	return retry.Do(func() error {
-		query := `update files set 
-    				uploaded=true 
-				  where
-				    id = $1 and owner_id = $2`
-		_, err := s.db.Exec(ctx, query, id, ownerID)
+		query := `insert into job_runs (name, scheduled_at, started_at, finished_at, error) 
+					values ($1, $2, $3, $4, $5)
+				  on conflict (name) do update set
+					scheduled_at = excluded.scheduled_at,
+					started_at = excluded.started_at,
+					finished_at = excluded.finished_at,
+					error = excluded.error`
+		_, err := s.db.Exec(ctx, query, run.Name, run.ScheduledAt, run.StartedAt, run.FinishedAt, run.Error)
 		if err != nil {
 			return err
 		}
@@ -390,6 +785,107 @@ func (s *Storage) MarkFileAsUploaded(ctx context.Context, id int, ownerID int) e
 	}, retryOpts()...)
 }
 
+// sortColumn returns the column entities are sorted by.
+func sortColumn(by models.SortField) string {
+	switch by {
+	case models.SortByUpdatedAt:
+		return "updated_at"
+	case models.SortByDeletedAt:
+		return "deleted_at"
+	}
+	return "created_at"
+}
+
+// entitiesQuery builds the statement selecting the entities matching the list options
+// sorted by (sort column, type, id). The files which upload has not been finished are not selected.
+// The entities shared with the user and the entities of the user`s vaults are selected as well,
+// but the trash lists only the entities the user can restore.
+func entitiesQuery(ownerID int, opts models.ListOptions) (string, []any) {
+	var b strings.Builder
+	args := []any{ownerID}
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	access := models.PermissionRead
+	if opts.Trashed {
+		access = ""
+	}
+	b.WriteString(`select
+    			` + entityColumns + `, ` + sharedColumns("$1") + `
+    		  from
+    			entities
+    		  where
+    			` + accessCondition("$1", access) + ` and not data @> '{"uploaded": false}'`)
+
+	if len(opts.Types) > 0 {
+		types := make([]string, 0, len(opts.Types))
+		for _, t := range opts.Types {
+			types = append(types, string(t))
+		}
+		b.WriteString(" and type = any(" + arg(types) + ")")
+	}
+	if opts.Trashed {
+		b.WriteString(" and deleted_at is not null")
+	} else {
+		b.WriteString(" and deleted_at is null")
+	}
+	if !opts.CreatedFrom.IsZero() {
+		b.WriteString(" and created_at >= " + arg(opts.CreatedFrom))
+	}
+	if !opts.CreatedTo.IsZero() {
+		b.WriteString(" and created_at < " + arg(opts.CreatedTo))
+	}
+	if !opts.UpdatedFrom.IsZero() {
+		b.WriteString(" and updated_at >= " + arg(opts.UpdatedFrom))
+	}
+	if !opts.UpdatedTo.IsZero() {
+		b.WriteString(" and updated_at < " + arg(opts.UpdatedTo))
+	}
+	if opts.Metadata != "" {
+		b.WriteString(" and strpos(lower(metadata), lower(" + arg(opts.Metadata) + ")) > 0")
+	}
+	if opts.FolderID > 0 {
+		b.WriteString(` and folder_id in (
+					with recursive subtree as (
+					  select id from folders where id = ` + arg(opts.FolderID) + ` and owner_id = $1
+					  union all
+					  select f.id from folders f join subtree on f.parent_id = subtree.id
+					)
+					select id from subtree)`)
+	}
+	if opts.VaultID > 0 {
+		b.WriteString(" and vault_id = " + arg(opts.VaultID))
+	}
+	for _, r := range opts.Labels {
+		b.WriteString(" and " + labelCondition(r, arg))
+	}
+	if len(opts.TagIDs) > 0 {
+		tagIDs := uniqueIDs(opts.TagIDs)
+		b.WriteString(" and (select count(*) from entity_tags where entity_type = entities.type and entity_id = entities.id" +
+			" and tag_id = any(" + arg(tagIDs) + ")) = " + arg(len(tagIDs)))
+	}
+
+	col := sortColumn(opts.SortBy)
+	dir, cmp := "asc", ">"
+	if opts.Desc {
+		dir, cmp = "desc", "<"
+	}
+
+	if c := opts.After; c != nil {
+		b.WriteString(fmt.Sprintf(" and (%s, type, id) %s (%s, %s, %s)", col, cmp, arg(c.Time), arg(string(c.Type)), arg(c.ID)))
+	}
+
+	b.WriteString(fmt.Sprintf(" order by %s %s, type %s, id %s", col, dir, dir, dir))
+	if opts.PageSize > 0 {
+		b.WriteString(" limit " + arg(opts.PageSize))
+	}
+
+	return b.String(), args
+}
+
 func retryOpts() []retry.Option {
 	return []retry.Option{
 		retry.RetryIf(func(err error) bool {
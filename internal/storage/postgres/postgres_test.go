@@ -2,8 +2,10 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/vindosVP/go-pass/internal/labels"
 	"github.com/vindosVP/go-pass/internal/models"
 	"github.com/vindosVP/go-pass/internal/storage"
 	"github.com/vindosVP/go-pass/pkg/db"
@@ -108,7 +111,7 @@ func setupStorage(cfg *testConfig, createUser bool) (*Storage, error) {
 
 }
 
-func TestStorage_AddPassword(t *testing.T) {
+func TestStorage_AddEntity(t *testing.T) {
 	ctx := context.Background()
 
 	cfg, err := parseTestConfig()
@@ -118,19 +121,46 @@ func TestStorage_AddPassword(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	pwd := &models.Password{
-		ID:       1,
-		OwnerID:  1,
-		Login:    "login",
-		Password: "password",
-		Metadata: "metadata",
+	tests := []struct {
+		name   string
+		entity *models.Entity
+	}{
+		{
+			name:   "password",
+			entity: &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "metadata"},
+		},
+		{
+			name: "card",
+			entity: &models.Entity{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234 1234 1234 1234",
+				CardCVC: "123", CardOwner: "USER CARD", CardExp: "06/28", Metadata: "metadata"},
+		},
+		{
+			name:   "text",
+			entity: &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "some text", Metadata: "metadata"},
+		},
+		{
+			name:   "file",
+			entity: &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "metadata"},
+		},
 	}
-	id, err := s.AddPassword(ctx, pwd)
-	require.NoError(t, err)
-	assert.Equal(t, pwd.ID, id)
+
+	ids := make(map[int]bool)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := s.AddEntity(ctx, tt.entity)
+			require.NoError(t, err)
+			// ids are shared by all types
+			assert.False(t, ids[id])
+			ids[id] = true
+		})
+	}
+
+	_, err = s.AddEntity(ctx, &models.Entity{Type: "UNKNOWN", OwnerID: 1})
+	assert.Error(t, err)
 }
 
-func TestStorage_AddCard(t *testing.T) {
+func TestStorage_GetEntity(t *testing.T) {
+
 	ctx := context.Background()
 
 	cfg, err := parseTestConfig()
@@ -140,21 +170,38 @@ func TestStorage_AddCard(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	card := &models.Card{
-		ID:       1,
-		OwnerID:  1,
-		Number:   "1234 1234 1234 1234",
-		CVC:      "123",
-		Owner:    "USER CARD",
-		Date:     "06/28",
-		Metadata: "metadata",
+	entities := []*models.Entity{
+		{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "metadata"},
+		{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234 1234 1234 1234", CardCVC: "123",
+			CardOwner: "USER CARD", CardExp: "06/28", Metadata: "metadata"},
+		{Type: models.TypeText, OwnerID: 1, Text: "some text", Metadata: "metadata"},
+		{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "metadata"},
+	}
+
+	for _, e := range entities {
+		t.Run(string(e.Type), func(t *testing.T) {
+			_, err := s.GetEntity(ctx, e.Type, 1, e.OwnerID)
+			assert.ErrorIs(t, err, notFound(e.Type))
+
+			e.ID, err = s.AddEntity(ctx, e)
+			require.NoError(t, err)
+
+			res, err := s.GetEntity(ctx, e.Type, e.ID, e.OwnerID)
+			require.NoError(t, err)
+			assert.Equal(t, 1, res.Revision)
+			res.CreatedAt = time.Time{}
+			res.UpdatedAt = time.Time{}
+			res.Revision = 0
+			assert.Equal(t, e, res)
+
+			_, err = s.GetEntity(ctx, e.Type, e.ID, 2)
+			assert.ErrorIs(t, err, notFound(e.Type))
+		})
 	}
-	id, err := s.AddCard(ctx, card)
-	require.NoError(t, err)
-	assert.Equal(t, card.ID, id)
 }
 
-func TestStorage_AddText(t *testing.T) {
+func TestStorage_UpdateEntity(t *testing.T) {
+
 	ctx := context.Background()
 
 	cfg, err := parseTestConfig()
@@ -164,39 +211,30 @@ func TestStorage_AddText(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	text := &models.Text{
-		ID:       1,
-		OwnerID:  1,
-		Text:     "some text",
-		Metadata: "metadata",
-	}
-	id, err := s.AddText(ctx, text)
+	card := &models.Entity{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234 1234 1234 1234",
+		CardCVC: "123", CardOwner: "USER CARD", CardExp: "06/28", Metadata: "metadata"}
+	card.ID, err = s.AddEntity(ctx, card)
 	require.NoError(t, err)
-	assert.Equal(t, text.ID, id)
-}
-
-func TestStorage_AddFile(t *testing.T) {
-	ctx := context.Background()
 
-	cfg, err := parseTestConfig()
-	require.NoError(t, err)
+	card.CardNumber = "2222 2222 2222 2222"
+	card.CardCVC = "321"
+	card.CardOwner = "CARD OWNER"
+	card.CardExp = "01/20"
+	card.Metadata = "new-metadata"
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
+	err = s.UpdateEntity(ctx, card, nil)
 	require.NoError(t, err)
 
-	file := &models.File{
-		ID:       1,
-		OwnerID:  1,
-		FileName: "file.txt",
-		Metadata: "metadata",
-	}
-	id, err := s.AddFile(ctx, file)
+	res, err := s.GetEntity(ctx, models.TypeCard, card.ID, card.OwnerID)
 	require.NoError(t, err)
-	assert.Equal(t, file.ID, id)
+	assert.Equal(t, 2, res.Revision)
+	res.CreatedAt = time.Time{}
+	res.UpdatedAt = time.Time{}
+	res.Revision = 0
+	assert.Equal(t, card, res)
 }
 
-func TestStorage_UpdatePassword(t *testing.T) {
+func TestStorage_UpdateEntityMask(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -207,25 +245,25 @@ func TestStorage_UpdatePassword(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	pwd := &models.Password{
-		ID:       1,
-		OwnerID:  1,
-		Login:    "login",
-		Password: "password",
-		Metadata: "metadata",
-	}
-	_, err = s.AddPassword(ctx, pwd)
+	pwd := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "metadata"}
+	pwd.ID, err = s.AddEntity(ctx, pwd)
 	require.NoError(t, err)
 
-	pwd.Login = "new-login"
-	pwd.Password = "new-password"
-	pwd.Metadata = "new-metadata"
+	upd := &models.Entity{Type: models.TypePassword, ID: pwd.ID, OwnerID: pwd.OwnerID, Password: "new-password"}
+	err = s.UpdateEntity(ctx, upd, []models.EntityField{models.FieldPassword})
+	require.NoError(t, err)
 
-	err = s.UpdatePassword(ctx, pwd)
-	assert.NoError(t, err)
+	got, err := s.GetEntity(ctx, models.TypePassword, pwd.ID, pwd.OwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, "login", got.Login)
+	assert.Equal(t, "new-password", got.Password)
+	assert.Equal(t, "metadata", got.Metadata)
+
+	err = s.UpdateEntity(ctx, upd, []models.EntityField{models.FieldText})
+	assert.Error(t, err)
 }
 
-func TestStorage_UpdateCard(t *testing.T) {
+func TestStorage_Revision(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -236,55 +274,31 @@ func TestStorage_UpdateCard(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	card := &models.Card{
-		ID:       1,
-		OwnerID:  1,
-		Number:   "1234 1234 1234 1234",
-		CVC:      "123",
-		Owner:    "USER CARD",
-		Date:     "06/28",
-		Metadata: "metadata",
-	}
-	_, err = s.AddCard(ctx, card)
+	text := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "some text", Metadata: "metadata"}
+	text.ID, err = s.AddEntity(ctx, text)
 	require.NoError(t, err)
 
-	card.Number = "2222 2222 2222 2222"
-	card.CVC = "321"
-	card.Owner = "CARD OWNER"
-	card.Date = "01/20"
-	card.Metadata = "new-metadata"
-
-	err = s.UpdateCard(ctx, card)
-	assert.NoError(t, err)
-}
-
-func TestStorage_UpdateText(t *testing.T) {
-	ctx := context.Background()
-
-	cfg, err := parseTestConfig()
+	text.Revision = 1
+	text.Text = "first update"
+	err = s.UpdateEntity(ctx, text, nil)
 	require.NoError(t, err)
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
-	require.NoError(t, err)
+	text.Text = "stale update"
+	err = s.UpdateEntity(ctx, text, nil)
+	assert.ErrorIs(t, err, storage.ErrRevisionMismatch)
 
-	text := &models.Text{
-		ID:       1,
-		OwnerID:  1,
-		Text:     "some text",
-		Metadata: "metadata",
-	}
-	_, err = s.AddText(ctx, text)
+	got, err := s.GetEntity(ctx, models.TypeText, text.ID, text.OwnerID)
 	require.NoError(t, err)
+	assert.Equal(t, 2, got.Revision)
+	assert.Equal(t, "first update", got.Text)
 
-	text.Text = "some updated text"
-	text.Metadata = "new metadata"
-
-	err = s.UpdateText(ctx, text)
+	err = s.DeleteEntity(ctx, models.TypeText, text.ID, text.OwnerID, 1)
+	assert.ErrorIs(t, err, storage.ErrRevisionMismatch)
+	err = s.DeleteEntity(ctx, models.TypeText, text.ID, text.OwnerID, 2)
 	assert.NoError(t, err)
 }
 
-func TestStorage_DeletePassword(t *testing.T) {
+func TestStorage_Versions(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -295,48 +309,65 @@ func TestStorage_DeletePassword(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	pwd := &models.Password{
-		ID:       1,
-		OwnerID:  1,
-		Login:    "login",
-		Password: "password",
-		Metadata: "metadata",
-	}
-	id, err := s.AddPassword(ctx, pwd)
+	card := &models.Entity{Type: models.TypeCard, OwnerID: 1, CardNumber: "1111", CardCVC: "111",
+		CardOwner: "OWNER", CardExp: "01/30", Metadata: "v1"}
+	card.ID, err = s.AddEntity(ctx, card)
 	require.NoError(t, err)
 
-	err = s.DeletePassword(ctx, id, pwd.OwnerID)
-	assert.NoError(t, err)
-}
+	for _, md := range []string{"v2", "v3", "v4"} {
+		upd := &models.Entity{Type: models.TypeCard, ID: card.ID, OwnerID: card.OwnerID, Metadata: md}
+		err = s.UpdateEntity(ctx, upd, []models.EntityField{models.FieldMetadata})
+		require.NoError(t, err)
+	}
 
-func TestStorage_DeleteCard(t *testing.T) {
+	// the failed update is not archived
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeCard, ID: card.ID, OwnerID: card.OwnerID, Revision: 1}, nil)
+	require.ErrorIs(t, err, storage.ErrRevisionMismatch)
 
-	ctx := context.Background()
+	versions, err := s.ListVersions(ctx, models.TypeCard, card.ID, card.OwnerID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 3, versions[0].Entity.Revision)
+	assert.Equal(t, "v3", versions[0].Entity.Metadata)
+	assert.Equal(t, 1, versions[2].Entity.Revision)
+	assert.Equal(t, "1111", versions[2].Entity.CardNumber)
+	assert.Equal(t, "v1", versions[2].Entity.Metadata)
+
+	_, err = s.ListVersions(ctx, models.TypeCard, card.ID, 2)
+	require.NoError(t, err)
 
-	cfg, err := parseTestConfig()
+	v, err := s.GetVersion(ctx, models.TypeCard, card.ID, card.OwnerID, 2)
 	require.NoError(t, err)
+	assert.Equal(t, "v2", v.Entity.Metadata)
+	_, err = s.GetVersion(ctx, models.TypeCard, card.ID, card.OwnerID, 4)
+	assert.ErrorIs(t, err, storage.ErrVersionNotExist)
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
+	n, err := s.DeleteOldVersions(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	versions, err = s.ListVersions(ctx, models.TypeCard, card.ID, card.OwnerID)
 	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+	got, err := s.GetEntity(ctx, models.TypeCard, card.ID, card.OwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.PrunedRevision)
 
-	card := &models.Card{
-		ID:       1,
-		OwnerID:  1,
-		Number:   "1234 1234 1234 1234",
-		CVC:      "123",
-		Owner:    "USER CARD",
-		Date:     "06/28",
-		Metadata: "metadata",
-	}
-	id, err := s.AddCard(ctx, card)
+	// versions are kept in the trash and removed on purge
+	err = s.DeleteEntity(ctx, models.TypeCard, card.ID, card.OwnerID, 0)
 	require.NoError(t, err)
+	versions, err = s.ListVersions(ctx, models.TypeCard, card.ID, card.OwnerID)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
 
-	err = s.DeleteCard(ctx, id, card.OwnerID)
-	assert.NoError(t, err)
+	_, err = s.PurgeEntity(ctx, models.TypeCard, card.ID, card.OwnerID)
+	require.NoError(t, err)
+	versions, err = s.ListVersions(ctx, models.TypeCard, card.ID, card.OwnerID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
 }
 
-func TestStorage_DeleteText(t *testing.T) {
+func TestStorage_Trash(t *testing.T) {
+
 	ctx := context.Background()
 
 	cfg, err := parseTestConfig()
@@ -346,74 +377,68 @@ func TestStorage_DeleteText(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	text := &models.Text{
-		ID:       1,
-		OwnerID:  1,
-		Text:     "some text",
-		Metadata: "metadata",
-	}
-	id, err := s.AddText(ctx, text)
+	text := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "text", Metadata: "md"}
+	text.ID, err = s.AddEntity(ctx, text)
 	require.NoError(t, err)
-
-	err = s.DeleteText(ctx, id, text.OwnerID)
-	assert.NoError(t, err)
-}
-
-func TestStorage_MarkFileAsUploaded(t *testing.T) {
-
-	ctx := context.Background()
-
-	cfg, err := parseTestConfig()
+	file := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "md"}
+	file.ID, err = s.AddEntity(ctx, file)
+	require.NoError(t, err)
+	err = s.MarkFileAsUploaded(ctx, file.ID, file.OwnerID)
 	require.NoError(t, err)
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
+	err = s.DeleteEntity(ctx, models.TypeText, text.ID, text.OwnerID, 0)
 	require.NoError(t, err)
+	_, err = s.GetEntity(ctx, models.TypeText, text.ID, text.OwnerID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+	err = s.DeleteEntity(ctx, models.TypeText, text.ID, text.OwnerID, 0)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
 
-	file := &models.File{
-		ID:       1,
-		OwnerID:  1,
-		FileName: "file.txt",
-		Metadata: "metadata",
-	}
-	id, err := s.AddFile(ctx, file)
+	live, err := s.ListEntities(ctx, 1, models.ListOptions{})
 	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, models.TypeFile, live[0].Type)
 
-	err = s.MarkFileAsUploaded(ctx, id, file.OwnerID)
-	assert.NoError(t, err)
-}
+	trashed, err := s.ListEntities(ctx, 1, models.ListOptions{Trashed: true, SortBy: models.SortByDeletedAt})
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, text.ID, trashed[0].ID)
+	assert.False(t, trashed[0].DeletedAt.IsZero())
 
-func TestStorage_GetFile(t *testing.T) {
+	err = s.RestoreEntity(ctx, models.TypeText, text.ID, text.OwnerID)
+	require.NoError(t, err)
+	_, err = s.GetEntity(ctx, models.TypeText, text.ID, text.OwnerID)
+	require.NoError(t, err)
+	err = s.RestoreEntity(ctx, models.TypeText, text.ID, text.OwnerID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
 
-	ctx := context.Background()
+	// only entities in the trash are purged
+	_, err = s.PurgeEntity(ctx, models.TypeFile, file.ID, file.OwnerID)
+	assert.ErrorIs(t, err, storage.ErrFileNotExist)
 
-	cfg, err := parseTestConfig()
+	err = s.DeleteEntity(ctx, models.TypeFile, file.ID, file.OwnerID, 0)
+	require.NoError(t, err)
+	err = s.DeleteEntity(ctx, models.TypeText, text.ID, text.OwnerID, 0)
 	require.NoError(t, err)
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
+	purged, err := s.PurgeTrash(ctx, time.Now().Add(-time.Hour))
 	require.NoError(t, err)
+	assert.Empty(t, purged)
 
-	file := &models.File{
-		ID:       1,
-		OwnerID:  1,
-		FileName: "file.txt",
-		Metadata: "metadata",
+	purged, err = s.PurgeTrash(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, purged, 2)
+	for _, e := range purged {
+		if e.Type == models.TypeFile {
+			assert.Equal(t, file.Filename, e.Filename)
+		}
 	}
 
-	_, err = s.GetFile(ctx, file.ID, file.OwnerID)
-	assert.ErrorIs(t, err, storage.ErrFileNotExist)
-
-	id, err := s.AddFile(ctx, file)
+	trashed, err = s.ListEntities(ctx, 1, models.ListOptions{Trashed: true})
 	require.NoError(t, err)
-
-	f, err := s.GetFile(ctx, id, file.OwnerID)
-	assert.NoError(t, err)
-	assert.Equal(t, file.FileName, f.FileName)
-	assert.Equal(t, file.Metadata, f.Metadata)
+	assert.Empty(t, trashed)
 }
 
-func TestStorage_DeleteFile(t *testing.T) {
+func TestStorage_MutateNotExist(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -424,20 +449,37 @@ func TestStorage_DeleteFile(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	file := &models.File{
-		ID:       1,
-		OwnerID:  1,
-		FileName: "file.txt",
-		Metadata: "metadata",
-	}
-	id, err := s.AddFile(ctx, file)
+	pwd := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password"}
+	pwd.ID, err = s.AddEntity(ctx, pwd)
 	require.NoError(t, err)
 
-	err = s.DeleteFile(ctx, id, file.OwnerID)
+	// foreign password
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypePassword, ID: pwd.ID, OwnerID: 2}, nil)
+	assert.ErrorIs(t, err, storage.ErrPasswordNotExist)
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypePassword, ID: pwd.ID, OwnerID: 2, Revision: 1}, nil)
+	assert.ErrorIs(t, err, storage.ErrPasswordNotExist)
+	err = s.DeleteEntity(ctx, models.TypePassword, pwd.ID, 2, 0)
+	assert.ErrorIs(t, err, storage.ErrPasswordNotExist)
+
+	// same id of another type
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeCard, ID: pwd.ID, OwnerID: 1}, nil)
+	assert.ErrorIs(t, err, storage.ErrCardNotExist)
+	err = s.DeleteEntity(ctx, models.TypeCard, pwd.ID, 1, 0)
+	assert.ErrorIs(t, err, storage.ErrCardNotExist)
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: 100, OwnerID: 1}, nil)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+	err = s.DeleteEntity(ctx, models.TypeText, 100, 1, 0)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+	err = s.DeleteEntity(ctx, models.TypeFile, 100, 1, 0)
+	assert.ErrorIs(t, err, storage.ErrFileNotExist)
+	err = s.MarkFileAsUploaded(ctx, 100, 1)
+	assert.ErrorIs(t, err, storage.ErrFileNotExist)
+
+	err = s.DeleteEntity(ctx, models.TypePassword, pwd.ID, pwd.OwnerID, 0)
 	assert.NoError(t, err)
 }
 
-func TestStorage_GetPasswords(t *testing.T) {
+func TestStorage_DeleteEntity(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -448,47 +490,27 @@ func TestStorage_GetPasswords(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	pwds := []*models.Password{
-		{
-			ID:        1,
-			OwnerID:   1,
-			Login:     "login1",
-			Password:  "password1",
-			Metadata:  "metadata1",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        2,
-			OwnerID:   1,
-			Login:     "login2",
-			Password:  "password2",
-			Metadata:  "metadata2",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        3,
-			OwnerID:   1,
-			Login:     "login3",
-			Password:  "password3",
-			Metadata:  "metadata3",
-			CreatedAt: time.Time{},
-		},
+	entities := []*models.Entity{
+		{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "metadata"},
+		{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234", CardCVC: "123", CardOwner: "OWNER", CardExp: "06/28"},
+		{Type: models.TypeText, OwnerID: 1, Text: "some text", Metadata: "metadata"},
+		{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "metadata"},
 	}
 
-	for _, pwd := range pwds {
-		_, err = s.AddPassword(ctx, pwd)
-		require.NoError(t, err)
-	}
+	for _, e := range entities {
+		t.Run(string(e.Type), func(t *testing.T) {
+			id, err := s.AddEntity(ctx, e)
+			require.NoError(t, err)
 
-	res, err := s.GetPasswords(ctx, 1)
-	for _, r := range res {
-		r.CreatedAt = time.Time{}
+			err = s.DeleteEntity(ctx, e.Type, id, e.OwnerID, 0)
+			assert.NoError(t, err)
+			_, err = s.GetEntity(ctx, e.Type, id, e.OwnerID)
+			assert.ErrorIs(t, err, notFound(e.Type))
+		})
 	}
-	assert.NoError(t, err)
-	assert.ElementsMatch(t, pwds, res)
 }
 
-func TestStorage_GetCards(t *testing.T) {
+func TestStorage_MarkFileAsUploaded(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -499,53 +521,20 @@ func TestStorage_GetCards(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	cards := []*models.Card{
-		{
-			ID:        1,
-			OwnerID:   1,
-			Number:    "1234",
-			CVC:       "123",
-			Owner:     "VADIM VALOV",
-			Date:      "06/28",
-			Metadata:  "md",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        2,
-			OwnerID:   1,
-			Number:    "5678",
-			CVC:       "111",
-			Owner:     "IVANOV IVAN",
-			Date:      "06/20",
-			Metadata:  "md1",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        3,
-			OwnerID:   1,
-			Number:    "9012",
-			CVC:       "222",
-			Owner:     "VASYA IVALOV",
-			Date:      "01/21",
-			Metadata:  "md2",
-			CreatedAt: time.Time{},
-		},
-	}
-
-	for _, card := range cards {
-		_, err = s.AddCard(ctx, card)
-		require.NoError(t, err)
-	}
+	file := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "metadata"}
+	id, err := s.AddEntity(ctx, file)
+	require.NoError(t, err)
 
-	res, err := s.GetCards(ctx, 1)
-	for _, r := range res {
-		r.CreatedAt = time.Time{}
-	}
+	err = s.MarkFileAsUploaded(ctx, id, file.OwnerID)
 	assert.NoError(t, err)
-	assert.ElementsMatch(t, cards, res)
+
+	// the upload flag is not exposed as an entity field
+	res, err := s.GetEntity(ctx, models.TypeFile, id, file.OwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, file.Filename, res.Filename)
 }
 
-func TestStorage_GetTexts(t *testing.T) {
+func TestStorage_ListEntitiesTypes(t *testing.T) {
 
 	ctx := context.Background()
 
@@ -556,98 +545,50 @@ func TestStorage_GetTexts(t *testing.T) {
 	defer cleanup(cfg)
 	require.NoError(t, err)
 
-	texts := []*models.Text{
-		{
-			ID:        1,
-			OwnerID:   1,
-			Text:      "text1",
-			Metadata:  "md1",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        2,
-			OwnerID:   1,
-			Text:      "text2",
-			Metadata:  "md2",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        3,
-			OwnerID:   1,
-			Text:      "text3",
-			Metadata:  "md3",
-			CreatedAt: time.Time{},
-		},
+	cards := []*models.Entity{
+		{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234", CardCVC: "123", CardOwner: "VADIM VALOV", CardExp: "06/28", Metadata: "md"},
+		{Type: models.TypeCard, OwnerID: 1, CardNumber: "5678", CardCVC: "111", CardOwner: "IVANOV IVAN", CardExp: "06/20", Metadata: "md1"},
+		{Type: models.TypeCard, OwnerID: 1, CardNumber: "9012", CardCVC: "222", CardOwner: "VASYA IVALOV", CardExp: "01/21", Metadata: "md2"},
+	}
+	files := []*models.Entity{
+		{Type: models.TypeFile, OwnerID: 1, Filename: "file1", Metadata: "md1"},
+		{Type: models.TypeFile, OwnerID: 1, Filename: "file2", Metadata: "md2"},
 	}
 
-	for _, text := range texts {
-		_, err = s.AddText(ctx, text)
+	for _, e := range append(cards, files...) {
+		e.ID, err = s.AddEntity(ctx, e)
 		require.NoError(t, err)
 	}
+	_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "text"})
+	require.NoError(t, err)
 
-	res, err := s.GetTexts(ctx, 1)
-	for _, r := range res {
-		r.CreatedAt = time.Time{}
+	reset := func(res []*models.Entity) {
+		for _, r := range res {
+			r.CreatedAt = time.Time{}
+			r.UpdatedAt = time.Time{}
+			r.Revision = 0
+		}
 	}
-	assert.NoError(t, err)
-	assert.ElementsMatch(t, texts, res)
-}
 
-func TestStorage_GetFiles(t *testing.T) {
-
-	ctx := context.Background()
-
-	cfg, err := parseTestConfig()
+	res, err := s.ListEntities(ctx, 1, models.ListOptions{Types: []models.EntityType{models.TypeCard}})
 	require.NoError(t, err)
+	reset(res)
+	assert.ElementsMatch(t, cards, res)
 
-	s, err := setupStorage(cfg, true)
-	defer cleanup(cfg)
+	// files are listed once uploaded only
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{Types: []models.EntityType{models.TypeFile}})
 	require.NoError(t, err)
-
-	files := []*models.File{
-		{
-			ID:        1,
-			OwnerID:   1,
-			FileName:  "file1",
-			Metadata:  "md1",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        2,
-			OwnerID:   1,
-			FileName:  "file2",
-			Metadata:  "md2",
-			CreatedAt: time.Time{},
-		},
-		{
-			ID:        3,
-			OwnerID:   1,
-			FileName:  "file3",
-			Metadata:  "md3",
-			CreatedAt: time.Time{},
-		},
-	}
-
-	for _, file := range files {
-		_, err = s.AddFile(ctx, file)
-		require.NoError(t, err)
-	}
-
-	res1, err := s.GetFiles(ctx, 1)
-	assert.Len(t, res1, 0)
-	assert.NoError(t, err)
+	assert.Len(t, res, 0)
 
 	for _, file := range files {
 		err = s.MarkFileAsUploaded(ctx, file.ID, file.OwnerID)
 		require.NoError(t, err)
 	}
 
-	res2, err := s.GetFiles(ctx, 1)
-	for _, r := range res2 {
-		r.CreatedAt = time.Time{}
-	}
-	assert.NoError(t, err)
-	assert.ElementsMatch(t, files, res2)
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{Types: []models.EntityType{models.TypeFile}})
+	require.NoError(t, err)
+	reset(res)
+	assert.ElementsMatch(t, files, res)
 }
 
 func TestStorage_CreateUser(t *testing.T) {
@@ -708,3 +649,1649 @@ func TestStorage_UserByEmail(t *testing.T) {
 	assert.Equal(t, user.PassHash, res.PassHash)
 
 }
+
+func TestStorage_DeleteStaleUploads(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	stale := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "stale", Metadata: "md1"}
+	uploaded := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "uploaded", Metadata: "md2"}
+
+	stale.ID, err = s.AddEntity(ctx, stale)
+	require.NoError(t, err)
+	uploaded.ID, err = s.AddEntity(ctx, uploaded)
+	require.NoError(t, err)
+	err = s.MarkFileAsUploaded(ctx, uploaded.ID, uploaded.OwnerID)
+	require.NoError(t, err)
+
+	res, err := s.DeleteStaleUploads(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+
+	res, err = s.DeleteStaleUploads(ctx, time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, stale.ID, res[0].ID)
+	assert.Equal(t, stale.Filename, res[0].Filename)
+
+	_, err = s.GetEntity(ctx, models.TypeFile, stale.ID, stale.OwnerID)
+	assert.ErrorIs(t, err, storage.ErrFileNotExist)
+	_, err = s.GetEntity(ctx, models.TypeFile, uploaded.ID, uploaded.OwnerID)
+	assert.NoError(t, err)
+}
+
+func TestStorage_JobRuns(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, false)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	_, err = s.LastJobRun(ctx, "job")
+	assert.ErrorIs(t, err, storage.ErrJobRunNotExist)
+
+	tick := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		run := &models.JobRun{
+			Name:        "job",
+			ScheduledAt: tick.Add(time.Duration(i) * time.Hour),
+			StartedAt:   tick.Add(time.Duration(i) * time.Hour),
+			FinishedAt:  tick.Add(time.Duration(i)*time.Hour + time.Minute),
+			Error:       fmt.Sprintf("error %d", i),
+		}
+		err = s.SaveJobRun(ctx, run)
+		require.NoError(t, err)
+
+		res, err := s.LastJobRun(ctx, "job")
+		assert.NoError(t, err)
+		assert.Equal(t, run.ScheduledAt, res.ScheduledAt.UTC())
+		assert.Equal(t, run.Error, res.Error)
+	}
+}
+
+func TestStorage_TryLock(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, false)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	unlock, ok, err := s.TryLock(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, ok, err = s.TryLock(ctx, "job")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	unlock()
+
+	unlock, ok, err = s.TryLock(ctx, "job")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	unlock()
+}
+
+func Test_updateQuery(t *testing.T) {
+
+	card := &models.Entity{Type: models.TypeCard, ID: 1, OwnerID: 2,
+		CardNumber: "1111", CardOwner: "OWNER", CardCVC: "123", CardExp: "01/30", Metadata: "meta"}
+
+	tests := []struct {
+		name     string
+		entity   *models.Entity
+		fields   []models.EntityField
+		revision int
+		query    string
+		args     []any
+		err      bool
+	}{
+		{
+			name:   "all fields",
+			entity: card,
+			query: "update entities set metadata=$6, data=data || jsonb_build_object(" +
+				"'number', $1::text, 'owner', $2::text, 'cvc', $3::text, 'date', $4::text, 'custom_fields', $5::jsonb), " +
+				"updated_at=$7, revision=revision+1 where type = $8 and id = $9 and " + accessCondition("$10", models.PermissionWrite) + " and deleted_at is null",
+			args: []any{"1111", "OWNER", "123", "01/30", "[]", "meta"},
+		},
+		{
+			name: "custom fields",
+			entity: &models.Entity{Type: models.TypePassword, ID: 1, OwnerID: 2,
+				Custom: []models.CustomField{{Name: "pin", Type: models.CustomHidden, Value: "1234"}}},
+			fields: []models.EntityField{models.FieldCustom},
+			query: "update entities set data=data || jsonb_build_object('custom_fields', $1::jsonb), " +
+				"updated_at=$2, revision=revision+1 where type = $3 and id = $4 and " + accessCondition("$5", models.PermissionWrite) + " and deleted_at is null",
+			args: []any{`[{"name":"pin","type":"HIDDEN","value":"1234"}]`},
+		},
+		{
+			name:   "masked fields",
+			entity: card,
+			fields: []models.EntityField{models.FieldMetadata, models.FieldCardCVC},
+			query: "update entities set metadata=$1, data=data || jsonb_build_object('cvc', $2::text), " +
+				"updated_at=$3, revision=revision+1 where type = $4 and id = $5 and " + accessCondition("$6", models.PermissionWrite) + " and deleted_at is null",
+			args: []any{"meta", "123"},
+		},
+		{
+			name:     "expected revision",
+			entity:   card,
+			fields:   []models.EntityField{models.FieldCardNumber},
+			revision: 3,
+			query: "update entities set data=data || jsonb_build_object('number', $1::text), " +
+				"updated_at=$2, revision=revision+1 where type = $3 and id = $4 and " + accessCondition("$5", models.PermissionWrite) + " and deleted_at is null and revision = $6",
+			args: []any{"1111"},
+		},
+		{
+			name:   "folder and tags",
+			entity: card,
+			fields: []models.EntityField{models.FieldFolder, models.FieldTags},
+			query: "update entities set folder_id=nullif($1::integer, 0), " +
+				"updated_at=$2, revision=revision+1 where type = $3 and id = $4 and " + accessCondition("$5", "") + " and deleted_at is null",
+			args: []any{0},
+		},
+		{
+			name:   "unknown field",
+			entity: card,
+			fields: []models.EntityField{models.FieldLogin},
+			err:    true,
+		},
+		{
+			name:   "unknown type",
+			entity: &models.Entity{Type: "UNKNOWN"},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := *tt.entity
+			e.Revision = tt.revision
+			query, args, err := updateQuery(&e, tt.fields)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.query, query)
+			tail := []any{e.Type, e.ID, e.OwnerID}
+			if tt.revision > 0 {
+				tail = append(tail, tt.revision)
+			}
+			require.Len(t, args, len(tt.args)+1+len(tail))
+			assert.Equal(t, tt.args, args[:len(tt.args)])
+			assert.IsType(t, time.Time{}, args[len(tt.args)])
+			assert.Equal(t, tail, args[len(tt.args)+1:])
+		})
+	}
+}
+
+func Test_accessCondition(t *testing.T) {
+
+	shared := "exists(select 1 from entity_shares where entity_type = entities.type and entity_id = entities.id and user_id = $1"
+	members := "vault_id in (select v.id from vaults v join org_members m on m.org_id = v.org_id where m.user_id = $1"
+	emergency := "exists(select 1 from emergency_contacts where grantor_id = entities.owner_id and grantee_id = $1 and status = 'GRANTED')"
+
+	tests := []struct {
+		name string
+		p    models.Permission
+		want string
+	}{
+		{
+			name: "owner",
+			want: "((vault_id is null and owner_id = $1) or " + members + " and m.role <> 'VIEWER'))",
+		},
+		{
+			name: "read",
+			p:    models.PermissionRead,
+			want: "((vault_id is null and (owner_id = $1 or " + shared + ") or " + emergency + ")) or " + members + "))",
+		},
+		{
+			name: "write",
+			p:    models.PermissionWrite,
+			want: "((vault_id is null and (owner_id = $1 or " + shared + " and permission = 'WRITE'))) or " +
+				members + " and m.role <> 'VIEWER'))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, accessCondition("$1", tt.p))
+		})
+	}
+}
+
+func Test_entitiesQuery(t *testing.T) {
+
+	ts := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		opts  models.ListOptions
+		query string
+		args  []any
+	}{
+		{
+			name:  "no options",
+			query: " and deleted_at is null order by created_at asc, type asc, id asc",
+			args:  []any{1},
+		},
+		{
+			name: "filters",
+			opts: models.ListOptions{
+				Types:       []models.EntityType{models.TypeCard, models.TypeText},
+				CreatedFrom: ts,
+				UpdatedTo:   ts,
+				Metadata:    "prod",
+				SortBy:      models.SortByUpdatedAt,
+				Desc:        true,
+				PageSize:    10,
+			},
+			query: " and type = any($2) and deleted_at is null and created_at >= $3 and updated_at < $4" +
+				" and strpos(lower(metadata), lower($5)) > 0" +
+				" order by updated_at desc, type desc, id desc limit $6",
+			args: []any{1, []string{"CARD", "TEXT"}, ts, ts, "prod", 10},
+		},
+		{
+			name:  "cursor",
+			opts:  models.ListOptions{After: &models.Cursor{Time: ts, Type: models.TypeText, ID: 5}},
+			query: " and deleted_at is null and (created_at, type, id) > ($2, $3, $4) order by created_at asc, type asc, id asc",
+			args:  []any{1, ts, "TEXT", 5},
+		},
+		{
+			name:  "cursor descending",
+			opts:  models.ListOptions{Desc: true, After: &models.Cursor{Time: ts, Type: models.TypeCard, ID: 5}},
+			query: " and deleted_at is null and (created_at, type, id) < ($2, $3, $4) order by created_at desc, type desc, id desc",
+			args:  []any{1, ts, "CARD", 5},
+		},
+		{
+			name: "label selector",
+			opts: models.ListOptions{Labels: labels.Selector{
+				{Key: "env", Op: labels.In, Values: []string{"prod", "staging"}},
+				{Key: "team", Op: labels.NotEquals, Values: []string{"payments"}},
+				{Key: "region", Op: labels.NotIn, Values: []string{"eu"}},
+				{Key: "deprecated", Op: labels.DoesNotExist},
+			}},
+			query: " and deleted_at is null and labels->>$2::text = any($3)" +
+				" and not labels @> jsonb_build_object($4::text, $5::text)" +
+				" and coalesce(labels->>$6::text <> all($7), true)" +
+				" and not labels ? $8" +
+				" order by created_at asc, type asc, id asc",
+			args: []any{1, "env", []string{"prod", "staging"}, "team", "payments", "region", []string{"eu"}, "deprecated"},
+		},
+		{
+			name:  "vault",
+			opts:  models.ListOptions{VaultID: 3},
+			query: " and deleted_at is null and vault_id = $2 order by created_at asc, type asc, id asc",
+			args:  []any{1, 3},
+		},
+		{
+			name:  "trashed",
+			opts:  models.ListOptions{Trashed: true, SortBy: models.SortByDeletedAt, Desc: true},
+			query: " and deleted_at is not null order by deleted_at desc, type desc, id desc",
+			args:  []any{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := entitiesQuery(1, tt.opts)
+			_, conditions, ok := strings.Cut(query, `not data @> '{"uploaded": false}'`)
+			require.True(t, ok)
+			assert.Equal(t, tt.query, conditions)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+
+	query, args := entitiesQuery(1, models.ListOptions{FolderID: 7, TagIDs: []int{3, 2, 3}})
+	assert.Contains(t, query, "with recursive subtree as")
+	assert.Contains(t, query, "select id from folders where id = $2 and owner_id = $1")
+	assert.Contains(t, query, "and tag_id = any($3)) = $4")
+	assert.Equal(t, []any{1, 7, []int{2, 3}, 2}, args)
+
+	query, _ = entitiesQuery(1, models.ListOptions{})
+	assert.Contains(t, query, accessCondition("$1", models.PermissionRead)+` and not data @> '{"uploaded": false}'`)
+	query, _ = entitiesQuery(1, models.ListOptions{Trashed: true})
+	assert.Contains(t, query, accessCondition("$1", "")+` and not data @> '{"uploaded": false}'`)
+}
+
+func TestStorage_ListEntitiesPage(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		pwd := &models.Entity{
+			Type:     models.TypePassword,
+			OwnerID:  1,
+			Login:    fmt.Sprintf("login%d", i),
+			Password: "password",
+			Metadata: fmt.Sprintf("env=%d", i%2),
+		}
+		_, err = s.AddEntity(ctx, pwd)
+		require.NoError(t, err)
+	}
+
+	res, err := s.ListEntities(ctx, 1, models.ListOptions{Metadata: "ENV=1"})
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	opts := models.ListOptions{Desc: true, PageSize: 2}
+	res, err = s.ListEntities(ctx, 1, opts)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "login5", res[0].Login)
+	assert.Equal(t, "login4", res[1].Login)
+
+	opts.After = res[1].Cursor(models.SortByCreatedAt)
+	res, err = s.ListEntities(ctx, 1, opts)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "login3", res[0].Login)
+	assert.Equal(t, "login2", res[1].Login)
+
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{CreatedFrom: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func TestStorage_IterateEntities(t *testing.T) {
+
+	ctx := context.Background()
+	stop := errors.New("stop")
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, Text: fmt.Sprintf("text%d", i)})
+		require.NoError(t, err)
+	}
+
+	read := make([]string, 0)
+	err = s.IterateEntities(ctx, 1, models.ListOptions{}, func(e *models.Entity) error {
+		read = append(read, e.Text)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"text1", "text2", "text3"}, read)
+
+	read = read[:0]
+	err = s.IterateEntities(ctx, 1, models.ListOptions{}, func(e *models.Entity) error {
+		read = append(read, e.Text)
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"text1"}, read)
+}
+
+func TestStorage_ListEntities(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	pwd := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "md"}
+	card := &models.Entity{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234", CardCVC: "123",
+		CardOwner: "OWNER", CardExp: "06/28", Metadata: "md"}
+	text := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "text", Metadata: "md"}
+	file := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "md"}
+
+	for _, e := range []*models.Entity{pwd, card, text, file} {
+		e.ID, err = s.AddEntity(ctx, e)
+		require.NoError(t, err)
+	}
+
+	res, err := s.ListEntities(ctx, 1, models.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	err = s.MarkFileAsUploaded(ctx, file.ID, file.OwnerID)
+	require.NoError(t, err)
+
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{})
+	require.NoError(t, err)
+	for _, r := range res {
+		r.CreatedAt = time.Time{}
+		r.UpdatedAt = time.Time{}
+		r.Revision = 0
+	}
+	assert.Equal(t, []*models.Entity{pwd, card, text, file}, res)
+
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{Desc: true, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, models.TypeFile, res[0].Type)
+	assert.Equal(t, models.TypeText, res[1].Type)
+
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{Desc: true, After: res[1].Cursor(models.SortByCreatedAt)})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, models.TypeCard, res[0].Type)
+	assert.Equal(t, models.TypePassword, res[1].Type)
+
+	res, err = s.ListEntities(ctx, 2, models.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func BenchmarkStorage_ListEntities(b *testing.B) {
+
+	ctx := context.Background()
+	perType := 250
+
+	cfg, err := parseTestConfig()
+	require.NoError(b, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(b, err)
+
+	for i := 0; i < perType; i++ {
+		for _, e := range []*models.Entity{
+			{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password", Metadata: "md"},
+			{Type: models.TypeCard, OwnerID: 1, CardNumber: "1234", CardCVC: "123", CardOwner: "OWNER", CardExp: "06/28", Metadata: "md"},
+			{Type: models.TypeText, OwnerID: 1, Text: "text", Metadata: "md"},
+			{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt", Metadata: "md"},
+		} {
+			id, err := s.AddEntity(ctx, e)
+			require.NoError(b, err)
+			if e.Type == models.TypeFile {
+				require.NoError(b, s.MarkFileAsUploaded(ctx, id, 1))
+			}
+		}
+	}
+
+	b.Run("per type", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := make([]*models.Entity, 0, perType*4)
+			for _, t := range []models.EntityType{models.TypePassword, models.TypeCard, models.TypeText, models.TypeFile} {
+				part, err := s.ListEntities(ctx, 1, models.ListOptions{Types: []models.EntityType{t}})
+				require.NoError(b, err)
+				res = append(res, part...)
+			}
+			require.Len(b, res, perType*4)
+		}
+	})
+
+	b.Run("single query", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res, err := s.ListEntities(ctx, 1, models.ListOptions{})
+			require.NoError(b, err)
+			require.Len(b, res, perType*4)
+		}
+	})
+}
+
+func TestStorage_Folders(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	_, err = s.CreateFolder(ctx, &models.Folder{OwnerID: 1, ParentID: 100, Name: "orphan"})
+	assert.ErrorIs(t, err, storage.ErrFolderNotExist)
+
+	work, err := s.CreateFolder(ctx, &models.Folder{OwnerID: 1, Name: "work"})
+	require.NoError(t, err)
+	prod, err := s.CreateFolder(ctx, &models.Folder{OwnerID: 1, ParentID: work.ID, Name: "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, prod.ParentID)
+
+	// the folder can not be moved into its subfolder
+	_, err = s.UpdateFolder(ctx, &models.Folder{ID: work.ID, OwnerID: 1, ParentID: prod.ID, Name: "work"})
+	assert.ErrorIs(t, err, storage.ErrFolderCycle)
+	_, err = s.UpdateFolder(ctx, &models.Folder{ID: work.ID, OwnerID: 1, ParentID: work.ID, Name: "work"})
+	assert.ErrorIs(t, err, storage.ErrFolderCycle)
+
+	text := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "text", FolderID: prod.ID}
+	text.ID, err = s.AddEntity(ctx, text)
+	require.NoError(t, err)
+	pwd := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login"}
+	pwd.ID, err = s.AddEntity(ctx, pwd)
+	require.NoError(t, err)
+	_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, FolderID: 100})
+	assert.ErrorIs(t, err, storage.ErrFolderNotExist)
+
+	// the subtree is listed
+	res, err := s.ListEntities(ctx, 1, models.ListOptions{FolderID: work.ID})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, text.ID, res[0].ID)
+	assert.Equal(t, prod.ID, res[0].FolderID)
+
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypePassword, ID: pwd.ID, OwnerID: 1, FolderID: work.ID},
+		[]models.EntityField{models.FieldFolder})
+	require.NoError(t, err)
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{FolderID: work.ID})
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	// moving the folder is not archived as the entity version
+	versions, err := s.ListVersions(ctx, models.TypePassword, pwd.ID, 1)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	// the entities of the deleted folder are moved to its parent
+	err = s.DeleteFolder(ctx, prod.ID, 1)
+	require.NoError(t, err)
+	got, err := s.GetEntity(ctx, models.TypeText, text.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, got.FolderID)
+
+	err = s.DeleteFolder(ctx, work.ID, 2)
+	assert.ErrorIs(t, err, storage.ErrFolderNotExist)
+	err = s.DeleteFolder(ctx, work.ID, 1)
+	require.NoError(t, err)
+	got, err = s.GetEntity(ctx, models.TypeText, text.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.FolderID)
+
+	folders, err := s.ListFolders(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, folders)
+}
+
+func TestStorage_Tags(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	prod, err := s.CreateTag(ctx, &models.Tag{OwnerID: 1, Name: "prod"})
+	require.NoError(t, err)
+	dev, err := s.CreateTag(ctx, &models.Tag{OwnerID: 1, Name: "dev"})
+	require.NoError(t, err)
+	_, err = s.CreateTag(ctx, &models.Tag{OwnerID: 1, Name: "prod"})
+	assert.ErrorIs(t, err, storage.ErrTagAlreadyExists)
+	_, err = s.RenameTag(ctx, &models.Tag{ID: dev.ID, OwnerID: 1, Name: "prod"})
+	assert.ErrorIs(t, err, storage.ErrTagAlreadyExists)
+
+	both := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "both", TagIDs: []int{prod.ID, dev.ID}}
+	both.ID, err = s.AddEntity(ctx, both)
+	require.NoError(t, err)
+	one := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "one", TagIDs: []int{prod.ID}}
+	one.ID, err = s.AddEntity(ctx, one)
+	require.NoError(t, err)
+	_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, TagIDs: []int{100}})
+	assert.ErrorIs(t, err, storage.ErrTagNotExist)
+
+	res, err := s.ListEntities(ctx, 1, models.ListOptions{TagIDs: []int{prod.ID}})
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+	res, err = s.ListEntities(ctx, 1, models.ListOptions{TagIDs: []int{prod.ID, dev.ID}})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, both.ID, res[0].ID)
+	assert.ElementsMatch(t, []int{prod.ID, dev.ID}, res[0].TagIDs)
+
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: one.ID, OwnerID: 1, TagIDs: []int{dev.ID}},
+		[]models.EntityField{models.FieldTags})
+	require.NoError(t, err)
+	got, err := s.GetEntity(ctx, models.TypeText, one.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{dev.ID}, got.TagIDs)
+	assert.Equal(t, "one", got.Text)
+
+	err = s.DeleteTag(ctx, dev.ID, 1)
+	require.NoError(t, err)
+	got, err = s.GetEntity(ctx, models.TypeText, one.ID, 1)
+	require.NoError(t, err)
+	assert.Empty(t, got.TagIDs)
+	err = s.DeleteTag(ctx, dev.ID, 1)
+	assert.ErrorIs(t, err, storage.ErrTagNotExist)
+
+	tags, err := s.ListTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "prod", tags[0].Name)
+}
+
+func TestStorage_Labels(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	entities := []*models.Entity{
+		{Type: models.TypePassword, OwnerID: 1, Login: "prod", Labels: map[string]string{"env": "prod", "team": "payments"}},
+		{Type: models.TypePassword, OwnerID: 1, Login: "staging", Labels: map[string]string{"env": "staging", "deprecated": ""}},
+		{Type: models.TypeText, OwnerID: 1, Text: "unlabeled"},
+	}
+	for _, e := range entities {
+		e.ID, err = s.AddEntity(ctx, e)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		selector string
+		want     []int
+	}{
+		{"env=prod", []int{entities[0].ID}},
+		{"env in (prod,staging)", []int{entities[0].ID, entities[1].ID}},
+		{"env in (prod,staging),!deprecated", []int{entities[0].ID}},
+		{"env!=prod", []int{entities[1].ID, entities[2].ID}},
+		{"env notin (staging)", []int{entities[0].ID, entities[2].ID}},
+		{"team", []int{entities[0].ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			require.NoError(t, err)
+			res, err := s.ListEntities(ctx, 1, models.ListOptions{Labels: selector})
+			require.NoError(t, err)
+			ids := make([]int, 0, len(res))
+			for _, e := range res {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	upd := &models.Entity{Type: models.TypeText, ID: entities[2].ID, OwnerID: 1, Labels: map[string]string{"env": "prod"}}
+	err = s.UpdateEntity(ctx, upd, []models.EntityField{models.FieldLabels})
+	require.NoError(t, err)
+	got, err := s.GetEntity(ctx, models.TypeText, upd.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, upd.Labels, got.Labels)
+	assert.Equal(t, "unlabeled", got.Text)
+}
+
+func TestStorage_CustomFields(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	custom := []models.CustomField{
+		{Name: "site", Type: models.CustomURL, Value: "https://example.com"},
+		{Name: "pin", Type: models.CustomHidden, Value: "1234"},
+	}
+	pwd := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Custom: custom}
+	pwd.ID, err = s.AddEntity(ctx, pwd)
+	require.NoError(t, err)
+
+	got, err := s.GetEntity(ctx, models.TypePassword, pwd.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, custom, got.Custom)
+
+	upd := &models.Entity{Type: models.TypePassword, ID: pwd.ID, OwnerID: 1, Custom: custom[1:]}
+	err = s.UpdateEntity(ctx, upd, []models.EntityField{models.FieldCustom})
+	require.NoError(t, err)
+	got, err = s.GetEntity(ctx, models.TypePassword, pwd.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, custom[1:], got.Custom)
+	assert.Equal(t, "login", got.Login)
+
+	versions, err := s.ListVersions(ctx, models.TypePassword, pwd.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, custom, versions[0].Entity.Custom)
+}
+
+func Test_searchQuery(t *testing.T) {
+
+	query, args := searchQuery(1, models.SearchOptions{Query: "github", Limit: 20})
+	assert.Equal(t, []any{1, "github", 20}, args)
+	assert.Contains(t, query, "websearch_to_tsquery('simple', $2)")
+	assert.Contains(t, query, "q.raw <% search_text")
+	assert.True(t, strings.HasSuffix(query, "order by rank desc, type, id limit $3"))
+
+	query, args = searchQuery(1, models.SearchOptions{Query: "github", Types: []models.EntityType{models.TypePassword}, Limit: 5})
+	assert.Equal(t, []any{1, "github", []string{"PASSWORD"}, 5}, args)
+	assert.Contains(t, query, "and type = any($3) order by rank desc, type, id limit $4")
+}
+
+func TestStorage_SearchEntities(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	entities := []*models.Entity{
+		{Type: models.TypePassword, OwnerID: 1, Login: "octocat", Password: "secret-github-password", Metadata: "github account"},
+		{Type: models.TypeText, OwnerID: 1, Text: "wifi password for the summer house"},
+		{Type: models.TypeCard, OwnerID: 1, CardOwner: "JOHN DOE", CardNumber: "4111111111111111", CardCVC: "321"},
+		{Type: models.TypePassword, OwnerID: 1, Login: "admin", Custom: []models.CustomField{{Name: "recovery", Type: models.CustomHidden, Value: "hidden-phrase"}}},
+		{Type: models.TypePassword, OwnerID: 2, Login: "octocat", Metadata: "github account"},
+	}
+	for _, e := range entities {
+		e.ID, err = s.AddEntity(ctx, e)
+		require.NoError(t, err)
+	}
+	tag, err := s.CreateTag(ctx, &models.Tag{OwnerID: 1, Name: "household"})
+	require.NoError(t, err)
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: entities[1].ID, OwnerID: 1, TagIDs: []int{tag.ID}},
+		[]models.EntityField{models.FieldTags})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"login", "octocat", []int{entities[0].ID}},
+		{"metadata", "github", []int{entities[0].ID}},
+		{"fuzzy", "octocats", []int{entities[0].ID}},
+		{"text", "summer house", []int{entities[1].ID}},
+		{"tag", "household", []int{entities[1].ID}},
+		{"card owner", "doe", []int{entities[2].ID}},
+		{"custom field name", "recovery", []int{entities[3].ID}},
+		{"password", "secret-github-password", []int{}},
+		{"card number", "4111111111111111", []int{}},
+		{"cvc", "321", []int{}},
+		{"custom field value", "hidden-phrase", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.SearchEntities(ctx, 1, models.SearchOptions{Query: tt.query, Limit: 10})
+			require.NoError(t, err)
+			ids := make([]int, 0, len(res))
+			for _, r := range res {
+				ids = append(ids, r.Entity.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	res, err := s.SearchEntities(ctx, 1, models.SearchOptions{Query: "summer", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Contains(t, res[0].Snippet, "<mark>summer</mark>")
+	assert.Greater(t, res[0].Rank, 0.0)
+}
+
+func TestStorage_Changes(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	listenCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	listening := make(chan struct{})
+	notified := make(chan int, 10)
+	go func() {
+		_ = s.ListenChanges(listenCtx, func() { close(listening) }, func(ownerID int) { notified <- ownerID })
+	}()
+	<-listening
+
+	text := &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "text"}
+	text.ID, err = s.AddEntity(ctx, text)
+	require.NoError(t, err)
+	file := &models.Entity{Type: models.TypeFile, OwnerID: 1, Filename: "file.txt"}
+	file.ID, err = s.AddEntity(ctx, file)
+	require.NoError(t, err)
+	require.NoError(t, s.MarkFileAsUploaded(ctx, file.ID, 1))
+	text.Text = "updated"
+	require.NoError(t, s.UpdateEntity(ctx, text, nil))
+	require.NoError(t, s.DeleteEntity(ctx, models.TypeText, text.ID, 1, 0))
+	require.NoError(t, s.RestoreEntity(ctx, models.TypeText, text.ID, 1))
+	require.NoError(t, s.DeleteEntity(ctx, models.TypeText, text.ID, 1, 0))
+	_, err = s.PurgeEntity(ctx, models.TypeText, text.ID, 1)
+	require.NoError(t, err)
+
+	select {
+	case ownerID := <-notified:
+		assert.Equal(t, 1, ownerID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change notification is not received")
+	}
+
+	log, err := s.ChangeLog(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChangeLog{Last: 7}, log)
+
+	changes, err := s.Changes(ctx, 1, 0, 100)
+	require.NoError(t, err)
+	kinds := make([]models.ChangeKind, 0, len(changes))
+	for i, c := range changes {
+		assert.Equal(t, int64(i+1), c.Seq)
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []models.ChangeKind{models.ChangeCreated, models.ChangeCreated, models.ChangeUpdated,
+		models.ChangeTrashed, models.ChangeRestored, models.ChangeTrashed, models.ChangeDeleted}, kinds)
+	assert.Equal(t, file.ID, changes[1].ID)
+	require.NotNil(t, changes[1].Entity)
+	assert.Equal(t, "file.txt", changes[1].Entity.Filename)
+	assert.Nil(t, changes[6].Entity)
+
+	changes, err = s.Changes(ctx, 1, 5, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, int64(6), changes[0].Seq)
+
+	log, err = s.ChangeLog(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChangeLog{}, log)
+
+	n, err := s.DeleteOldChanges(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	n, err = s.DeleteOldChanges(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+	log, err = s.ChangeLog(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChangeLog{Last: 7, Pruned: 7}, log)
+}
+
+func TestStorage_ChangesFanOut(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	friend, err := s.CreateUser(ctx, "friend@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+	member, err := s.CreateUser(ctx, "member@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	kinds := func(userID int) ([]models.ChangeKind, []*models.Change) {
+		changes, err := s.Changes(ctx, userID, 0, 100)
+		require.NoError(t, err)
+		res := make([]models.ChangeKind, 0, len(changes))
+		for _, c := range changes {
+			res = append(res, c.Kind)
+		}
+		return res, changes
+	}
+
+	// the share recipient gets the changes while the entity is shared
+	id, err := s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "shared"})
+	require.NoError(t, err)
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: 1,
+		Email: "friend@gmail.com", Permission: models.PermissionRead})
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: 1, Text: "updated"},
+		[]models.EntityField{models.FieldText}))
+	require.NoError(t, s.RevokeShare(ctx, models.TypeText, id, 1, "friend@gmail.com"))
+	require.NoError(t, s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: 1, Text: "private"},
+		[]models.EntityField{models.FieldText}))
+
+	got, changes := kinds(friend.ID)
+	assert.Equal(t, []models.ChangeKind{models.ChangeCreated, models.ChangeUpdated, models.ChangeDeleted}, got)
+	assert.Nil(t, changes[2].Entity)
+	got, _ = kinds(1)
+	assert.Equal(t, []models.ChangeKind{models.ChangeCreated, models.ChangeUpdated, models.ChangeUpdated}, got)
+
+	// the vault entity changes are recorded for the current members, not for the creator only
+	o, err := s.CreateOrg(ctx, &models.Org{Name: "team"}, 1)
+	require.NoError(t, err)
+	v, err := s.CreateVault(ctx, &models.Vault{OrgID: o.ID, Name: "prod"}, 1)
+	require.NoError(t, err)
+	vid, err := s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, VaultID: v.ID, Text: "vault"})
+	require.NoError(t, err)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "member@gmail.com", Role: models.RoleEditor}, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: vid, OwnerID: member.ID, Text: "edited"},
+		[]models.EntityField{models.FieldText}))
+
+	got, changes = kinds(member.ID)
+	assert.Equal(t, []models.ChangeKind{models.ChangeCreated, models.ChangeUpdated}, got)
+	require.NotNil(t, changes[1].Entity)
+	assert.Equal(t, "edited", changes[1].Entity.Text)
+	got, _ = kinds(1)
+	assert.Len(t, got, 5)
+
+	// the removed member stops getting the vault changes
+	require.NoError(t, s.RemoveMember(ctx, o.ID, "member@gmail.com", 1))
+	require.NoError(t, s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: vid, OwnerID: 1, Text: "rotated"},
+		[]models.EntityField{models.FieldText}))
+	got, changes = kinds(member.ID)
+	assert.Equal(t, []models.ChangeKind{models.ChangeCreated, models.ChangeUpdated, models.ChangeDeleted}, got)
+	for _, c := range changes {
+		assert.Nil(t, c.Entity)
+	}
+}
+
+func Test_conflictData(t *testing.T) {
+
+	e := &models.Entity{
+		Type:     models.TypePassword,
+		Login:    "login",
+		Password: "password",
+		Metadata: "metadata",
+		FolderID: 3,
+		TagIDs:   []int{2, 1, 2},
+		Labels:   map[string]string{"env": "prod"},
+		Custom:   []models.CustomField{{Name: "pin", Type: models.CustomHidden, Value: "1234"}},
+	}
+	data, err := encodeConflictData(e)
+	require.NoError(t, err)
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	got := &models.Entity{Type: models.TypePassword}
+	require.NoError(t, decodeConflictData(raw, got))
+	e.TagIDs = []int{1, 2}
+	assert.Equal(t, e, got)
+
+	data, err = encodeConflictData(&models.Entity{Type: models.TypeText, Text: "text"})
+	require.NoError(t, err)
+	raw, err = json.Marshal(data)
+	require.NoError(t, err)
+	got = &models.Entity{Type: models.TypeText}
+	require.NoError(t, decodeConflictData(raw, got))
+	assert.Equal(t, &models.Entity{Type: models.TypeText, Text: "text"}, got)
+}
+
+func TestStorage_Conflicts(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	e := &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "login", Password: "password"}
+	e.ID, err = s.AddEntity(ctx, e)
+	require.NoError(t, err)
+
+	client := &models.Entity{ID: e.ID, OwnerID: 1, Type: models.TypePassword, Login: "login", Password: "client"}
+	c := &models.Conflict{
+		OwnerID:           1,
+		Type:              models.TypePassword,
+		EntityID:          e.ID,
+		BaseRevision:      1,
+		ServerRevision:    2,
+		Fields:            []models.EntityField{models.FieldPassword},
+		ConflictingFields: []models.EntityField{models.FieldPassword},
+		Client:            client,
+	}
+	c.ID, err = s.AddConflict(ctx, c)
+	require.NoError(t, err)
+
+	got, err := s.GetConflict(ctx, c.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []models.EntityField{models.FieldPassword}, got.ConflictingFields)
+	assert.Equal(t, "client", got.Client.Password)
+	assert.Equal(t, 2, got.ServerRevision)
+
+	_, err = s.GetConflict(ctx, c.ID, 2)
+	assert.ErrorIs(t, err, storage.ErrConflictNotExist)
+
+	_, err = s.AddConflict(ctx, &models.Conflict{OwnerID: 2, Type: models.TypePassword, EntityID: e.ID, Client: client})
+	assert.ErrorIs(t, err, storage.ErrPasswordNotExist)
+
+	conflicts, err := s.ListConflicts(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+
+	require.NoError(t, s.DeleteConflict(ctx, c.ID, 1))
+	assert.ErrorIs(t, s.DeleteConflict(ctx, c.ID, 1), storage.ErrConflictNotExist)
+
+	// the conflicts are deleted with the purged entity
+	c.ID, err = s.AddConflict(ctx, c)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteEntity(ctx, models.TypePassword, e.ID, 1, 0))
+	_, err = s.PurgeEntity(ctx, models.TypePassword, e.ID, 1)
+	require.NoError(t, err)
+	conflicts, err = s.ListConflicts(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestStorage_Devices(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	d, err := s.AddDevice(ctx, &models.Device{UserID: 1, Name: "laptop", PublicKey: []byte("key")})
+	require.NoError(t, err)
+	assert.Equal(t, "laptop", d.Name)
+
+	ok, err := s.DeviceExists(ctx, d.ID, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.DeviceExists(ctx, d.ID, 2)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	got, err := s.GetDevice(ctx, d.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), got.PublicKey)
+	_, err = s.GetDevice(ctx, d.ID, 2)
+	assert.ErrorIs(t, err, storage.ErrDeviceNotExist)
+
+	logged, err := s.LoginDevice(ctx, d.ID, 1)
+	require.NoError(t, err)
+	assert.False(t, logged.LastLoginAt.Before(d.LastLoginAt))
+	_, err = s.LoginDevice(ctx, d.ID, 2)
+	assert.ErrorIs(t, err, storage.ErrDeviceNotExist)
+
+	devices, err := s.ListDevices(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, devices, 1)
+
+	require.NoError(t, s.DeleteDevice(ctx, d.ID, 1))
+	assert.ErrorIs(t, s.DeleteDevice(ctx, d.ID, 1), storage.ErrDeviceNotExist)
+	ok, err = s.DeviceExists(ctx, d.ID, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// the challenge is used once
+	require.NoError(t, s.AddChallenge(ctx, []byte("nonce"), time.Now().Add(time.Minute)))
+	require.NoError(t, s.UseChallenge(ctx, []byte("nonce")))
+	assert.ErrorIs(t, s.UseChallenge(ctx, []byte("nonce")), storage.ErrChallengeNotExist)
+	require.NoError(t, s.AddChallenge(ctx, []byte("expired"), time.Now().Add(-time.Minute)))
+	assert.ErrorIs(t, s.UseChallenge(ctx, []byte("expired")), storage.ErrChallengeNotExist)
+}
+
+func TestStorage_Shares(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	friend, err := s.CreateUser(ctx, "friend@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	id, err := s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "shared"})
+	require.NoError(t, err)
+
+	_, err = s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: 1,
+		Email: "testmail@gmail.com", Permission: models.PermissionRead})
+	assert.ErrorIs(t, err, storage.ErrShareWithOwner)
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: 1,
+		Email: "unknown@gmail.com", Permission: models.PermissionRead})
+	assert.ErrorIs(t, err, storage.ErrUserNotExist)
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: friend.ID,
+		Email: "testmail@gmail.com", Permission: models.PermissionRead})
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	sh, err := s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: 1,
+		Email: "friend@gmail.com", Permission: models.PermissionRead})
+	require.NoError(t, err)
+	assert.Equal(t, friend.ID, sh.UserID)
+
+	e, err := s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PermissionRead, e.Permission)
+	assert.Equal(t, 1, e.OwnerID)
+	e, err = s.GetEntity(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	assert.Empty(t, e.Permission)
+
+	list, err := s.ListEntities(ctx, friend.ID, models.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, models.PermissionRead, list[0].Permission)
+
+	// the read permission does not allow updates
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: friend.ID, Text: "updated"},
+		[]models.EntityField{models.FieldText})
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypeText, EntityID: id, OwnerID: 1,
+		Email: "friend@gmail.com", Permission: models.PermissionWrite})
+	require.NoError(t, err)
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: friend.ID, Text: "updated"},
+		[]models.EntityField{models.FieldText})
+	require.NoError(t, err)
+	// only the owner organizes the entity
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: friend.ID, Labels: map[string]string{"a": "b"}},
+		[]models.EntityField{models.FieldLabels})
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+	assert.ErrorIs(t, s.DeleteEntity(ctx, models.TypeText, id, friend.ID, 0), storage.ErrTextNotExist)
+
+	versions, err := s.ListVersions(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+
+	shares, err := s.ListShares(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.Equal(t, "friend@gmail.com", shares[0].Email)
+	assert.Equal(t, models.PermissionWrite, shares[0].Permission)
+	_, err = s.ListShares(ctx, models.TypeText, id, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	require.NoError(t, s.RevokeShare(ctx, models.TypeText, id, 1, "friend@gmail.com"))
+	assert.ErrorIs(t, s.RevokeShare(ctx, models.TypeText, id, 1, "friend@gmail.com"), storage.ErrShareNotExist)
+	_, err = s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+}
+
+func TestStorage_Orgs(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	editor, err := s.CreateUser(ctx, "editor@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+	viewer, err := s.CreateUser(ctx, "viewer@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+	outsider, err := s.CreateUser(ctx, "outsider@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	o, err := s.CreateOrg(ctx, &models.Org{Name: "team"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleOwner, o.Role)
+
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "editor@gmail.com", Role: models.RoleEditor}, 1)
+	require.NoError(t, err)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "viewer@gmail.com", Role: models.RoleViewer}, 1)
+	require.NoError(t, err)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "viewer@gmail.com", Role: models.RoleViewer}, 1)
+	assert.ErrorIs(t, err, storage.ErrMemberAlreadyExists)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "unknown@gmail.com", Role: models.RoleViewer}, 1)
+	assert.ErrorIs(t, err, storage.ErrUserNotExist)
+
+	// the role is checked within the change
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "outsider@gmail.com", Role: models.RoleViewer}, editor.ID)
+	assert.ErrorIs(t, err, storage.ErrForbidden)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "outsider@gmail.com", Role: models.RoleViewer}, outsider.ID)
+	assert.ErrorIs(t, err, storage.ErrOrgNotExist)
+	_, err = s.UpdateMember(ctx, &models.Member{OrgID: o.ID, Email: "viewer@gmail.com", Role: models.RoleEditor}, editor.ID)
+	assert.ErrorIs(t, err, storage.ErrForbidden)
+	assert.ErrorIs(t, s.RemoveMember(ctx, o.ID, "viewer@gmail.com", editor.ID), storage.ErrForbidden)
+	_, err = s.CreateVault(ctx, &models.Vault{OrgID: o.ID, Name: "prod"}, editor.ID)
+	assert.ErrorIs(t, err, storage.ErrForbidden)
+	assert.ErrorIs(t, s.DeleteOrg(ctx, o.ID, editor.ID), storage.ErrForbidden)
+
+	role, err := s.MemberRole(ctx, o.ID, viewer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleViewer, role)
+	_, err = s.MemberRole(ctx, o.ID, outsider.ID)
+	assert.ErrorIs(t, err, storage.ErrOrgNotExist)
+
+	v, err := s.CreateVault(ctx, &models.Vault{OrgID: o.ID, Name: "prod"}, 1)
+	require.NoError(t, err)
+	_, err = s.CreateVault(ctx, &models.Vault{OrgID: o.ID, Name: "prod"}, 1)
+	assert.ErrorIs(t, err, storage.ErrVaultAlreadyExists)
+
+	_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: viewer.ID, VaultID: v.ID, Text: "secret"})
+	assert.ErrorIs(t, err, storage.ErrForbidden)
+	_, err = s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: outsider.ID, VaultID: v.ID, Text: "secret"})
+	assert.ErrorIs(t, err, storage.ErrVaultNotExist)
+	id, err := s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: editor.ID, VaultID: v.ID, Text: "secret"})
+	require.NoError(t, err)
+
+	// the members see the vault entities, the viewers read them only
+	e, err := s.GetEntity(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, v.ID, e.VaultID)
+	assert.Empty(t, e.Permission)
+	e, err = s.GetEntity(ctx, models.TypeText, id, viewer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PermissionRead, e.Permission)
+	_, err = s.GetEntity(ctx, models.TypeText, id, outsider.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	list, err := s.ListEntities(ctx, viewer.ID, models.ListOptions{VaultID: v.ID})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: viewer.ID, Text: "updated"},
+		[]models.EntityField{models.FieldText})
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+	err = s.UpdateEntity(ctx, &models.Entity{Type: models.TypeText, ID: id, OwnerID: 1, Text: "updated"},
+		[]models.EntityField{models.FieldText})
+	require.NoError(t, err)
+
+	// the organization keeps an owner
+	_, err = s.UpdateMember(ctx, &models.Member{OrgID: o.ID, Email: "testmail@gmail.com", Role: models.RoleAdmin}, 1)
+	assert.ErrorIs(t, err, storage.ErrLastOwner)
+	assert.ErrorIs(t, s.RemoveMember(ctx, o.ID, "testmail@gmail.com", 1), storage.ErrLastOwner)
+
+	require.NoError(t, s.RemoveMember(ctx, o.ID, "viewer@gmail.com", 1))
+	_, err = s.GetEntity(ctx, models.TypeText, id, viewer.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	members, err := s.ListMembers(ctx, o.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// the history is kept for the members after the entity creator leaves
+	require.NoError(t, s.RemoveMember(ctx, o.ID, "editor@gmail.com", 1))
+	versions, err := s.ListVersions(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+	versions, err = s.ListVersions(ctx, models.TypeText, id, editor.ID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	assert.ErrorIs(t, s.DeleteVault(ctx, v.ID, o.ID, 1), storage.ErrVaultNotEmpty)
+	assert.ErrorIs(t, s.DeleteOrg(ctx, o.ID, 1), storage.ErrOrgNotEmpty)
+	require.NoError(t, s.DeleteEntity(ctx, models.TypeText, id, 1, 0))
+	_, err = s.PurgeEntity(ctx, models.TypeText, id, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteVault(ctx, v.ID, o.ID, 1))
+	require.NoError(t, s.DeleteOrg(ctx, o.ID, 1))
+}
+
+func TestStorage_Keys(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	friend, err := s.CreateUser(ctx, "friend@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	pub := make([]byte, 32)
+	_, err = s.UserKeys(ctx, 1)
+	assert.ErrorIs(t, err, storage.ErrUserKeysNotExist)
+	k, err := s.SetUserKeys(ctx, &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: []byte("wrapped")})
+	require.NoError(t, err)
+	assert.Equal(t, "testmail@gmail.com", k.Email)
+	// the private key is re-wrapped, the public key stays
+	_, err = s.SetUserKeys(ctx, &models.UserKeys{UserID: 1, PublicKey: pub, WrappedPrivateKey: []byte("rewrapped")})
+	require.NoError(t, err)
+	_, err = s.SetUserKeys(ctx, &models.UserKeys{UserID: 1, PublicKey: []byte("other"), WrappedPrivateKey: []byte("wrapped")})
+	assert.ErrorIs(t, err, storage.ErrPublicKeyMismatch)
+	k, err = s.UserKeys(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("rewrapped"), k.WrappedPrivateKey)
+	k, err = s.PublicKey(ctx, "testmail@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, pub, k.PublicKey)
+	assert.Nil(t, k.WrappedPrivateKey)
+
+	o, err := s.CreateOrg(ctx, &models.Org{Name: "team"}, 1)
+	require.NoError(t, err)
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "friend@gmail.com", Role: models.RoleViewer}, 1)
+	require.NoError(t, err)
+	v, err := s.CreateVault(ctx, &models.Vault{OrgID: o.ID, Name: "prod"}, 1)
+	require.NoError(t, err)
+
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 2, []*models.VaultKey{{Email: "testmail@gmail.com", EncryptedKey: []byte("k1")}}, 1)
+	assert.ErrorIs(t, err, storage.ErrVaultKeyVersion)
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 1, []*models.VaultKey{{Email: "testmail@gmail.com", EncryptedKey: []byte("k1")}}, 1)
+	assert.ErrorIs(t, err, storage.ErrVaultKeysIncomplete)
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 1, []*models.VaultKey{{Email: "unknown@gmail.com", EncryptedKey: []byte("k1")}}, 1)
+	assert.ErrorIs(t, err, storage.ErrMemberNotExist)
+	// the viewer does not distribute the keys
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 1, []*models.VaultKey{{Email: "friend@gmail.com", EncryptedKey: []byte("k1")}}, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrForbidden)
+	v, err = s.SetVaultKeys(ctx, v.ID, o.ID, 1, []*models.VaultKey{
+		{Email: "testmail@gmail.com", EncryptedKey: []byte("k1")},
+		{Email: "friend@gmail.com", EncryptedKey: []byte("k1-friend")},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v.KeyVersion)
+
+	keys, err := s.ListVaultKeys(ctx, v.ID, o.ID, friend.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, []byte("k1-friend"), keys[0].EncryptedKey)
+
+	// the removed member loses the keys and the vault has to be re-keyed
+	require.NoError(t, s.RemoveMember(ctx, o.ID, "friend@gmail.com", 1))
+	keys, err = s.ListVaultKeys(ctx, v.ID, o.ID, friend.ID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	vaults, err := s.ListVaults(ctx, o.ID)
+	require.NoError(t, err)
+	require.Len(t, vaults, 1)
+	assert.True(t, vaults[0].RekeyRequired)
+
+	// the compromised key is not distributed to the new members, only the rotation is accepted
+	_, err = s.AddMember(ctx, &models.Member{OrgID: o.ID, Email: "friend@gmail.com", Role: models.RoleViewer}, 1)
+	require.NoError(t, err)
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 1, []*models.VaultKey{{Email: "friend@gmail.com", EncryptedKey: []byte("k1-friend")}}, 1)
+	assert.ErrorIs(t, err, storage.ErrVaultRekeyRequired)
+	_, err = s.SetVaultKeys(ctx, v.ID, o.ID, 2, []*models.VaultKey{{Email: "testmail@gmail.com", EncryptedKey: []byte("k2")}}, 1)
+	assert.ErrorIs(t, err, storage.ErrVaultKeysIncomplete)
+	require.NoError(t, s.RemoveMember(ctx, o.ID, "friend@gmail.com", 1))
+
+	v, err = s.SetVaultKeys(ctx, v.ID, o.ID, 2, []*models.VaultKey{{Email: "testmail@gmail.com", EncryptedKey: []byte("k2")}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v.KeyVersion)
+	assert.False(t, v.RekeyRequired)
+	keys, err = s.ListVaultKeys(ctx, v.ID, o.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestStorage_Sends(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	sd, err := s.AddSend(ctx, &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret"), MaxViews: 2,
+		ExpiresAt: time.Now().Add(time.Hour)}, []byte("hash"))
+	require.NoError(t, err)
+	assert.Empty(t, sd.PasswordHash)
+	_, err = s.AddSend(ctx, &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("expired"), MaxViews: 1,
+		ExpiresAt: time.Now().Add(-time.Minute)}, []byte("expired"))
+	require.NoError(t, err)
+
+	_, err = s.SendByToken(ctx, []byte("expired"))
+	assert.ErrorIs(t, err, storage.ErrSendNotExist)
+	sends, err := s.ListSends(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, sends, 1)
+
+	got, err := s.SendByToken(ctx, []byte("hash"))
+	require.NoError(t, err)
+	assert.Equal(t, sd.ID, got.ID)
+	assert.Nil(t, got.Payload)
+
+	viewed, err := s.ViewSend(ctx, sd.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, viewed.Views)
+	assert.Equal(t, []byte("secret"), viewed.Payload)
+
+	// the send is destroyed after the last view
+	viewed, err = s.ViewSend(ctx, sd.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, viewed.Views)
+	_, err = s.ViewSend(ctx, sd.ID)
+	assert.ErrorIs(t, err, storage.ErrSendNotExist)
+	_, err = s.SendByToken(ctx, []byte("hash"))
+	assert.ErrorIs(t, err, storage.ErrSendNotExist)
+
+	n, err := s.DeleteExpiredSends(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	sd, err = s.AddSend(ctx, &models.Send{OwnerID: 1, Kind: models.SendFile, Filename: "key.pem", Payload: []byte("secret"),
+		PasswordHash: []byte("bcrypt"), MaxViews: 1, ExpiresAt: time.Now().Add(time.Hour)}, []byte("file"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bcrypt"), sd.PasswordHash)
+
+	// the send is destroyed after the failed attempts limit
+	deleted, err := s.FailSendAttempt(ctx, sd.ID, 2)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	deleted, err = s.FailSendAttempt(ctx, sd.ID, 2)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = s.SendByToken(ctx, []byte("file"))
+	assert.ErrorIs(t, err, storage.ErrSendNotExist)
+	_, err = s.FailSendAttempt(ctx, sd.ID, 2)
+	assert.ErrorIs(t, err, storage.ErrSendNotExist)
+
+	sd, err = s.AddSend(ctx, &models.Send{OwnerID: 1, Kind: models.SendText, Payload: []byte("secret"),
+		MaxViews: 1, ExpiresAt: time.Now().Add(time.Hour)}, []byte("deleted"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.DeleteSend(ctx, sd.ID, 2), storage.ErrSendNotExist)
+	require.NoError(t, s.DeleteSend(ctx, sd.ID, 1))
+}
+
+func TestStorage_Emergency(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	friend, err := s.CreateUser(ctx, "friend@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	id, err := s.AddEntity(ctx, &models.Entity{Type: models.TypeText, OwnerID: 1, Text: "will"})
+	require.NoError(t, err)
+
+	_, err = s.InviteEmergencyContact(ctx, &models.EmergencyContact{GrantorID: 1, GranteeEmail: "testmail@gmail.com", WaitPeriod: time.Hour})
+	assert.ErrorIs(t, err, storage.ErrEmergencyContactSelf)
+	_, err = s.InviteEmergencyContact(ctx, &models.EmergencyContact{GrantorID: 1, GranteeEmail: "unknown@gmail.com", WaitPeriod: time.Hour})
+	assert.ErrorIs(t, err, storage.ErrUserNotExist)
+
+	c, err := s.InviteEmergencyContact(ctx, &models.EmergencyContact{GrantorID: 1, GranteeEmail: "friend@gmail.com", WaitPeriod: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, friend.ID, c.GranteeID)
+	assert.Equal(t, models.EmergencyInvited, c.Status)
+	assert.Equal(t, time.Hour, c.WaitPeriod)
+	_, err = s.InviteEmergencyContact(ctx, &models.EmergencyContact{GrantorID: 1, GranteeEmail: "friend@gmail.com", WaitPeriod: time.Hour})
+	assert.ErrorIs(t, err, storage.ErrEmergencyContactAlreadyExists)
+
+	transition := func(actor int, a models.EmergencyAction, from, to models.EmergencyStatus) (*models.EmergencyContact, error) {
+		return s.TransitionEmergencyContact(ctx, &models.EmergencyEvent{ContactID: c.ID, ActorID: actor, Action: a, From: from, To: to})
+	}
+	_, err = transition(friend.ID, models.EmergencyAccept, models.EmergencyInvited, models.EmergencyAccepted)
+	require.NoError(t, err)
+	// the stale status is not transitioned
+	_, err = transition(friend.ID, models.EmergencyAccept, models.EmergencyInvited, models.EmergencyAccepted)
+	assert.ErrorIs(t, err, storage.ErrEmergencyTransition)
+	c, err = transition(friend.ID, models.EmergencyRequest, models.EmergencyAccepted, models.EmergencyRequested)
+	require.NoError(t, err)
+	assert.False(t, c.RequestedAt.IsZero())
+	_, err = transition(1, models.EmergencyReject, models.EmergencyRequested, models.EmergencyAccepted)
+	require.NoError(t, err)
+	_, err = transition(friend.ID, models.EmergencyRequest, models.EmergencyAccepted, models.EmergencyRequested)
+	require.NoError(t, err)
+
+	// the request is granted after the wait period only
+	n, err := s.GrantEmergencyRequests(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	_, err = s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	n, err = s.GrantEmergencyRequests(ctx, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	c, err = s.GetEmergencyContact(ctx, c.ID, friend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyGranted, c.Status)
+	_, err = s.GetEmergencyContact(ctx, c.ID, 100)
+	assert.ErrorIs(t, err, storage.ErrEmergencyContactNotExist)
+
+	e, err := s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PermissionRead, e.Permission)
+	assert.Equal(t, "will", e.Text)
+
+	contacts, err := s.ListEmergencyContacts(ctx, friend.ID)
+	require.NoError(t, err)
+	assert.Len(t, contacts, 1)
+
+	_, err = transition(1, models.EmergencyRevoke, models.EmergencyGranted, models.EmergencyRevoked)
+	require.NoError(t, err)
+	_, err = s.GetEntity(ctx, models.TypeText, id, friend.ID)
+	assert.ErrorIs(t, err, storage.ErrTextNotExist)
+
+	events, err := s.ListEmergencyEvents(ctx, c.ID)
+	require.NoError(t, err)
+	actions := make([]models.EmergencyAction, 0, len(events))
+	for _, ev := range events {
+		actions = append(actions, ev.Action)
+	}
+	assert.Equal(t, []models.EmergencyAction{models.EmergencyInvite, models.EmergencyAccept, models.EmergencyRequest,
+		models.EmergencyReject, models.EmergencyRequest, models.EmergencyGrant, models.EmergencyRevoke}, actions)
+	assert.Equal(t, 0, events[5].ActorID)
+	assert.Equal(t, friend.ID, events[1].ActorID)
+
+	// the revoked contact is invited again
+	c, err = s.InviteEmergencyContact(ctx, &models.EmergencyContact{GrantorID: 1, GranteeEmail: "friend@gmail.com", WaitPeriod: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyInvited, c.Status)
+	assert.Equal(t, time.Minute, c.WaitPeriod)
+}
+
+func TestStorage_AccessRequests(t *testing.T) {
+
+	ctx := context.Background()
+
+	cfg, err := parseTestConfig()
+	require.NoError(t, err)
+
+	s, err := setupStorage(cfg, true)
+	defer cleanup(cfg)
+	require.NoError(t, err)
+
+	friend, err := s.CreateUser(ctx, "friend@gmail.com", []byte("hash"))
+	require.NoError(t, err)
+
+	id, err := s.AddEntity(ctx, &models.Entity{Type: models.TypePassword, OwnerID: 1, Login: "root", Password: "prod"})
+	require.NoError(t, err)
+	_, err = s.ShareEntity(ctx, &models.Share{Type: models.TypePassword, EntityID: id, OwnerID: 1,
+		Email: "friend@gmail.com", Permission: models.PermissionRead})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, s.SetApprovalRequired(ctx, models.TypePassword, id+1, 1, true), storage.ErrPasswordNotExist)
+	require.NoError(t, s.SetApprovalRequired(ctx, models.TypePassword, id, 1, true))
+	// setting the same value is neither audited nor recorded
+	require.NoError(t, s.SetApprovalRequired(ctx, models.TypePassword, id, 1, true))
+
+	e, err := s.GetEntity(ctx, models.TypePassword, id, friend.ID)
+	require.NoError(t, err)
+	assert.True(t, e.RequiresApproval)
+	assert.Equal(t, 2, e.Revision)
+	assert.False(t, e.Approver)
+	e, err = s.GetEntity(ctx, models.TypePassword, id, 1)
+	require.NoError(t, err)
+	assert.True(t, e.Approver)
+
+	r, err := s.AddAccessRequest(ctx, &models.AccessRequest{Type: models.TypePassword, EntityID: id, RequesterID: friend.ID, Reason: "incident"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessPending, r.Status)
+	assert.Equal(t, "friend@gmail.com", r.RequesterEmail)
+	_, err = s.AddAccessRequest(ctx, &models.AccessRequest{Type: models.TypePassword, EntityID: id, RequesterID: friend.ID})
+	assert.ErrorIs(t, err, storage.ErrAccessRequestAlreadyExists)
+
+	requests, err := s.ListAccessRequests(ctx, 1, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+	requests, err = s.ListAccessRequests(ctx, friend.ID, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+
+	_, err = s.ActiveAccessRequest(ctx, models.TypePassword, id, friend.ID, time.Now())
+	assert.ErrorIs(t, err, storage.ErrAccessRequestNotExist)
+
+	expires := time.Now().Add(time.Hour)
+	r, err = s.DecideAccessRequest(ctx, &models.AccessRequest{ID: r.ID, ApproverID: 1, Status: models.AccessApproved, ExpiresAt: expires})
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessApproved, r.Status)
+	assert.Equal(t, 1, r.ApproverID)
+	_, err = s.DecideAccessRequest(ctx, &models.AccessRequest{ID: r.ID, ApproverID: 1, Status: models.AccessDenied})
+	assert.ErrorIs(t, err, storage.ErrAccessRequestDecided)
+
+	// the approver has no pending requests, the requester sees the approved one until it expires
+	requests, err = s.ListAccessRequests(ctx, 1, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, requests, 0)
+	requests, err = s.ListAccessRequests(ctx, friend.ID, expires.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, requests, 0)
+
+	active, err := s.ActiveAccessRequest(ctx, models.TypePassword, id, friend.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, r.ID, active.ID)
+	_, err = s.ActiveAccessRequest(ctx, models.TypePassword, id, friend.ID, expires.Add(time.Minute))
+	assert.ErrorIs(t, err, storage.ErrAccessRequestNotExist)
+
+	require.NoError(t, s.AddAccessEvent(ctx, &models.AccessEvent{Type: models.TypePassword, EntityID: id, RequestID: r.ID,
+		ActorID: friend.ID, Action: models.AccessViewed}))
+	events, err := s.ListAccessEvents(ctx, models.TypePassword, id)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, models.AccessRequired, events[0].Action)
+	assert.Equal(t, 1, events[0].ActorID)
+	assert.Equal(t, models.AccessRequested, events[1].Action)
+	assert.Equal(t, models.AccessGranted, events[2].Action)
+	assert.Equal(t, 1, events[2].ActorID)
+	assert.Equal(t, models.AccessViewed, events[3].Action)
+	assert.Equal(t, r.ID, events[3].RequestID)
+
+	changes, err := s.Changes(ctx, friend.ID, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.ChangeUpdated, changes[1].Kind)
+	assert.Equal(t, 2, changes[1].Revision)
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/avast/retry-go/v4"
+
+	"github.com/vindosVP/go-pass/internal/models"
+)
+
+// headlineOptions mark the matches in the search snippets.
+const headlineOptions = `StartSel="<mark>", StopSel="</mark>", MaxWords=20, MinWords=5, MaxFragments=2, FragmentDelimiter=" ... "`
+
+// SearchEntities returns the user`s entities and the entities shared with the user matching the search query,
+// the best matches first.
+//
+// The query is matched by the full-text search against the search_text column, which never contains
+// the secrets, and by the trigram word similarity for the fuzzy matches. The entities are also found
+// by the names of their tags. The trashed entities and the files being uploaded are not searched.
+func (s *Storage) SearchEntities(ctx context.Context, ownerID int, opts models.SearchOptions) ([]*models.SearchResult, error) {
+	return retry.DoWithData(func() ([]*models.SearchResult, error) {
+		query, args := searchQuery(ownerID, opts)
+		rows, err := s.db.Query(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		results := make([]*models.SearchResult, 0)
+		for rows.Next() {
+			r := &models.SearchResult{}
+			r.Entity, err = scanSharedEntity(rows, &r.Snippet, &r.Rank)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, r)
+		}
+		return results, rows.Err()
+	}, retryOpts()...)
+}
+
+func searchQuery(ownerID int, opts models.SearchOptions) (string, []any) {
+	var b strings.Builder
+	args := []any{ownerID, opts.Query}
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	b.WriteString(`with q as (select websearch_to_tsquery('simple', $2) as tsq, $2::text as raw)
+			  select
+    			` + entityColumns + `, ` + sharedColumns("$1") + `,
+    			ts_headline('simple', search_text, q.tsq, '` + headlineOptions + `'),
+    			ts_rank(to_tsvector('simple', search_text), q.tsq) + word_similarity(q.raw, search_text) + coalesce(tag_match.rank, 0) as rank
+    		  from
+    			entities
+    			cross join q
+    			left join lateral (
+    			  select max(similarity(t.name, q.raw)) as rank
+    			  from entity_tags et join tags t on t.id = et.tag_id
+    			  where et.entity_type = entities.type and et.entity_id = entities.id
+    			    and (to_tsvector('simple', t.name) @@ q.tsq or t.name % q.raw)
+    			) tag_match on true
+    		  where
+    			` + accessCondition("$1", models.PermissionRead) + ` and deleted_at is null and not data @> '{"uploaded": false}'
+    			and (to_tsvector('simple', search_text) @@ q.tsq or q.raw <% search_text or tag_match.rank is not null)`)
+
+	if len(opts.Types) > 0 {
+		types := make([]string, 0, len(opts.Types))
+		for _, t := range opts.Types {
+			types = append(types, string(t))
+		}
+		b.WriteString(" and type = any(" + arg(types) + ")")
+	}
+	b.WriteString(" order by rank desc, type, id limit " + arg(opts.Limit))
+
+	return b.String(), args
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// sendColumns are the columns scanned by scanSend, the payload is selected only when the send is viewed.
+const sendColumns = "id, owner_id, kind, filename, coalesce(password_hash, ''), max_views, views, expires_at, created_at"
+
+func scanSend(row pgx.Row, extra ...any) (*models.Send, error) {
+	s := &models.Send{}
+	dest := []any{&s.ID, &s.OwnerID, &s.Kind, &s.Filename, &s.PasswordHash, &s.MaxViews, &s.Views, &s.ExpiresAt, &s.CreatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddSend stores the send redeemed by the token with the hash.
+func (s *Storage) AddSend(ctx context.Context, sd *models.Send, tokenHash []byte) (*models.Send, error) {
+	return retry.DoWithData(func() (*models.Send, error) {
+		query := `insert into sends (owner_id, token_hash, kind, filename, payload, password_hash, max_views, expires_at, created_at)
+					values ($1, $2, $3, $4, $5, nullif($6, ''::bytea), $7, $8, $9) returning ` + sendColumns
+		return scanSend(s.db.QueryRow(ctx, query, sd.OwnerID, tokenHash, sd.Kind, sd.Filename, sd.Payload, sd.PasswordHash,
+			sd.MaxViews, sd.ExpiresAt, time.Now()))
+	}, retryOpts()...)
+}
+
+// SendByToken returns the not expired send redeemed by the token with the hash, the payload is not set.
+func (s *Storage) SendByToken(ctx context.Context, tokenHash []byte) (*models.Send, error) {
+	return retry.DoWithData(func() (*models.Send, error) {
+		query := "select " + sendColumns + " from sends where token_hash = $1 and expires_at > $2 and views < max_views"
+		sd, err := scanSend(s.db.QueryRow(ctx, query, tokenHash, time.Now()))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, storage.ErrSendNotExist
+			}
+			return nil, err
+		}
+		return sd, nil
+	}, retryOpts()...)
+}
+
+// ViewSend counts the send view and returns the send with the payload,
+// the send is deleted after the last view.
+func (s *Storage) ViewSend(ctx context.Context, id int) (*models.Send, error) {
+	return retry.DoWithData(func() (*models.Send, error) {
+		var res *models.Send
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			query := `update sends set views = views + 1
+					  where id = $1 and expires_at > $2 and views < max_views
+					  returning ` + sendColumns + `, payload`
+			var payload []byte
+			sd, err := scanSend(tx.QueryRow(ctx, query, id, time.Now()), &payload)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrSendNotExist
+				}
+				return err
+			}
+			sd.Payload = payload
+			if sd.Views >= sd.MaxViews {
+				if _, err := tx.Exec(ctx, "delete from sends where id = $1", id); err != nil {
+					return err
+				}
+			}
+			res = sd
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}, retryOpts()...)
+}
+
+// FailSendAttempt counts the failed password attempt of the send and reports whether the send is deleted,
+// the send is deleted once the limit of the failed attempts is reached.
+func (s *Storage) FailSendAttempt(ctx context.Context, id int, limit int) (bool, error) {
+	return retry.DoWithData(func() (bool, error) {
+		var deleted bool
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			var attempts int
+			query := "update sends set failed_attempts = failed_attempts + 1 where id = $1 returning failed_attempts"
+			if err := tx.QueryRow(ctx, query, id).Scan(&attempts); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrSendNotExist
+				}
+				return err
+			}
+			if attempts < limit {
+				return nil
+			}
+			if _, err := tx.Exec(ctx, "delete from sends where id = $1", id); err != nil {
+				return err
+			}
+			deleted = true
+			return nil
+		})
+		return deleted, err
+	}, retryOpts()...)
+}
+
+// ListSends returns the owner`s sends which are not expired yet in the creation order, the payloads are not set.
+func (s *Storage) ListSends(ctx context.Context, ownerID int) ([]*models.Send, error) {
+	return retry.DoWithData(func() ([]*models.Send, error) {
+		query := "select " + sendColumns + " from sends where owner_id = $1 and expires_at > $2 order by id"
+		rows, err := s.db.Query(ctx, query, ownerID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		sends := make([]*models.Send, 0)
+		for rows.Next() {
+			sd, err := scanSend(rows)
+			if err != nil {
+				return nil, err
+			}
+			sends = append(sends, sd)
+		}
+		return sends, rows.Err()
+	}, retryOpts()...)
+}
+
+// DeleteSend deletes the owner`s send.
+func (s *Storage) DeleteSend(ctx context.Context, id int, ownerID int) error {
+	return retry.Do(func() error {
+		tag, err := s.db.Exec(ctx, "delete from sends where id = $1 and owner_id = $2", id, ownerID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrSendNotExist
+		}
+		return nil
+	}, retryOpts()...)
+}
+
+// DeleteExpiredSends deletes the sends expired before the provided time and returns the number of deleted sends.
+func (s *Storage) DeleteExpiredSends(ctx context.Context, before time.Time) (int64, error) {
+	return retry.DoWithData(func() (int64, error) {
+		tag, err := s.db.Exec(ctx, "delete from sends where expires_at <= $1", before)
+		if err != nil {
+			return 0, err
+		}
+		return tag.RowsAffected(), nil
+	}, retryOpts()...)
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vindosVP/go-pass/internal/models"
+	"github.com/vindosVP/go-pass/internal/storage"
+)
+
+// accessCondition returns the condition selecting the entities the user referenced by the placeholder
+// has the permission to. The personal entities are accessed by the owner, who has any permission, by the users
+// they are shared with and are read by the owner`s emergency contacts granted the access. The vault entities
+// are accessed by the organization members: the viewers read them, the other roles have any permission.
+// Only the owner and the vault editors match if the permission is empty.
+func accessCondition(user string, p models.Permission) string {
+	personal := "owner_id = " + user
+	if p != "" {
+		shared := "select 1 from entity_shares where entity_type = entities.type and entity_id = entities.id and user_id = " + user
+		if p == models.PermissionWrite {
+			shared += " and permission = '" + string(models.PermissionWrite) + "'"
+		}
+		personal += " or exists(" + shared + ")"
+		if p == models.PermissionRead {
+			personal += " or exists(select 1 from emergency_contacts where grantor_id = entities.owner_id and grantee_id = " + user +
+				" and status = '" + string(models.EmergencyGranted) + "')"
+		}
+		personal = "(" + personal + ")"
+	}
+	members := "select v.id from vaults v join org_members m on m.org_id = v.org_id where m.user_id = " + user
+	if p != models.PermissionRead {
+		members += " and m.role <> '" + string(models.RoleViewer) + "'"
+	}
+	return "((vault_id is null and " + personal + ") or vault_id in (" + members + "))"
+}
+
+// permissionColumn returns the column selecting the permission the entity is shared with to the user
+// referenced by the placeholder, READ for the emergency contacts and the vault viewers and the empty string
+// for the users having any permission.
+func permissionColumn(user string) string {
+	return `case when vault_id is null then
+    				case when owner_id = ` + user + ` then '' else
+    				coalesce((select permission from entity_shares
+    				  where entity_type = entities.type and entity_id = entities.id and user_id = ` + user + `), '` +
+		string(models.PermissionRead) + `') end
+    			  else
+    				coalesce((select '` + string(models.PermissionRead) + `' from vaults v join org_members m on m.org_id = v.org_id
+    				  where v.id = entities.vault_id and m.user_id = ` + user + ` and m.role = '` + string(models.RoleViewer) + `'), '')
+    			  end`
+}
+
+// approverCondition returns the condition matching the entities the user referenced by the placeholder approves
+// the reads of: the owner approves the personal entities, the organization owners and admins approve the vault ones.
+func approverCondition(user string) string {
+	return `case when vault_id is null then owner_id = ` + user + ` else
+    				exists(select 1 from vaults v join org_members m on m.org_id = v.org_id
+    				  where v.id = entities.vault_id and m.user_id = ` + user + ` and m.role in ('` +
+		string(models.RoleOwner) + `', '` + string(models.RoleAdmin) + `'))
+    			  end`
+}
+
+// sharedColumns returns the permissionColumn and the approverCondition columns of the user referenced by the placeholder.
+func sharedColumns(user string) string {
+	return permissionColumn(user) + ", " + approverCondition(user)
+}
+
+// scanSharedEntity scans the entity row selected as entityColumns followed by the sharedColumns.
+func scanSharedEntity(row pgx.Row, extra ...any) (*models.Entity, error) {
+	var p string
+	var approver bool
+	e, err := scanEntity(row, append([]any{&p, &approver}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	e.Permission = models.Permission(p)
+	e.Approver = approver
+	return e, nil
+}
+
+// ShareEntity grants the user with the share email the permission to the owner`s personal entity,
+// the permission granted before is replaced. The vault entities are accessed by the vault members only.
+func (s *Storage) ShareEntity(ctx context.Context, sh *models.Share) (*models.Share, error) {
+	return retry.DoWithData(func() (*models.Share, error) {
+		res := *sh
+		err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+			err := tx.QueryRow(ctx, "select id from users where email = $1", sh.Email).Scan(&res.UserID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return storage.ErrUserNotExist
+				}
+				return err
+			}
+			if res.UserID == sh.OwnerID {
+				return storage.ErrShareWithOwner
+			}
+			var exists bool
+			query := `select exists(select 1 from entities
+						where type = $1 and id = $2 and owner_id = $3 and vault_id is null and deleted_at is null)`
+			if err := tx.QueryRow(ctx, query, sh.Type, sh.EntityID, sh.OwnerID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return notFound(sh.Type)
+			}
+			query = `insert into entity_shares (entity_type, entity_id, owner_id, user_id, permission, created_at)
+						values ($1, $2, $3, $4, $5, $6)
+					  on conflict (entity_type, entity_id, user_id) do update set
+						permission = excluded.permission
+					  returning created_at`
+			return tx.QueryRow(ctx, query, sh.Type, sh.EntityID, sh.OwnerID, res.UserID, sh.Permission, time.Now()).
+				Scan(&res.CreatedAt)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &res, nil
+	}, retryOpts()...)
+}
+
+// RevokeShare revokes the access to the owner`s entity from the user with the email.
+func (s *Storage) RevokeShare(ctx context.Context, t models.EntityType, id int, ownerID int, email string) error {
+	return retry.Do(func() error {
+		query := `delete from
+    				entity_shares
+				  where
+				    entity_type = $1 and entity_id = $2 and owner_id = $3 and user_id = (select id from users where email = $4)`
+		tag, err := s.db.Exec(ctx, query, t, id, ownerID, email)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrShareNotExist
+		}
+		return nil
+	}, retryOpts()...)
+}
+
+// ListShares returns the shares of the owner`s entity in the order they were granted.
+func (s *Storage) ListShares(ctx context.Context, t models.EntityType, id int, ownerID int) ([]*models.Share, error) {
+	return retry.DoWithData(func() ([]*models.Share, error) {
+		var exists bool
+		query := "select exists(select 1 from entities where type = $1 and id = $2 and owner_id = $3 and deleted_at is null)"
+		if err := s.db.QueryRow(ctx, query, t, id, ownerID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, notFound(t)
+		}
+		query = `select
+    				s.entity_type, s.entity_id, s.owner_id, s.user_id, u.email, s.permission, s.created_at
+    			  from
+    				entity_shares s join users u on u.id = s.user_id
+				  where
+				    s.entity_type = $1 and s.entity_id = $2 and s.owner_id = $3
+				  order by s.created_at, u.email`
+		rows, err := s.db.Query(ctx, query, t, id, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		shares := make([]*models.Share, 0)
+		for rows.Next() {
+			sh := &models.Share{}
+			err := rows.Scan(&sh.Type, &sh.EntityID, &sh.OwnerID, &sh.UserID, &sh.Email, &sh.Permission, &sh.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			shares = append(shares, sh)
+		}
+		return shares, rows.Err()
+	}, retryOpts()...)
+}
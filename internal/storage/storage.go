@@ -1,15 +1,209 @@
 // Package storage consists the storage errors
 package storage
 
-import "errors"
+// Kind is the storage error kind.
+type Kind int
+
+const (
+	// KindNotFound - the resource does not exist or belongs to another user
+	KindNotFound Kind = iota + 1
+	// KindConflict - the resource state conflicts with the requested change
+	KindConflict
+	// KindQuota - the owner has exhausted the resource quota
+	KindQuota
+	// KindForbidden - the user`s role does not allow the requested change
+	KindForbidden
+)
+
+// String returns the kind name.
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindConflict:
+		return "conflict"
+	case KindQuota:
+		return "quota exceeded"
+	case KindForbidden:
+		return "forbidden"
+	}
+	return "unknown"
+}
+
+// Error is the typed storage error.
+//
+// Errors are compared by kind, resource and reason, where empty target fields match any value,
+// so errors.Is(err, ErrNotFound) reports any not found error and errors.Is(err, ErrCardNotExist)
+// only the not found card error.
+type Error struct {
+	Kind     Kind
+	Resource string
+	Reason   string
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	msg := e.Reason
+	if msg == "" {
+		switch e.Kind {
+		case KindNotFound:
+			msg = "does not exist"
+		case KindConflict:
+			msg = "already exists"
+		default:
+			msg = e.Kind.String()
+		}
+	}
+	if e.Resource == "" {
+		return msg
+	}
+	return e.Resource + " " + msg
+}
+
+// Is reports whether the error matches the target error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Kind == e.Kind &&
+		(t.Resource == "" || t.Resource == e.Resource) &&
+		(t.Reason == "" || t.Reason == e.Reason)
+}
 
 var (
+	// ErrNotFound - matches any not found error
+	ErrNotFound = &Error{Kind: KindNotFound}
+
+	// ErrConflict - matches any conflict error
+	ErrConflict = &Error{Kind: KindConflict}
+
+	// ErrQuotaExceeded - matches any quota error
+	ErrQuotaExceeded = &Error{Kind: KindQuota}
+
 	// ErrUserAlreadyExists - error if user already exists
-	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserAlreadyExists = &Error{Kind: KindConflict, Resource: "user"}
 
 	// ErrUserNotExist - error if user with provided email does not exist
-	ErrUserNotExist = errors.New("user does not exist")
+	ErrUserNotExist = &Error{Kind: KindNotFound, Resource: "user"}
+
+	// ErrDeviceNotExist - error if device does not exist or belongs to another user
+	ErrDeviceNotExist = &Error{Kind: KindNotFound, Resource: "device"}
+
+	// ErrChallengeNotExist - error if login challenge does not exist, is expired or is already used
+	ErrChallengeNotExist = &Error{Kind: KindNotFound, Resource: "challenge"}
+
+	// ErrPasswordNotExist - error if password does not exist
+	ErrPasswordNotExist = &Error{Kind: KindNotFound, Resource: "password"}
+
+	// ErrCardNotExist - error if card does not exist
+	ErrCardNotExist = &Error{Kind: KindNotFound, Resource: "card"}
+
+	// ErrTextNotExist - error if text does not exist
+	ErrTextNotExist = &Error{Kind: KindNotFound, Resource: "text"}
 
 	// ErrFileNotExist - error if file does not exist
-	ErrFileNotExist = errors.New("file does not exist")
+	ErrFileNotExist = &Error{Kind: KindNotFound, Resource: "file"}
+
+	// ErrVersionNotExist - error if the entity version does not exist
+	ErrVersionNotExist = &Error{Kind: KindNotFound, Resource: "version"}
+
+	// ErrRevisionMismatch - error if the entity has been changed since the expected revision
+	ErrRevisionMismatch = &Error{Kind: KindConflict, Reason: "revision mismatch"}
+
+	// ErrFolderNotExist - error if folder does not exist
+	ErrFolderNotExist = &Error{Kind: KindNotFound, Resource: "folder"}
+
+	// ErrFolderCycle - error if the folder is moved into itself or its subfolder
+	ErrFolderCycle = &Error{Kind: KindConflict, Resource: "folder", Reason: "can not be moved into its subfolder"}
+
+	// ErrTagNotExist - error if tag does not exist
+	ErrTagNotExist = &Error{Kind: KindNotFound, Resource: "tag"}
+
+	// ErrTagAlreadyExists - error if tag with the same name already exists
+	ErrTagAlreadyExists = &Error{Kind: KindConflict, Resource: "tag"}
+
+	// ErrConflictNotExist - error if the entity conflict does not exist
+	ErrConflictNotExist = &Error{Kind: KindNotFound, Resource: "conflict"}
+
+	// ErrShareNotExist - error if the entity is not shared with the user
+	ErrShareNotExist = &Error{Kind: KindNotFound, Resource: "share"}
+
+	// ErrShareWithOwner - error if the entity is shared with its owner
+	ErrShareWithOwner = &Error{Kind: KindConflict, Resource: "share", Reason: "entity can not be shared with its owner"}
+
+	// ErrForbidden - error if the user`s role does not allow the change
+	ErrForbidden = &Error{Kind: KindForbidden}
+
+	// ErrOrgNotExist - error if the organization does not exist or the user is not its member
+	ErrOrgNotExist = &Error{Kind: KindNotFound, Resource: "organization"}
+
+	// ErrOrgNotEmpty - error if the organization vaults have entities
+	ErrOrgNotEmpty = &Error{Kind: KindConflict, Resource: "organization", Reason: "vaults are not empty"}
+
+	// ErrMemberNotExist - error if the user is not the organization member
+	ErrMemberNotExist = &Error{Kind: KindNotFound, Resource: "member"}
+
+	// ErrMemberAlreadyExists - error if the user is the organization member already
+	ErrMemberAlreadyExists = &Error{Kind: KindConflict, Resource: "member"}
+
+	// ErrLastOwner - error if the last organization owner is removed or gets another role
+	ErrLastOwner = &Error{Kind: KindConflict, Resource: "member", Reason: "organization must have an owner"}
+
+	// ErrVaultNotExist - error if the vault does not exist or the user is not a member of its organization
+	ErrVaultNotExist = &Error{Kind: KindNotFound, Resource: "vault"}
+
+	// ErrVaultAlreadyExists - error if the organization has a vault with the same name
+	ErrVaultAlreadyExists = &Error{Kind: KindConflict, Resource: "vault"}
+
+	// ErrVaultNotEmpty - error if the deleted vault has entities
+	ErrVaultNotEmpty = &Error{Kind: KindConflict, Resource: "vault", Reason: "is not empty"}
+
+	// ErrUserKeysNotExist - error if the user has not uploaded the key pair
+	ErrUserKeysNotExist = &Error{Kind: KindNotFound, Resource: "user keys"}
+
+	// ErrPublicKeyMismatch - error if the uploaded public key differs from the registered one
+	ErrPublicKeyMismatch = &Error{Kind: KindConflict, Resource: "user keys", Reason: "public key can not be changed"}
+
+	// ErrVaultKeyVersion - error if the vault key version is neither the current nor the next one
+	ErrVaultKeyVersion = &Error{Kind: KindConflict, Resource: "vault key", Reason: "version is outdated"}
+
+	// ErrVaultKeysIncomplete - error if the rotated vault key is not encrypted to every member
+	ErrVaultKeysIncomplete = &Error{Kind: KindConflict, Resource: "vault key", Reason: "must be encrypted to every member"}
+
+	// ErrVaultRekeyRequired - error if the current vault key is distributed after a member has been removed
+	ErrVaultRekeyRequired = &Error{Kind: KindConflict, Resource: "vault key", Reason: "must be rotated"}
+
+	// ErrSendNotExist - error if the send does not exist, has expired or has been viewed the max times
+	ErrSendNotExist = &Error{Kind: KindNotFound, Resource: "send"}
+
+	// ErrSendPasswordMismatch - error if the send access password is missing or invalid
+	ErrSendPasswordMismatch = &Error{Kind: KindForbidden, Resource: "send", Reason: "password is invalid"}
+
+	// ErrEmergencyContactNotExist - error if the emergency contact does not exist or the user is neither party
+	ErrEmergencyContactNotExist = &Error{Kind: KindNotFound, Resource: "emergency contact"}
+
+	// ErrEmergencyContactAlreadyExists - error if the contact is invited already and has not been revoked
+	ErrEmergencyContactAlreadyExists = &Error{Kind: KindConflict, Resource: "emergency contact"}
+
+	// ErrEmergencyContactSelf - error if the user invites themselves
+	ErrEmergencyContactSelf = &Error{Kind: KindConflict, Resource: "emergency contact", Reason: "user can not be their own contact"}
+
+	// ErrEmergencyTransition - error if the emergency access action is not allowed in the current status
+	ErrEmergencyTransition = &Error{Kind: KindConflict, Resource: "emergency access", Reason: "action is not allowed in the current status"}
+
+	// ErrAccessRequestNotExist - error if the access request does not exist or is not approved by the user
+	ErrAccessRequestNotExist = &Error{Kind: KindNotFound, Resource: "access request"}
+
+	// ErrAccessRequestAlreadyExists - error if the user has the pending request to the entity already
+	ErrAccessRequestAlreadyExists = &Error{Kind: KindConflict, Resource: "access request"}
+
+	// ErrAccessRequestDecided - error if the access request has been approved or denied already
+	ErrAccessRequestDecided = &Error{Kind: KindConflict, Resource: "access request", Reason: "request has been decided already"}
+
+	// ErrApprovalRequired - error if the entity is read without the approved access request
+	ErrApprovalRequired = &Error{Kind: KindForbidden, Resource: "entity", Reason: "read has to be approved"}
+
+	// ErrJobRunNotExist - error if job has never been run
+	ErrJobRunNotExist = &Error{Kind: KindNotFound, Resource: "job run"}
 )
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+
+	mismatch := &Error{Kind: KindConflict, Resource: "card", Reason: ErrRevisionMismatch.Reason}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		is     bool
+	}{
+		{
+			name:   "same error",
+			err:    ErrCardNotExist,
+			target: ErrCardNotExist,
+			is:     true,
+		},
+		{
+			name:   "any not found",
+			err:    ErrCardNotExist,
+			target: ErrNotFound,
+			is:     true,
+		},
+		{
+			name:   "wrapped",
+			err:    fmt.Errorf("failed to get card: %w", ErrCardNotExist),
+			target: ErrNotFound,
+			is:     true,
+		},
+		{
+			name:   "other resource",
+			err:    ErrCardNotExist,
+			target: ErrTextNotExist,
+			is:     false,
+		},
+		{
+			name:   "other kind",
+			err:    ErrCardNotExist,
+			target: ErrConflict,
+			is:     false,
+		},
+		{
+			name:   "revision mismatch",
+			err:    mismatch,
+			target: ErrRevisionMismatch,
+			is:     true,
+		},
+		{
+			name:   "conflict is not revision mismatch",
+			err:    ErrUserAlreadyExists,
+			target: ErrRevisionMismatch,
+			is:     false,
+		},
+		{
+			name:   "plain error",
+			err:    ErrCardNotExist,
+			target: errors.New("card does not exist"),
+			is:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.is, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	assert.Equal(t, "card does not exist", ErrCardNotExist.Error())
+	assert.Equal(t, "user already exists", ErrUserAlreadyExists.Error())
+	assert.Equal(t, "card revision mismatch", (&Error{Kind: KindConflict, Resource: "card", Reason: "revision mismatch"}).Error())
+	assert.Equal(t, "quota exceeded", ErrQuotaExceeded.Error())
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tags_name_trgm;
+DROP INDEX IF EXISTS idx_entities_search_trgm;
+DROP INDEX IF EXISTS idx_entities_search_fts;
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "search_text";
+DROP FUNCTION IF EXISTS entity_search_text(jsonb, text);
+DROP EXTENSION IF EXISTS pg_trgm;
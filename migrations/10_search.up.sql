@@ -0,0 +1,19 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- entity_search_text returns the searchable text of the entity, the secrets (passwords, card numbers, CVCs
+-- and custom field values) are never included. Text bodies are truncated to keep the indexes small.
+CREATE OR REPLACE FUNCTION entity_search_text(data jsonb, metadata text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE AS
+$$
+SELECT concat_ws(' ', data ->> 'login', data ->> 'owner', left(data ->> 'text', 10000), data ->> 'filename', metadata,
+                 (SELECT string_agg(f ->> 'name', ' ')
+                  FROM jsonb_array_elements(CASE
+                                                WHEN jsonb_typeof(data -> 'custom_fields') = 'array'
+                                                    THEN data -> 'custom_fields'
+                                                ELSE '[]' END) f))
+$$;
+
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "search_text" text GENERATED ALWAYS AS (entity_search_text(data, metadata)) STORED;
+CREATE INDEX IF NOT EXISTS idx_entities_search_fts ON entities USING gin (to_tsvector('simple', search_text));
+CREATE INDEX IF NOT EXISTS idx_entities_search_trgm ON entities USING gin (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tags_name_trgm ON tags USING gin (name gin_trgm_ops);
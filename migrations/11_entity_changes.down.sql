@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS entity_changes ON entities;
+DROP FUNCTION IF EXISTS record_entity_change();
+DROP TABLE IF EXISTS "entity_changes";
+DROP TABLE IF EXISTS "change_cursors";
@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS "change_cursors" (
+                        "owner_id" integer PRIMARY KEY NOT NULL,
+                        "seq" bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "entity_changes" (
+                        "owner_id" integer NOT NULL,
+                        "seq" bigint NOT NULL,
+                        "kind" text NOT NULL,
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "revision" bigint NOT NULL,
+                        "changed_at" timestamp NOT NULL,
+                        PRIMARY KEY ("owner_id", "seq")
+);
+
+-- record_entity_change appends the entity change to the owner`s change log and notifies the watchers.
+-- The owner`s change_cursors row is locked until the commit, so the changes of the owner are committed
+-- in the seq order and a reader never skips a change. Files are recorded once they are uploaded.
+CREATE OR REPLACE FUNCTION record_entity_change() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    e           entities;
+    change_kind text;
+    change_seq  bigint;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.data @> '{"uploaded": false}' THEN
+            RETURN NULL;
+        END IF;
+        e := OLD;
+        change_kind := 'DELETED';
+    ELSE
+        IF NEW.data @> '{"uploaded": false}' THEN
+            RETURN NULL;
+        END IF;
+        e := NEW;
+        IF TG_OP = 'INSERT' OR OLD.data @> '{"uploaded": false}' THEN
+            change_kind := 'CREATED';
+        ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            change_kind := 'TRASHED';
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            change_kind := 'RESTORED';
+        ELSIF OLD.revision <> NEW.revision THEN
+            change_kind := 'UPDATED';
+        ELSE
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    INSERT INTO change_cursors AS c (owner_id, seq) VALUES (e.owner_id, 1)
+    ON CONFLICT (owner_id) DO UPDATE SET seq = c.seq + 1
+    RETURNING seq INTO change_seq;
+
+    INSERT INTO entity_changes (owner_id, seq, kind, entity_type, entity_id, revision, changed_at)
+    VALUES (e.owner_id, change_seq, change_kind, e.type, e.id, e.revision, localtimestamp);
+
+    PERFORM pg_notify('entity_changes', e.owner_id::text);
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER entity_changes
+    AFTER INSERT OR UPDATE OR DELETE ON entities
+    FOR EACH ROW EXECUTE FUNCTION record_entity_change();
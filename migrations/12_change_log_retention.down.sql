@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_entity_changes_changed_at;
+ALTER TABLE "change_cursors" DROP COLUMN IF EXISTS "pruned_seq";
@@ -0,0 +1,2 @@
+ALTER TABLE "change_cursors" ADD COLUMN IF NOT EXISTS "pruned_seq" bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_entity_changes_changed_at ON entity_changes (changed_at);
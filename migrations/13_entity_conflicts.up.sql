@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "entity_conflicts" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "owner_id" integer NOT NULL,
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "base_revision" bigint NOT NULL,
+                        "server_revision" bigint NOT NULL,
+                        "fields" text[] NOT NULL,
+                        "conflicting_fields" text[] NOT NULL,
+                        "data" jsonb NOT NULL,
+                        "created_at" timestamp NOT NULL
+);
+ALTER TABLE "entity_conflicts" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "entity_conflicts" ADD FOREIGN KEY ("entity_type", "entity_id") REFERENCES "entities" ("type", "id") ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_entity_conflicts_owner ON entity_conflicts (owner_id, id);
+CREATE INDEX IF NOT EXISTS idx_entity_conflicts_entity ON entity_conflicts (entity_type, entity_id);
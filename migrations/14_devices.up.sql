@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "devices" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "user_id" integer NOT NULL,
+                        "name" text NOT NULL,
+                        "public_key" bytea NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        "last_login_at" timestamp NOT NULL
+);
+ALTER TABLE "devices" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_devices_user ON devices (user_id, id);
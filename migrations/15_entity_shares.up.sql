@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "entity_shares" (
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "user_id" integer NOT NULL,
+                        "permission" text NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        PRIMARY KEY ("entity_type", "entity_id", "user_id"),
+                        CHECK ("owner_id" <> "user_id")
+);
+ALTER TABLE "entity_shares" ADD FOREIGN KEY ("entity_type", "entity_id") REFERENCES "entities" ("type", "id") ON DELETE CASCADE;
+ALTER TABLE "entity_shares" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "entity_shares" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_entity_shares_user ON entity_shares (user_id, entity_type, entity_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_entities_vault;
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "vault_id";
+
+DROP TABLE IF EXISTS "vaults";
+DROP TABLE IF EXISTS "org_members";
+DROP TABLE IF EXISTS "organizations";
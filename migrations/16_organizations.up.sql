@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS "organizations" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "name" text NOT NULL,
+                        "created_at" timestamp NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "org_members" (
+                        "org_id" integer NOT NULL,
+                        "user_id" integer NOT NULL,
+                        "role" text NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        PRIMARY KEY ("org_id", "user_id")
+);
+ALTER TABLE "org_members" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+ALTER TABLE "org_members" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_org_members_user ON org_members (user_id);
+
+CREATE TABLE IF NOT EXISTS "vaults" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "org_id" integer NOT NULL,
+                        "name" text NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        UNIQUE ("org_id", "name")
+);
+ALTER TABLE "vaults" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "vault_id" integer;
+ALTER TABLE "entities" ADD FOREIGN KEY ("vault_id") REFERENCES "vaults" ("id");
+CREATE INDEX IF NOT EXISTS idx_entities_vault ON entities (vault_id) WHERE vault_id IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "vault_keys";
+
+ALTER TABLE "vaults" DROP COLUMN IF EXISTS "rekey_required";
+ALTER TABLE "vaults" DROP COLUMN IF EXISTS "key_version";
+
+DROP TABLE IF EXISTS "user_keys";
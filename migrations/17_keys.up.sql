@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "user_keys" (
+                        "user_id" integer PRIMARY KEY,
+                        "public_key" bytea NOT NULL,
+                        "wrapped_private_key" bytea NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL
+);
+ALTER TABLE "user_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "vaults" ADD COLUMN IF NOT EXISTS "key_version" integer NOT NULL DEFAULT 0;
+ALTER TABLE "vaults" ADD COLUMN IF NOT EXISTS "rekey_required" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "vault_keys" (
+                        "vault_id" integer NOT NULL,
+                        "user_id" integer NOT NULL,
+                        "version" integer NOT NULL,
+                        "encrypted_key" bytea NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        PRIMARY KEY ("vault_id", "user_id", "version")
+);
+ALTER TABLE "vault_keys" ADD FOREIGN KEY ("vault_id") REFERENCES "vaults" ("id") ON DELETE CASCADE;
+ALTER TABLE "vault_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_vault_keys_user ON vault_keys (user_id);
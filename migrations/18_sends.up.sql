@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "sends" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "owner_id" integer NOT NULL,
+                        "token_hash" bytea NOT NULL UNIQUE,
+                        "kind" text NOT NULL,
+                        "filename" text NOT NULL DEFAULT '',
+                        "payload" bytea NOT NULL,
+                        "password_hash" bytea,
+                        "max_views" integer NOT NULL,
+                        "views" integer NOT NULL DEFAULT 0,
+                        "expires_at" timestamp NOT NULL,
+                        "created_at" timestamp NOT NULL
+);
+ALTER TABLE "sends" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_sends_owner ON sends (owner_id, id);
+CREATE INDEX IF NOT EXISTS idx_sends_expires_at ON sends (expires_at);
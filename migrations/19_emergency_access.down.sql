@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "emergency_events";
+DROP TABLE IF EXISTS "emergency_contacts";
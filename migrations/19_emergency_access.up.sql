@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "emergency_contacts" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "grantor_id" integer NOT NULL,
+                        "grantee_id" integer NOT NULL,
+                        "status" text NOT NULL,
+                        "wait_seconds" bigint NOT NULL,
+                        "requested_at" timestamp,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        UNIQUE ("grantor_id", "grantee_id"),
+                        CHECK ("grantor_id" <> "grantee_id")
+);
+ALTER TABLE "emergency_contacts" ADD FOREIGN KEY ("grantor_id") REFERENCES "users" ("id");
+ALTER TABLE "emergency_contacts" ADD FOREIGN KEY ("grantee_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_grantee ON emergency_contacts (grantee_id);
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_requested ON emergency_contacts (requested_at) WHERE status = 'REQUESTED';
+
+CREATE TABLE IF NOT EXISTS "emergency_events" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "contact_id" integer NOT NULL,
+                        "actor_id" integer,
+                        "action" text NOT NULL,
+                        "from_status" text NOT NULL,
+                        "to_status" text NOT NULL,
+                        "created_at" timestamp NOT NULL
+);
+ALTER TABLE "emergency_events" ADD FOREIGN KEY ("contact_id") REFERENCES "emergency_contacts" ("id");
+ALTER TABLE "emergency_events" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_emergency_events_contact ON emergency_events (contact_id, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "access_events";
+DROP TABLE IF EXISTS "access_requests";
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "requires_approval";
@@ -0,0 +1,32 @@
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "requires_approval" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "access_requests" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "requester_id" integer NOT NULL,
+                        "reason" text NOT NULL,
+                        "status" text NOT NULL,
+                        "approver_id" integer,
+                        "expires_at" timestamp,
+                        "created_at" timestamp NOT NULL,
+                        "decided_at" timestamp
+);
+ALTER TABLE "access_requests" ADD FOREIGN KEY ("entity_type", "entity_id") REFERENCES "entities" ("type", "id") ON DELETE CASCADE;
+ALTER TABLE "access_requests" ADD FOREIGN KEY ("requester_id") REFERENCES "users" ("id");
+ALTER TABLE "access_requests" ADD FOREIGN KEY ("approver_id") REFERENCES "users" ("id");
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_requests_pending ON access_requests (entity_type, entity_id, requester_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_access_requests_requester ON access_requests (requester_id, expires_at);
+
+-- the audit trail outlives the entity and its requests
+CREATE TABLE IF NOT EXISTS "access_events" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "request_id" integer,
+                        "actor_id" integer NOT NULL,
+                        "action" text NOT NULL,
+                        "created_at" timestamp NOT NULL
+);
+ALTER TABLE "access_events" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_access_events_entity ON access_events (entity_type, entity_id, id);
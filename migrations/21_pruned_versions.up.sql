@@ -0,0 +1,3 @@
+-- pruned_revision is the latest revision of the entity whose archived version was pruned,
+-- the content at the revisions up to it is unknown.
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "pruned_revision" bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS "device_challenges" (
+                        "nonce" bytea PRIMARY KEY,
+                        "expires_at" timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_device_challenges_expires ON device_challenges (expires_at);
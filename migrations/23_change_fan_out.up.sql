@@ -0,0 +1,176 @@
+-- entity_readers returns the users reading the entity: the owner of the personal entity, the users it is shared with
+-- and the owner`s emergency contacts granted the access, or the members of the organization of the vault entity.
+CREATE OR REPLACE FUNCTION entity_readers(e entities) RETURNS SETOF integer
+    LANGUAGE sql STABLE AS
+$$
+SELECT e.owner_id WHERE e.vault_id IS NULL
+UNION
+SELECT user_id FROM entity_shares WHERE e.vault_id IS NULL AND entity_type = e.type AND entity_id = e.id
+UNION
+SELECT grantee_id FROM emergency_contacts WHERE e.vault_id IS NULL AND grantor_id = e.owner_id AND status = 'GRANTED'
+UNION
+SELECT m.user_id FROM vaults v JOIN org_members m ON m.org_id = v.org_id WHERE v.id = e.vault_id
+$$;
+
+-- append_entity_change appends the entity change to the user`s change log and notifies the user`s watchers.
+-- The user`s change_cursors row is locked until the commit, so the changes of the user are committed
+-- in the seq order and a reader never skips a change.
+CREATE OR REPLACE FUNCTION append_entity_change(log_user integer, change_kind text, e entities) RETURNS void
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    change_seq bigint;
+BEGIN
+    INSERT INTO change_cursors AS c (owner_id, seq) VALUES (log_user, 1)
+    ON CONFLICT (owner_id) DO UPDATE SET seq = c.seq + 1
+    RETURNING seq INTO change_seq;
+
+    INSERT INTO entity_changes (owner_id, seq, kind, entity_type, entity_id, revision, changed_at)
+    VALUES (log_user, change_seq, change_kind, e.type, e.id, e.revision, localtimestamp);
+
+    PERFORM pg_notify('entity_changes', log_user::text);
+END
+$$;
+
+-- record_entity_change appends the entity change to the change logs of all entity readers, the readers are locked
+-- in the id order. The deletion is recorded before the shares of the entity are deleted with it.
+-- Files are recorded once they are uploaded.
+CREATE OR REPLACE FUNCTION record_entity_change() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    e           entities;
+    change_kind text;
+    reader      integer;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        e := OLD;
+        change_kind := 'DELETED';
+    ELSE
+        e := NEW;
+        IF TG_OP = 'INSERT' OR OLD.data @> '{"uploaded": false}' THEN
+            change_kind := 'CREATED';
+        ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            change_kind := 'TRASHED';
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            change_kind := 'RESTORED';
+        ELSIF OLD.revision <> NEW.revision THEN
+            change_kind := 'UPDATED';
+        END IF;
+    END IF;
+
+    IF change_kind IS NOT NULL AND NOT e.data @> '{"uploaded": false}' THEN
+        FOR reader IN SELECT r FROM entity_readers(e) r ORDER BY r LOOP
+            PERFORM append_entity_change(reader, change_kind, e);
+        END LOOP;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+DROP TRIGGER IF EXISTS entity_changes ON entities;
+CREATE TRIGGER entity_changes
+    AFTER INSERT OR UPDATE ON entities
+    FOR EACH ROW EXECUTE FUNCTION record_entity_change();
+CREATE TRIGGER entity_changes_delete
+    BEFORE DELETE ON entities
+    FOR EACH ROW EXECUTE FUNCTION record_entity_change();
+
+-- record_access_change appends the entity the user gained the access to as created and the entity the user
+-- lost the access to as deleted, unless the user still reads it. Files are recorded once they are uploaded.
+CREATE OR REPLACE FUNCTION record_access_change(log_user integer, gained boolean, e entities) RETURNS void
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF e.data @> '{"uploaded": false}' THEN
+        RETURN;
+    END IF;
+    IF gained THEN
+        PERFORM append_entity_change(log_user, 'CREATED', e);
+    ELSIF NOT EXISTS(SELECT 1 FROM entity_readers(e) r WHERE r = log_user) THEN
+        PERFORM append_entity_change(log_user, 'DELETED', e);
+    END IF;
+END
+$$;
+
+-- record_share_change records the shared entity in the change log of the user it is shared with or revoked from.
+-- The shares deleted with the entity are skipped, the entity deletion is recorded for the user.
+CREATE OR REPLACE FUNCTION record_share_change() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    e entities;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        SELECT * INTO e FROM entities WHERE type = NEW.entity_type AND id = NEW.entity_id;
+        IF FOUND THEN
+            PERFORM record_access_change(NEW.user_id, true, e);
+        END IF;
+    ELSE
+        SELECT * INTO e FROM entities WHERE type = OLD.entity_type AND id = OLD.entity_id;
+        IF FOUND THEN
+            PERFORM record_access_change(OLD.user_id, false, e);
+        END IF;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER entity_shares_changes
+    AFTER INSERT OR DELETE ON entity_shares
+    FOR EACH ROW EXECUTE FUNCTION record_share_change();
+
+-- record_member_change records the organization vault entities in the change log of the member joining
+-- or leaving the organization.
+CREATE OR REPLACE FUNCTION record_member_change() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    e entities;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        FOR e IN SELECT x.* FROM entities x JOIN vaults v ON v.id = x.vault_id
+                 WHERE v.org_id = NEW.org_id ORDER BY x.type, x.id LOOP
+            PERFORM record_access_change(NEW.user_id, true, e);
+        END LOOP;
+    ELSE
+        FOR e IN SELECT x.* FROM entities x JOIN vaults v ON v.id = x.vault_id
+                 WHERE v.org_id = OLD.org_id ORDER BY x.type, x.id LOOP
+            PERFORM record_access_change(OLD.user_id, false, e);
+        END LOOP;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER org_members_changes
+    AFTER INSERT OR DELETE ON org_members
+    FOR EACH ROW EXECUTE FUNCTION record_member_change();
+
+-- record_emergency_change records the grantor`s personal entities in the change log of the emergency contact
+-- the access is granted to or taken from.
+CREATE OR REPLACE FUNCTION record_emergency_change() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    e           entities;
+    was_granted boolean := OLD.status = 'GRANTED';
+    is_granted  boolean := TG_OP = 'UPDATE' AND NEW.status = 'GRANTED';
+BEGIN
+    IF was_granted = is_granted THEN
+        RETURN NULL;
+    END IF;
+    FOR e IN SELECT * FROM entities WHERE owner_id = OLD.grantor_id AND vault_id IS NULL ORDER BY type, id LOOP
+        PERFORM record_access_change(OLD.grantee_id, is_granted, e);
+    END LOOP;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER emergency_contacts_changes
+    AFTER UPDATE OF status OR DELETE ON emergency_contacts
+    FOR EACH ROW EXECUTE FUNCTION record_emergency_change();
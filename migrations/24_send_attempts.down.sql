@@ -0,0 +1 @@
+ALTER TABLE "sends" DROP COLUMN IF EXISTS "failed_attempts";
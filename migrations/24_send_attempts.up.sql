@@ -0,0 +1 @@
+ALTER TABLE "sends" ADD COLUMN IF NOT EXISTS "failed_attempts" integer NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS job_runs CASCADE;
\ No newline at end of file
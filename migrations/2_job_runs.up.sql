@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS "job_runs" (
+                            "name" text UNIQUE PRIMARY KEY NOT NULL,
+                            "scheduled_at" timestamp NOT NULL,
+                            "started_at" timestamp NOT NULL,
+                            "finished_at" timestamp NOT NULL,
+                            "error" text NOT NULL DEFAULT ''
+);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_passwords_owner_created;
+DROP INDEX IF EXISTS idx_passwords_owner_updated;
+ALTER TABLE "passwords" DROP COLUMN IF EXISTS "updated_at";
+
+DROP INDEX IF EXISTS idx_cards_owner_created;
+DROP INDEX IF EXISTS idx_cards_owner_updated;
+ALTER TABLE "cards" DROP COLUMN IF EXISTS "updated_at";
+
+DROP INDEX IF EXISTS idx_texts_owner_created;
+DROP INDEX IF EXISTS idx_texts_owner_updated;
+ALTER TABLE "texts" DROP COLUMN IF EXISTS "updated_at";
+
+DROP INDEX IF EXISTS idx_files_owner_created;
+DROP INDEX IF EXISTS idx_files_owner_updated;
+ALTER TABLE "files" DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,23 @@
+ALTER TABLE "passwords" ADD COLUMN IF NOT EXISTS "updated_at" timestamp;
+UPDATE "passwords" SET "updated_at" = "created_at";
+ALTER TABLE "passwords" ALTER COLUMN "updated_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_passwords_owner_created ON passwords (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_passwords_owner_updated ON passwords (owner_id, updated_at, id);
+
+ALTER TABLE "cards" ADD COLUMN IF NOT EXISTS "updated_at" timestamp;
+UPDATE "cards" SET "updated_at" = "created_at";
+ALTER TABLE "cards" ALTER COLUMN "updated_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_cards_owner_created ON cards (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_cards_owner_updated ON cards (owner_id, updated_at, id);
+
+ALTER TABLE "texts" ADD COLUMN IF NOT EXISTS "updated_at" timestamp;
+UPDATE "texts" SET "updated_at" = "created_at";
+ALTER TABLE "texts" ALTER COLUMN "updated_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_texts_owner_created ON texts (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_texts_owner_updated ON texts (owner_id, updated_at, id);
+
+ALTER TABLE "files" ADD COLUMN IF NOT EXISTS "updated_at" timestamp;
+UPDATE "files" SET "updated_at" = "created_at";
+ALTER TABLE "files" ALTER COLUMN "updated_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_files_owner_created ON files (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_files_owner_updated ON files (owner_id, updated_at, id);
@@ -0,0 +1,4 @@
+ALTER TABLE "passwords" DROP COLUMN IF EXISTS "revision";
+ALTER TABLE "cards" DROP COLUMN IF EXISTS "revision";
+ALTER TABLE "texts" DROP COLUMN IF EXISTS "revision";
+ALTER TABLE "files" DROP COLUMN IF EXISTS "revision";
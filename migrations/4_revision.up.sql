@@ -0,0 +1,4 @@
+ALTER TABLE "passwords" ADD COLUMN IF NOT EXISTS "revision" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "cards" ADD COLUMN IF NOT EXISTS "revision" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "texts" ADD COLUMN IF NOT EXISTS "revision" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "files" ADD COLUMN IF NOT EXISTS "revision" bigint NOT NULL DEFAULT 1;
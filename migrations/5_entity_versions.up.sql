@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "entity_versions" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+                        "type" text NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "revision" bigint NOT NULL,
+                        "data" jsonb NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "archived_at" timestamp NOT NULL,
+                        UNIQUE ("type", "entity_id", "revision")
+);
+CREATE INDEX IF NOT EXISTS idx_entity_versions_owner ON entity_versions (owner_id, type, entity_id);
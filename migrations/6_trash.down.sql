@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_passwords_deleted;
+ALTER TABLE "passwords" DROP COLUMN IF EXISTS "deleted_at";
+
+DROP INDEX IF EXISTS idx_cards_deleted;
+ALTER TABLE "cards" DROP COLUMN IF EXISTS "deleted_at";
+
+DROP INDEX IF EXISTS idx_texts_deleted;
+ALTER TABLE "texts" DROP COLUMN IF EXISTS "deleted_at";
+
+DROP INDEX IF EXISTS idx_files_deleted;
+ALTER TABLE "files" DROP COLUMN IF EXISTS "deleted_at";
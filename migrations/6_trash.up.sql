@@ -0,0 +1,11 @@
+ALTER TABLE "passwords" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp;
+CREATE INDEX IF NOT EXISTS idx_passwords_deleted ON passwords (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE "cards" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp;
+CREATE INDEX IF NOT EXISTS idx_cards_deleted ON cards (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE "texts" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp;
+CREATE INDEX IF NOT EXISTS idx_texts_deleted ON texts (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE "files" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp;
+CREATE INDEX IF NOT EXISTS idx_files_deleted ON files (deleted_at) WHERE deleted_at IS NOT NULL;
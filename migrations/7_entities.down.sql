@@ -0,0 +1,91 @@
+CREATE TABLE IF NOT EXISTS "passwords" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "login" text NOT NULL,
+                        "password" text NOT NULL,
+                        "metadata" text,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "revision" bigint NOT NULL DEFAULT 1,
+                        "deleted_at" timestamp
+);
+CREATE INDEX IF NOT EXISTS idx_passwords_owner_id ON passwords (owner_id);
+CREATE INDEX IF NOT EXISTS idx_passwords_owner_created ON passwords (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_passwords_owner_updated ON passwords (owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_passwords_deleted ON passwords (deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE "passwords" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+CREATE TABLE IF NOT EXISTS "cards" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "number" text NOT NULL,
+                        "cvc" text NOT NULL,
+                        "owner" text NOT NULL,
+                        "date" text NOT NULL,
+                        "metadata" text,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "revision" bigint NOT NULL DEFAULT 1,
+                        "deleted_at" timestamp
+);
+CREATE INDEX IF NOT EXISTS idx_cards_owner_id ON cards (owner_id);
+CREATE INDEX IF NOT EXISTS idx_cards_owner_created ON cards (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_cards_owner_updated ON cards (owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_cards_deleted ON cards (deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE "cards" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+CREATE TABLE IF NOT EXISTS "texts" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "text" text NOT NULL,
+                        "metadata" text,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "revision" bigint NOT NULL DEFAULT 1,
+                        "deleted_at" timestamp
+);
+CREATE INDEX IF NOT EXISTS idx_texts_owner_id ON texts (owner_id);
+CREATE INDEX IF NOT EXISTS idx_texts_owner_created ON texts (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_texts_owner_updated ON texts (owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_texts_deleted ON texts (deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE "texts" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+CREATE TABLE IF NOT EXISTS "files" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "filename" text NOT NULL,
+                        "uploaded" boolean default false,
+                        "metadata" text,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "revision" bigint NOT NULL DEFAULT 1,
+                        "deleted_at" timestamp
+);
+CREATE INDEX IF NOT EXISTS idx_files_owner_id ON files (owner_id);
+CREATE INDEX IF NOT EXISTS idx_files_owner_created ON files (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_files_owner_updated ON files (owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_files_deleted ON files (deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE "files" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+INSERT INTO "passwords" (id, owner_id, login, password, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, owner_id, data->>'login', data->>'password', metadata, created_at, updated_at, revision, deleted_at
+FROM entities WHERE type = 'PASSWORD';
+
+INSERT INTO "cards" (id, owner_id, number, cvc, owner, date, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, owner_id, data->>'number', data->>'cvc', data->>'owner', data->>'date', metadata, created_at, updated_at, revision, deleted_at
+FROM entities WHERE type = 'CARD';
+
+INSERT INTO "texts" (id, owner_id, text, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, owner_id, data->>'text', metadata, created_at, updated_at, revision, deleted_at
+FROM entities WHERE type = 'TEXT';
+
+INSERT INTO "files" (id, owner_id, filename, uploaded, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, owner_id, data->>'filename', coalesce((data->>'uploaded')::boolean, true), metadata, created_at, updated_at, revision, deleted_at
+FROM entities WHERE type = 'FILE';
+
+SELECT setval(pg_get_serial_sequence('passwords', 'id'), coalesce((SELECT max(id) FROM passwords), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('cards', 'id'), coalesce((SELECT max(id) FROM cards), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('texts', 'id'), coalesce((SELECT max(id) FROM texts), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('files', 'id'), coalesce((SELECT max(id) FROM files), 0) + 1, false);
+
+DROP TABLE IF EXISTS entities;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS "entities" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+                        "type" text COLLATE "C" NOT NULL,
+                        "owner_id" integer NOT NULL,
+                        "data" jsonb NOT NULL,
+                        "metadata" text NOT NULL DEFAULT '',
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL,
+                        "revision" bigint NOT NULL DEFAULT 1,
+                        "deleted_at" timestamp,
+                        PRIMARY KEY ("type", "id")
+);
+ALTER TABLE "entities" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+CREATE INDEX IF NOT EXISTS idx_entities_owner_created ON entities (owner_id, created_at, type, id);
+CREATE INDEX IF NOT EXISTS idx_entities_owner_updated ON entities (owner_id, updated_at, type, id);
+CREATE INDEX IF NOT EXISTS idx_entities_deleted ON entities (deleted_at) WHERE deleted_at IS NOT NULL;
+
+INSERT INTO "entities" (id, type, owner_id, data, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, 'PASSWORD', owner_id, jsonb_build_object('login', login, 'password', password),
+       coalesce(metadata, ''), created_at, updated_at, revision, deleted_at
+FROM passwords;
+
+INSERT INTO "entities" (id, type, owner_id, data, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, 'CARD', owner_id, jsonb_build_object('number', number, 'cvc', cvc, 'owner', owner, 'date', date),
+       coalesce(metadata, ''), created_at, updated_at, revision, deleted_at
+FROM cards;
+
+INSERT INTO "entities" (id, type, owner_id, data, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, 'TEXT', owner_id, jsonb_build_object('text', text),
+       coalesce(metadata, ''), created_at, updated_at, revision, deleted_at
+FROM texts;
+
+INSERT INTO "entities" (id, type, owner_id, data, metadata, created_at, updated_at, revision, deleted_at)
+SELECT id, 'FILE', owner_id, jsonb_build_object('filename', filename, 'uploaded', coalesce(uploaded, false)),
+       coalesce(metadata, ''), created_at, updated_at, revision, deleted_at
+FROM files;
+
+-- the migrated entities keep their ids, the new ones get the ids unique across all types
+SELECT setval(pg_get_serial_sequence('entities', 'id'), coalesce((SELECT max(id) FROM entities), 0) + 1, false);
+
+DROP TABLE IF EXISTS passwords;
+DROP TABLE IF EXISTS cards;
+DROP TABLE IF EXISTS texts;
+DROP TABLE IF EXISTS files;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "entity_tags";
+
+DROP INDEX IF EXISTS idx_entities_folder;
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "folder_id";
+
+DROP TABLE IF EXISTS "tags";
+DROP TABLE IF EXISTS "folders";
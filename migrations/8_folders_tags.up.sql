@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS "folders" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "owner_id" integer NOT NULL,
+                        "parent_id" integer,
+                        "name" text NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        "updated_at" timestamp NOT NULL
+);
+ALTER TABLE "folders" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "folders" ADD FOREIGN KEY ("parent_id") REFERENCES "folders" ("id");
+CREATE INDEX IF NOT EXISTS idx_folders_owner ON folders (owner_id, parent_id);
+
+CREATE TABLE IF NOT EXISTS "tags" (
+                        "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+                        "owner_id" integer NOT NULL,
+                        "name" text NOT NULL,
+                        "created_at" timestamp NOT NULL,
+                        UNIQUE ("owner_id", "name")
+);
+ALTER TABLE "tags" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "folder_id" integer;
+ALTER TABLE "entities" ADD FOREIGN KEY ("folder_id") REFERENCES "folders" ("id");
+CREATE INDEX IF NOT EXISTS idx_entities_folder ON entities (folder_id) WHERE folder_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "entity_tags" (
+                        "entity_type" text COLLATE "C" NOT NULL,
+                        "entity_id" integer NOT NULL,
+                        "tag_id" integer NOT NULL,
+                        PRIMARY KEY ("entity_type", "entity_id", "tag_id")
+);
+ALTER TABLE "entity_tags" ADD FOREIGN KEY ("entity_type", "entity_id") REFERENCES "entities" ("type", "id") ON DELETE CASCADE;
+ALTER TABLE "entity_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_entity_tags_tag ON entity_tags (tag_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_entities_labels;
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "labels";
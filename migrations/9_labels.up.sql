@@ -0,0 +1,2 @@
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "labels" jsonb NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_entities_labels ON entities USING gin (labels);
@@ -11,23 +11,32 @@ import (
 )
 
 func ExtractUID(ctx context.Context) (int, error) {
+	return extractInt(ctx, "uid")
+}
+
+// ExtractDeviceID returns the id of the device the request token is bound to.
+func ExtractDeviceID(ctx context.Context) (int, error) {
+	return extractInt(ctx, "did")
+}
+
+func extractInt(ctx context.Context, key string) (int, error) {
 
-	errFailedToGetUID := errors.New("failed to extract uid from metadata")
+	errFailedToGet := errors.New("failed to extract " + key + " from metadata")
 
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		sl.Log.Info("no metadata in context")
-		return 0, errFailedToGetUID
+		return 0, errFailedToGet
 	}
-	uidMd := md.Get("uid")
-	if len(uidMd) != 1 {
-		sl.Log.Info("no uid in metadata")
-		return 0, errFailedToGetUID
+	v := md.Get(key)
+	if len(v) != 1 {
+		sl.Log.Info("no " + key + " in metadata")
+		return 0, errFailedToGet
 	}
-	uid, err := strconv.Atoi(uidMd[0])
+	n, err := strconv.Atoi(v[0])
 	if err != nil {
-		sl.Log.Error("failed to convert uid to int", err)
-		return 0, errFailedToGetUID
+		sl.Log.Error("failed to convert "+key+" to int", sl.Err(err))
+		return 0, errFailedToGet
 	}
-	return uid, nil
+	return n, nil
 }